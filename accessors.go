@@ -0,0 +1,62 @@
+package dispatchbidder
+
+import "time"
+
+// Bid returns the bidder's CurrentBid. Unlike reading the field, it is safe
+// to call while the bidder's auction is bidding.
+func (b *Bidder) Bid() float64 {
+	defer b.rlock()()
+
+	return b.CurrentBid
+}
+
+// BidTime returns the bidder's LastBidTime. Unlike reading the field, it is
+// safe to call while the bidder's auction is bidding.
+func (b *Bidder) BidTime() time.Time {
+	defer b.rlock()()
+
+	return b.LastBidTime
+}
+
+// View returns a consistent read-only copy of the bidder, with their status
+// in the auction. A bidder outside any auction is reported Active.
+func (b *Bidder) View() BidderView {
+	defer b.rlock()()
+
+	v := b.view()
+	if a := b.auction(); a != nil {
+		v.Status = a.status(b, a.determineWinner())
+	}
+	return v
+}
+
+// rlock takes a read lock on the bidder's auction, if any, and returns the
+// function releasing it. The accessors must not be called with the lock
+// already held, such as from a StopCondition.
+func (b *Bidder) rlock() func() {
+	for {
+		a := b.auction()
+		if a == nil {
+			return func() {}
+		}
+		a.RLock()
+		if b.auction() == a {
+			return a.RUnlock
+		}
+		// The bidder left the auction before the lock was taken.
+		a.RUnlock()
+	}
+}
+
+// auction returns the auction whose lock guards the bidder, or nil when the
+// bidder is detached. It is safe to call without any lock.
+func (b *Bidder) auction() *Auction {
+	a, _ := b.owner.Load().(*Auction)
+	return a
+}
+
+// setAuction attaches the bidder to the auction, or detaches it when nil.
+// The caller must hold the auction's lock.
+func (b *Bidder) setAuction(a *Auction) {
+	b.owner.Store(a)
+}
@@ -0,0 +1,99 @@
+package dispatchbidder
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBidderAccessors tests that the bidder accessors can be read while the
+// auction bids; run with -race.
+func TestBidderAccessors(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 1000.00, 1.00)
+	bob := createBidder("Bob", 60.00, 1000.00, 1.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for amount := 70.00; amount < 200.00; amount += 10.00 {
+			_ = auction.PlaceBid(alice, amount)
+		}
+	}()
+
+	last := 0.0
+	for i := 0; i < 100; i++ {
+		bid := bob.Bid()
+		assert.GreaterOrEqual(t, bid, last)
+		last = bid
+		_ = bob.BidTime()
+		_ = bob.View()
+	}
+	wg.Wait()
+
+	view := alice.View()
+	assert.Equal(t, alice.Bid(), view.CurrentBid)
+	assert.Equal(t, alice.BidTime(), view.LastBidTime)
+	assert.Equal(t, StatusLeading, view.Status)
+
+	t.Run("Detached bidders", func(t *testing.T) {
+		assert.NoError(t, auction.RemoveBidder(bob.ID))
+		assert.Equal(t, bob.CurrentBid, bob.Bid())
+		assert.Equal(t, StatusActive, bob.View().Status)
+
+		copied := auction.FilterBidders(StatusLeading)[0]
+		assert.Equal(t, alice.Bid(), copied.Bid())
+	})
+}
+
+// TestBidderAccessorsDuringRemoval tests that the accessors can be read while
+// bidders are removed and added; run with -race.
+func TestBidderAccessorsDuringRemoval(t *testing.T) {
+	bidders := make([]*Bidder, 8)
+	for i := range bidders {
+		bidders[i] = createBidder("Bidder", 50.00, 100.00, 1.00)
+	}
+	auction, err := NewAuction(NewAuctionConfig{Bidders: bidders})
+	assert.NoError(t, err)
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			for _, b := range bidders {
+				_ = b.Bid()
+				_ = b.View()
+			}
+		}
+	}()
+
+	for round := 0; round < 100; round++ {
+		for _, b := range bidders[:len(bidders)-2] {
+			assert.NoError(t, auction.RemoveBidder(b.ID))
+		}
+		if round < 99 {
+			for _, b := range bidders[:len(bidders)-2] {
+				assert.NoError(t, auction.AddBidder(b))
+			}
+		}
+	}
+	late := createBidder("Late", 40.00, 100.00, 1.00)
+	assert.NoError(t, auction.AddBidder(late))
+	close(stop)
+	wg.Wait()
+
+	assert.Nil(t, bidders[0].auction())
+	assert.Equal(t, auction, late.auction())
+	assert.Equal(t, 40.00, late.Bid())
+}
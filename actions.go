@@ -0,0 +1,141 @@
+package dispatchbidder
+
+import "fmt"
+
+// Action is an operation on an auction, as reported by AvailableActions.
+type Action int
+
+const (
+	// ActionOpen is Open.
+	ActionOpen Action = iota
+	// ActionPlaceBid is PlaceBid and its variants.
+	ActionPlaceBid
+	// ActionSubmitSealedBid is SubmitSealedBid.
+	ActionSubmitSealedBid
+	// ActionUndo is UndoLastBid.
+	ActionUndo
+	// ActionPause is Pause.
+	ActionPause
+	// ActionResume is Resume.
+	ActionResume
+	// ActionStartCountdown is StartCountdown.
+	ActionStartCountdown
+	// ActionStopCountdown is StopCountdown.
+	ActionStopCountdown
+	// ActionExtendEndTime is ExtendEndTime.
+	ActionExtendEndTime
+	// ActionAddBidder is AddBidder and MergeBidders.
+	ActionAddBidder
+	// ActionRemoveBidder is RemoveBidder.
+	ActionRemoveBidder
+	// ActionResolveProxies is ResolveProxies.
+	ActionResolveProxies
+	// ActionForceSettle is ForceSettle.
+	ActionForceSettle
+	// ActionClose is Close.
+	ActionClose
+	// ActionCancel is Cancel.
+	ActionCancel
+	// ActionAwardNext is AwardNext.
+	ActionAwardNext
+	// ActionGenerateResult is GenerateResult.
+	ActionGenerateResult
+	// ActionReopen is Reopen and ReopenFromCheckpoint.
+	ActionReopen
+)
+
+// String returns the human-readable name of the action.
+func (act Action) String() string {
+	switch act {
+	case ActionOpen:
+		return "Open"
+	case ActionPlaceBid:
+		return "PlaceBid"
+	case ActionSubmitSealedBid:
+		return "SubmitSealedBid"
+	case ActionUndo:
+		return "Undo"
+	case ActionPause:
+		return "Pause"
+	case ActionResume:
+		return "Resume"
+	case ActionStartCountdown:
+		return "StartCountdown"
+	case ActionStopCountdown:
+		return "StopCountdown"
+	case ActionExtendEndTime:
+		return "ExtendEndTime"
+	case ActionAddBidder:
+		return "AddBidder"
+	case ActionRemoveBidder:
+		return "RemoveBidder"
+	case ActionResolveProxies:
+		return "ResolveProxies"
+	case ActionForceSettle:
+		return "ForceSettle"
+	case ActionClose:
+		return "Close"
+	case ActionCancel:
+		return "Cancel"
+	case ActionAwardNext:
+		return "AwardNext"
+	case ActionGenerateResult:
+		return "GenerateResult"
+	case ActionReopen:
+		return "Reopen"
+	default:
+		return fmt.Sprintf("Action(%d)", int(act))
+	}
+}
+
+// AvailableActions returns the operations valid in the auction's current
+// state and configuration, in the order of the Action constants, so UIs need
+// not hardcode the transition rules. Operations that can still fail for a
+// particular bidder or amount, such as PlaceBid, are listed when the auction
+// itself allows them.
+func (a *Auction) AvailableActions() []Action {
+	a.RLock()
+	defer a.RUnlock()
+
+	var actions []Action
+	add := func(ok bool, action Action) {
+		if ok {
+			actions = append(actions, action)
+		}
+	}
+
+	open := a.state == StateOpen
+	sealed := a.Mode.sealed()
+
+	add(a.state == StatePending, ActionOpen)
+	add(open && !a.paused && !sealed, ActionPlaceBid)
+	add(open && !a.paused && sealed, ActionSubmitSealedBid)
+	add(open && !sealed && a.hasManualBid(), ActionUndo)
+	add(open && !a.paused, ActionPause)
+	add(open && a.paused, ActionResume)
+	add(open && a.countdown == nil, ActionStartCountdown)
+	add(a.countdown != nil, ActionStopCountdown)
+	add(open && !a.endTime.IsZero(), ActionExtendEndTime)
+	add(!a.state.ended(), ActionAddBidder)
+	add(!a.state.ended(), ActionRemoveBidder)
+	add(open && !sealed, ActionResolveProxies)
+	add(open && !sealed, ActionForceSettle)
+	add(open, ActionClose)
+	add(a.state != StateCancelled, ActionCancel)
+	add(a.state == StateClosed && a.determineWinner() != nil, ActionAwardNext)
+	add(a.state == StateClosed, ActionGenerateResult)
+	add(a.state == StateClosed, ActionReopen)
+
+	return actions
+}
+
+// hasManualBid reports whether the history holds a manual bid. The caller
+// must hold at least a read lock.
+func (a *Auction) hasManualBid() bool {
+	for _, event := range a.history {
+		if !event.Auto {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package dispatchbidder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAvailableActions tests the action set of each auction state.
+func TestAvailableActions(t *testing.T) {
+	t.Run("Pending", func(t *testing.T) {
+		auction, err := NewAuction(newTestConfig(), WithPending())
+		assert.NoError(t, err)
+
+		assert.Equal(t, []Action{ActionOpen, ActionAddBidder, ActionRemoveBidder, ActionCancel}, auction.AvailableActions())
+	})
+
+	t.Run("Open", func(t *testing.T) {
+		auction, err := NewAuction(newTestConfig())
+		assert.NoError(t, err)
+
+		assert.Equal(t, []Action{
+			ActionPlaceBid, ActionPause, ActionStartCountdown, ActionAddBidder, ActionRemoveBidder,
+			ActionResolveProxies, ActionForceSettle, ActionClose, ActionCancel,
+		}, auction.AvailableActions())
+
+		assert.NoError(t, auction.PlaceBid(auction.BidderList()[0], 70.00))
+		assert.NoError(t, auction.StartCountdown(time.Now().Add(time.Hour)))
+		defer auction.StopCountdown()
+
+		assert.Equal(t, []Action{
+			ActionPlaceBid, ActionUndo, ActionPause, ActionStopCountdown, ActionExtendEndTime, ActionAddBidder,
+			ActionRemoveBidder, ActionResolveProxies, ActionForceSettle, ActionClose, ActionCancel,
+		}, auction.AvailableActions())
+	})
+
+	t.Run("Paused", func(t *testing.T) {
+		auction, err := NewAuction(newTestConfig())
+		assert.NoError(t, err)
+		assert.NoError(t, auction.Pause())
+
+		actions := auction.AvailableActions()
+		assert.Contains(t, actions, ActionResume)
+		assert.NotContains(t, actions, ActionPause)
+		assert.NotContains(t, actions, ActionPlaceBid)
+		assert.Contains(t, actions, ActionClose)
+	})
+
+	t.Run("Sealed", func(t *testing.T) {
+		na := newTestConfig()
+		na.Mode = ModeSealedFirstPrice
+		auction, err := NewAuction(na)
+		assert.NoError(t, err)
+
+		actions := auction.AvailableActions()
+		assert.Contains(t, actions, ActionSubmitSealedBid)
+		assert.NotContains(t, actions, ActionPlaceBid)
+		assert.NotContains(t, actions, ActionResolveProxies)
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		auction, err := NewAuction(newTestConfig())
+		assert.NoError(t, err)
+		_, err = auction.Close()
+		assert.NoError(t, err)
+
+		assert.Equal(t, []Action{ActionCancel, ActionAwardNext, ActionGenerateResult, ActionReopen}, auction.AvailableActions())
+		assert.Equal(t, "GenerateResult", ActionGenerateResult.String())
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		auction, err := NewAuction(newTestConfig())
+		assert.NoError(t, err)
+		assert.NoError(t, auction.Cancel("withdrawn"))
+
+		assert.Empty(t, auction.AvailableActions())
+	})
+}
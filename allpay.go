@@ -0,0 +1,43 @@
+package dispatchbidder
+
+import "github.com/google/uuid"
+
+// Refunds returns the ledger of amounts owed back to the bidders of a
+// cancelled ModeAllPay auction, by bidder ID: each bidder who committed money
+// gets back their CurrentBid as it stood when the auction was cancelled.
+// It returns nil for auctions in other modes and before cancellation. The map
+// is a copy.
+func (a *Auction) Refunds() map[uuid.UUID]float64 {
+	a.RLock()
+	defer a.RUnlock()
+
+	return copyLedger(a.refunds)
+}
+
+// recordRefunds fills the refund ledger of an all-pay auction being
+// cancelled. The caller must hold the lock.
+func (a *Auction) recordRefunds() {
+	if a.Mode != ModeAllPay {
+		return
+	}
+
+	a.refunds = make(map[uuid.UUID]float64, len(a.Bidders))
+	for _, bidder := range a.Bidders {
+		if bidder.CurrentBid > 0 {
+			a.refunds[bidder.ID] = bidder.CurrentBid
+		}
+	}
+}
+
+// copyLedger returns a copy of a ledger of amounts by bidder ID, such as the
+// refunds, or nil when there is none.
+func copyLedger(ledger map[uuid.UUID]float64) map[uuid.UUID]float64 {
+	if ledger == nil {
+		return nil
+	}
+	c := make(map[uuid.UUID]float64, len(ledger))
+	for id, amount := range ledger {
+		c[id] = amount
+	}
+	return c
+}
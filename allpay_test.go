@@ -0,0 +1,67 @@
+package dispatchbidder
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAllPayRefunds tests the refund ledger of cancelled all-pay auctions.
+func TestAllPayRefunds(t *testing.T) {
+	newAuction := func(t *testing.T, mode Mode) (*Auction, *Bidder, *Bidder) {
+		alice := createBidder("Alice", 50.00, 100.00, 5.00)
+		bob := createBidder("Bob", 60.00, 120.00, 5.00)
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, Mode: mode})
+		assert.NoError(t, err)
+		return auction, alice, bob
+	}
+
+	t.Run("Cancel refunds each bidder's current bid", func(t *testing.T) {
+		auction, alice, bob := newAuction(t, ModeAllPay)
+		assert.NoError(t, auction.PlaceBid(alice, 80.00))
+		assert.Nil(t, auction.Refunds(), "nothing is refunded before cancelling")
+
+		assert.NoError(t, auction.Cancel("venue closed"))
+		assert.Equal(t, map[uuid.UUID]float64{alice.ID: alice.CurrentBid, bob.ID: bob.CurrentBid}, auction.Refunds())
+		assert.Equal(t, 80.00, auction.Refunds()[alice.ID])
+		assert.Equal(t, 65.00, auction.Refunds()[bob.ID])
+
+		refunds := auction.Refunds()
+		refunds[alice.ID] = 0
+		assert.Equal(t, 80.00, auction.Refunds()[alice.ID], "the ledger is returned as a copy")
+	})
+
+	t.Run("Ledger survives clone and JSON", func(t *testing.T) {
+		auction, alice, _ := newAuction(t, ModeAllPay)
+		assert.NoError(t, auction.PlaceBid(alice, 80.00))
+		assert.NoError(t, auction.Cancel("venue closed"))
+
+		assert.Equal(t, auction.Refunds(), auction.Clone().Refunds())
+
+		data, err := json.Marshal(auction)
+		assert.NoError(t, err)
+		var decoded Auction
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, auction.Refunds(), decoded.Refunds())
+	})
+
+	t.Run("Other modes refund nothing", func(t *testing.T) {
+		auction, alice, _ := newAuction(t, ModeStandard)
+		assert.NoError(t, auction.PlaceBid(alice, 80.00))
+		assert.NoError(t, auction.Cancel("venue closed"))
+
+		assert.Nil(t, auction.Refunds())
+	})
+
+	t.Run("All-pay settles like standard", func(t *testing.T) {
+		auction, alice, _ := newAuction(t, ModeAllPay)
+		assert.NoError(t, auction.PlaceBid(alice, 80.00))
+		_, err := auction.Close()
+		assert.NoError(t, err)
+
+		assert.Equal(t, alice, auction.DetermineWinner())
+		assert.Equal(t, "AllPay", ModeAllPay.String())
+	})
+}
@@ -0,0 +1,36 @@
+package dispatchbidder
+
+import (
+	"fmt"
+	"time"
+)
+
+// StateAsOf returns the snapshot of the auction as it stood at the given
+// time, for rendering it at a past moment: the bids recorded after that time
+// are undone on a clone, and the snapshot is taken at that time. Bidders
+// priced out by WithdrawAboveMax are Withdrawn only if they were by then;
+// freezes, retractions and disqualifications are not timestamped and show as
+// they are now. An auction closed or voided by then is reported as such;
+// since cancellation is not timestamped, a cancelled auction is reported as
+// open. The Version is that
+// of the live auction, which is not touched. It returns ErrAuctionNotOpen
+// when the auction had not opened by then.
+func (a *Auction) StateAsOf(t time.Time) (AuctionSnapshot, error) {
+	sim := a.Clone()
+
+	if sim.state == StatePending || t.Before(sim.openedAt) {
+		return AuctionSnapshot{}, fmt.Errorf("%w: auction was not open at %s", ErrAuctionNotOpen, t.Format(time.RFC3339))
+	}
+
+	n := len(sim.history)
+	for n > 0 && sim.history[n-1].Time.After(t) {
+		n--
+	}
+	ended := sim.state.ended() && !sim.closedAt.IsZero() && !sim.closedAt.After(t)
+	if n < len(sim.history) || !ended {
+		sim.rewind(n)
+	}
+	sim.clock = &replayClock{at: t}
+
+	return sim.Snapshot(), nil
+}
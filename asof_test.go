@@ -0,0 +1,97 @@
+package dispatchbidder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStateAsOf tests snapshots of the auction at past moments.
+func TestStateAsOf(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 100.00, 0)
+	bob := createBidder("Bob", 60.00, 100.00, 0)
+
+	clock := newManualClock()
+	start := clock.Now()
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, WithClock(clock))
+	assert.NoError(t, err)
+
+	clock.Advance(time.Minute)
+	assert.NoError(t, auction.PlaceBid(alice, 70.00))
+	clock.Advance(time.Minute)
+	assert.NoError(t, auction.PlaceBid(bob, 80.00))
+	clock.Advance(time.Minute)
+	assert.NoError(t, auction.PlaceBid(alice, 90.00))
+	clock.Advance(time.Minute)
+	_, err = auction.Close()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		at     time.Duration
+		leader *Bidder
+		amount float64
+		state  State
+	}{
+		{"Before any bid", 30 * time.Second, bob, 60.00, StateOpen},
+		{"After Alice's first bid", 90 * time.Second, alice, 70.00, StateOpen},
+		{"At Bob's bid", 2 * time.Minute, bob, 80.00, StateOpen},
+		{"After Alice's last bid", 3*time.Minute + time.Second, alice, 90.00, StateOpen},
+		{"After the close", time.Hour, alice, 90.00, StateClosed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot, err := auction.StateAsOf(start.Add(tt.at))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.state, snapshot.State)
+			assert.Equal(t, tt.leader.ID, snapshot.LeaderID)
+			assert.Equal(t, start.Add(tt.at), snapshot.TakenAt)
+			for _, view := range snapshot.Bidders {
+				if view.ID == tt.leader.ID {
+					assert.Equal(t, tt.amount, view.CurrentBid)
+				}
+			}
+		})
+	}
+
+	_, err = auction.StateAsOf(start.Add(-time.Second))
+	assert.ErrorIs(t, err, ErrAuctionNotOpen)
+
+	assert.Equal(t, StateClosed, auction.State(), "the live auction is not touched")
+	assert.Equal(t, 90.00, alice.CurrentBid)
+	assert.Len(t, auction.History(), 3)
+}
+
+// TestStateAsOfWithdrawal tests that a bidder priced out later is reported in
+// the running at earlier moments.
+func TestStateAsOfWithdrawal(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 100.00, 0)
+	bob := createBidder("Bob", 60.00, 80.00, 0)
+	bob.WithdrawAboveMax = true
+
+	clock := newManualClock()
+	start := clock.Now()
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, WithClock(clock))
+	assert.NoError(t, err)
+
+	clock.Advance(time.Minute)
+	assert.NoError(t, auction.PlaceBid(bob, 70.00))
+	clock.Advance(time.Minute)
+	assert.NoError(t, auction.PlaceBid(alice, 90.00))
+	assert.Equal(t, StatusWithdrawn, bob.View().Status)
+
+	status := func(at time.Duration) BidderStatus {
+		snapshot, err := auction.StateAsOf(start.Add(at))
+		assert.NoError(t, err)
+		for _, view := range snapshot.Bidders {
+			if view.ID == bob.ID {
+				return view.Status
+			}
+		}
+		t.Fatalf("no view of %s", bob.Name)
+		return 0
+	}
+	assert.Equal(t, StatusLeading, status(90*time.Second), "Bob led before Alice priced him out")
+	assert.Equal(t, StatusWithdrawn, status(3*time.Minute))
+}
@@ -1,100 +1,706 @@
 package dispatchbidder
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"math/rand"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
+	"golang.org/x/time/rate"
 )
 
 // Bidder represents an individual participant in an auction.
+//
+// Once the bidder joins an auction, the auction updates its fields under its
+// own lock, so reading them directly while others may bid is a data race.
+// Use Bid, BidTime and View, which take the auction's lock, or the
+// auction's snapshot methods instead.
 type Bidder struct {
-	ID            uuid.UUID
-	Name          string
-	StartingBid   float64
-	MaxBid        float64
-	CurrentBid    float64
+	ID          uuid.UUID
+	Name        string
+	StartingBid float64
+	MaxBid      float64
+	CurrentBid  float64
+
+	// AutoIncrement is how much the bidder is raised by when outbid. Zero
+	// makes a fixed bidder who never auto-raises but holds their bid, can
+	// still bid manually and can win if nobody beats them.
 	AutoIncrement float64
-	LastBidTime   time.Time
+
+	// LastBidTime is when the bidder's CurrentBid was placed.
+	LastBidTime time.Time
+
+	// IncrementSequence replaces AutoIncrement with escalating steps: the
+	// bidder's first auto-raise adds the first value, each later one the
+	// next, and the last value repeats once the sequence runs out. Empty
+	// means AutoIncrement applies.
+	IncrementSequence []float64
+
+	// ProxyMax is a soft ceiling for the auto-raises made on the bidder's
+	// behalf, below their hard MaxBid, which manual bids may still reach.
+	// Zero means auto-raises go up to MaxBid.
+	ProxyMax float64
+
+	// DesiredQuantity is how many units the bidder wants in a multi-unit
+	// auction. Zero means one.
+	DesiredQuantity int
+
+	// WithdrawAboveMax makes the bidder drop out of the auction for good once
+	// the highest bid exceeds their MaxBid, rather than merely stop raising:
+	// they are then Withdrawn, get no further auto-increments and cannot win,
+	// even if every other bidder later leaves.
+	WithdrawAboveMax bool
+
+	// StopCondition, when set, is consulted before every raise made on the
+	// bidder's behalf, auto-raises and RunToCompletion rounds alike, with
+	// views of the bidder and the auction; returning true skips the raise,
+	// so the bidder stops responding while their manual bids remain
+	// accepted. It must not call into the auction. StopAfterAutoRaises and
+	// StopAbovePrice are built in. It is not serialized.
+	StopCondition StopCondition
+
+	// ReactionDeadline models a bidder who only responds within a window of
+	// the bid they respond to. The bidder's response lands ReactionTime
+	// after it is made: right as the triggering bid is placed for an
+	// auto-raise, and at the auction clock for a RunToCompletion round. The
+	// response is skipped when it would land more than ReactionDeadline past
+	// the triggering bid, or the leader's bid in a round. Zero means the
+	// bidder always responds.
+	ReactionDeadline time.Duration
+
+	// ReactionTime is the bidder's latency in responding to a bid, checked
+	// against ReactionDeadline. The raise is still stamped when it is made.
+	ReactionTime time.Duration
+
+	// IncrementDecay shrinks AutoIncrement after each of the bidder's accepted
+	// bids by multiplying it by (1 - IncrementDecay). Zero means no decay.
+	IncrementDecay float64
+
+	// Currency is the currency of the bidder's amounts, converted for
+	// comparison when the auction has exchange rates. Empty means the
+	// auction's base currency.
+	Currency string
+
+	// OwnerID identifies the party behind the bidder, so one person bidding
+	// under several paddles can be recognized. Empty means unowned.
+	OwnerID string
+
+	// Metadata carries external references, such as customer IDs, through
+	// exports, clones and snapshots. The auction never reads it; set it
+	// before handing the bidder to the auction and read it back from
+	// snapshots, which hold their own copy.
+	Metadata map[string]string
+
+	seq uint64 // Auction sequence number of the bidder's registration or last bid.
+
+	nextTarget float64 // Amount of the next auto-raise set by SetNextTarget; zero means none.
+	bumps      int     // Number of auto-raises taken, indexing IncrementSequence.
+	manualBid  float64 // Amount of the bidder's last manual bid; zero means none yet.
+	rate       float64 // Exchange rate to the base currency; zero means the base currency.
+
+	frozen    bool // Frozen bidders neither bid nor get bumped, but keep their standing.
+	retracted bool // Retracted bidders are out of the auction for good.
+	withdrawn bool // WithdrawAboveMax bidders priced out of the auction.
+
+	disqualified     bool // Disqualified bidders are excluded from the results but kept for audit.
+	disqualifyReason string
+
+	sealedBid float64   // Hidden bid of a sealed-bid auction until revealed; zero means none.
+	sealedAt  time.Time // When the sealed bid was submitted.
+	abstained bool      // Sealed-bid bidders who submitted no bid by the close.
+
+	owner atomic.Value // *Auction whose lock guards the bidder; nil when detached.
+}
+
+// sameOwner reports whether both bidders are paddles of the same owner.
+func (b *Bidder) sameOwner(other *Bidder) bool {
+	return b.OwnerID != "" && b.OwnerID == other.OwnerID
+}
+
+// isFixed reports whether the bidder never auto-raises.
+func (b *Bidder) isFixed() bool {
+	return b.AutoIncrement == 0 && len(b.IncrementSequence) == 0
+}
+
+// increment returns the amount the bidder's next auto-raise adds: the next
+// step of their IncrementSequence, clamped at its last value, or else their
+// AutoIncrement.
+func (b *Bidder) increment() float64 {
+	if n := len(b.IncrementSequence); n > 0 {
+		return b.IncrementSequence[min(b.bumps, n-1)]
+	}
+	return b.AutoIncrement
+}
+
+// proxyLimit returns the highest amount auto-raises may take the bidder to:
+// their ProxyMax when set, otherwise their MaxBid.
+func (b *Bidder) proxyLimit() float64 {
+	if b.ProxyMax > 0 {
+		return b.ProxyMax
+	}
+	return b.MaxBid
+}
+
+// minDecayedIncrement is the floor a decaying AutoIncrement never goes below.
+const minDecayedIncrement = cent
+
+// Mode selects the rules used to settle an auction.
+type Mode int
+
+const (
+	// ModeStandard settles the auction on the highest current bid.
+	ModeStandard Mode = iota
+	// ModeFirstToTarget closes the auction as soon as a bid reaches the
+	// TargetPrice, awarding it to that bidder.
+	ModeFirstToTarget
+	// ModeSealedFirstPrice takes one hidden bid per bidder through
+	// SubmitSealedBid, revealed on close; the highest bid wins and pays
+	// their own bid.
+	ModeSealedFirstPrice
+	// ModeSealedSecondPrice is a Vickrey auction: sealed bids like
+	// ModeSealedFirstPrice, but the winner pays the second-highest bid.
+	ModeSealedSecondPrice
+	// ModeAllPay settles like ModeStandard, but every bidder pays their
+	// standing bid, won or lost. Cancelling the auction refunds them, see
+	// Refunds.
+	ModeAllPay
+)
+
+// String returns the human-readable name of the mode.
+func (m Mode) String() string {
+	switch m {
+	case ModeStandard:
+		return "Standard"
+	case ModeFirstToTarget:
+		return "FirstToTarget"
+	case ModeSealedFirstPrice:
+		return "SealedFirstPrice"
+	case ModeSealedSecondPrice:
+		return "SealedSecondPrice"
+	case ModeAllPay:
+		return "AllPay"
+	default:
+		return fmt.Sprintf("Mode(%d)", int(m))
+	}
 }
 
 // Auction holds all the details of a single auction event.
 type Auction struct {
 	sync.RWMutex
-	ID      uuid.UUID
+	ID uuid.UUID
+
+	// Bidders holds the auction bidders in registration order. NewAuction
+	// registers its bidders sorted by ID, so the order is stable and
+	// independent of the order the bidders were passed in and the outcome
+	// never depends on how the caller ordered its slice. Bidders added later
+	// register last, and removals preserve the order of the others.
+	//
+	// Deprecated: Reading or modifying Bidders bypasses the auction lock and
+	// races with concurrent bids. Use BidderList, BidderCount and
+	// LookupBidder, and AddBidder and RemoveBidder to change the bidders.
 	Bidders []*Bidder
+
+	// AuctionMaxBid is an absolute ceiling for any bid in the auction,
+	// independent of each bidder's MaxBid. Zero means unlimited.
+	AuctionMaxBid float64
+
+	// Mode selects the settlement rules of the auction.
+	Mode Mode
+
+	// TargetPrice is the price that settles a ModeFirstToTarget auction.
+	TargetPrice float64
+
+	// BidGridStep requires bids to land on StartingBid + k*BidGridStep for
+	// the bidding bidder. Zero disables the grid.
+	BidGridStep float64
+
+	// MinDuration is how long the auction must be open before IsFinal
+	// reports its winner as final.
+	MinDuration time.Duration
+
+	// MinIncrement is the minimum amount a bid must raise the bidder's
+	// current bid by. Zero means any raise is accepted.
+	MinIncrement float64
+
+	// IncrementTable, when set, overrides MinIncrement with an increment
+	// that depends on the current price band.
+	IncrementTable IncrementTable
+
+	// ReservePrice is the lowest price the item is sold at by
+	// ResolveProxies. Zero means no reserve.
+	ReservePrice float64
+
+	// Units is the number of identical units on offer, allocated by
+	// DetermineWinners. Zero means a single unit.
+	Units int
+
+	// MinParticipants is how many distinct bidders must have placed a manual
+	// bid for Close to produce a valid result rather than void the auction.
+	// Zero means no minimum.
+	MinParticipants int
+
+	// MaxRoundRise caps how much the total of the CurrentBids may rise in one
+	// round of NextRound or RunToCompletion: auto-raises that would pass it
+	// are skipped, and once it is reached, the bidders yet to raise wait for
+	// the next round. Zero means unlimited.
+	MaxRoundRise float64
+
+	// MaxTotalBids caps the number of bids, manual and auto alike, that
+	// PlaceBid accepts: once the history holds that many, no further
+	// auto-raises are applied and the auction closes with the then-current
+	// winner. An auction still open past the cap, say after Reopen, rejects
+	// bids with ErrBidCapReached. Zero means unlimited.
+	MaxTotalBids int
+
+	settings
+
+	state    State
+	winner   *Bidder // Set when the auction is settled before the bidding ends.
+	version  uint64  // Incremented on each successful bid.
+	seq      uint64  // Monotonic counter ordering registrations and bids.
+	history  []BidEvent
+	openedAt time.Time
+	closedAt time.Time
+	endTime  time.Time
+	paused   bool
+	pausedAt time.Time
+
+	pausedFor time.Duration // Total time spent in past pauses, for Elapsed.
+
+	countdown   *countdown
+	sinks       []EventSink
+	subscribers []*subscriber
+	watchers    []*winnerWatcher
+	persister   *persister
+	checkpoints map[string]*Auction         // Saved states of Checkpoint, by ID.
+	leader      *Bidder                     // Cached provisional leader, see Leader.
+	limiters    map[uuid.UUID]*rate.Limiter // Per-bidder buckets of WithBidRateLimit.
+	index       map[uuid.UUID]int           // Position of each bidder in Bidders.
+
+	cancelReason string
+	voidReason   string
+	awards       []Award               // Second chance offers made after closing.
+	refunds      map[uuid.UUID]float64 // Amounts owed back to all-pay bidders on cancel.
+	commitments  map[uuid.UUID]float64 // Amounts held from the bidders since the close.
+	rng          *rand.Rand            // Derived from the seed; safe for concurrent use.
+	source       *lockedSource         // Backs rng, counting its draws.
+	bidReceived  bool                  // Set on the first accepted manual bid.
+	roundLimit   float64               // Total of the CurrentBids the round's auto-raises stay within; zero means none.
+
+	onReject   RejectFunc
+	onFirstBid func(BidEvent)
+	firstBid   *BidEvent // First bid waiting for the OnFirstBid hook.
+	onOutbid   OutbidFunc
+	outbid     *outbid // Leadership change waiting for the OnOutbid hook.
 }
 
 // NewAuctionConfig is used to configure a new auction.
 type NewAuctionConfig struct {
 	Bidders []*Bidder
+
+	// AuctionMaxBid caps every bid placed in the auction. Zero means unlimited.
+	AuctionMaxBid float64
+
+	// Mode selects the settlement rules. Defaults to ModeStandard.
+	Mode Mode
+
+	// TargetPrice is required by ModeFirstToTarget and must be above every
+	// bidder's starting bid.
+	TargetPrice float64
+
+	// BidGridStep is the increment grid bids must align to. Zero disables it.
+	BidGridStep float64
+
+	// MinDuration is how long the auction must be open before its winner is
+	// final. Zero makes the winner final as soon as the auction opens.
+	MinDuration time.Duration
+
+	// MinIncrement is the minimum raise of a bid over the bidder's current bid.
+	MinIncrement float64
+
+	// IncrementTable overrides MinIncrement with price-banded increments.
+	IncrementTable IncrementTable
+
+	// ReservePrice is the lowest price proxy resolution sells at.
+	ReservePrice float64
+
+	// Units is the number of identical units on offer. Zero means one.
+	Units int
+
+	// MinParticipants is how many distinct bidders must bid for the auction
+	// to be valid. Zero means no minimum.
+	MinParticipants int
+
+	// MaxRoundRise caps the total rise of the bids in one bidding round.
+	// Zero means unlimited.
+	MaxRoundRise float64
+
+	// MaxTotalBids caps the number of bids, after which the auction closes.
+	// Zero means unlimited.
+	MaxTotalBids int
 }
 
 // NewAuction creates a new auction instance from the given parameters.
-func NewAuction(na NewAuctionConfig) (*Auction, error) {
+func NewAuction(na NewAuctionConfig, opts ...Option) (*Auction, error) {
 	if err := validateAuctionData(na); err != nil {
 		return nil, fmt.Errorf("invalid auction data: %w", err)
 	}
 
+	table := make(IncrementTable, len(na.IncrementTable))
+	copy(table, na.IncrementTable)
+
+	bidders := make([]*Bidder, len(na.Bidders))
+	copy(bidders, na.Bidders)
+	sortBiddersByID(bidders)
+
 	auction := Auction{
-		ID:      uuid.New(),
-		Bidders: na.Bidders,
+		Bidders:         bidders,
+		AuctionMaxBid:   na.AuctionMaxBid,
+		Mode:            na.Mode,
+		TargetPrice:     na.TargetPrice,
+		BidGridStep:     na.BidGridStep,
+		MinDuration:     na.MinDuration,
+		MinIncrement:    na.MinIncrement,
+		IncrementTable:  table,
+		ReservePrice:    na.ReservePrice,
+		Units:           na.Units,
+		MaxRoundRise:    na.MaxRoundRise,
+		MaxTotalBids:    na.MaxTotalBids,
+		MinParticipants: na.MinParticipants,
+		settings:        settings{clock: systemClock{}, seed: defaultSeed(), ids: randomIDs{}},
+	}
+
+	for _, bidder := range auction.Bidders {
+		auction.seq++
+		bidder.seq = auction.seq
+	}
+	auction.reindex(0)
+
+	for _, opt := range opts {
+		opt(&auction)
+	}
+	auction.ID = auction.newID()
+	auction.rng, auction.source = newLockedRand(auction.seed, 0)
+	if err := auction.quoteRates(auction.Bidders); err != nil {
+		return nil, fmt.Errorf("invalid auction data: %w", err)
+	}
+	auction.refreshLeader()
+
+	if auction.state == StateOpen {
+		auction.openedAt = auction.now()
 	}
 
 	return &auction, nil
 }
 
+// sortBiddersByID sorts the bidders by ID, giving them a stable order.
+func sortBiddersByID(bidders []*Bidder) {
+	sort.Slice(bidders, func(i, j int) bool {
+		return bytes.Compare(bidders[i].ID[:], bidders[j].ID[:]) < 0
+	})
+}
+
 // PlaceBid places a bid on the auction.
 func (a *Auction) PlaceBid(bidder *Bidder, bidAmount float64) error {
+	return a.PlaceBidContext(context.Background(), bidder, bidAmount)
+}
+
+// PlaceBidContext is like PlaceBid, passing ctx on to the validators set with
+// WithValidators.
+func (a *Auction) PlaceBidContext(ctx context.Context, bidder *Bidder, bidAmount float64) error {
 	a.Lock()
-	defer a.Unlock()
+	err := a.placeBid(ctx, bidder, bidAmount)
+	onReject := a.onReject
+	fireFirstBid := a.takeFirstBid()
+	fireOutbid := a.takeOutbid()
+	a.Unlock()
+
+	fireReject(onReject, bidder.ID, bidAmount, err)
+	fireFirstBid()
+	fireOutbid()
+	return err
+}
 
+// PlaceBidIfVersion places a bid only if the auction is still at the expected
+// version, implementing compare-and-set semantics for clients that may hold
+// stale state. It returns ErrStaleVersion if the auction advanced since the
+// client read its version.
+func (a *Auction) PlaceBidIfVersion(expectedVersion uint64, bidder *Bidder, bidAmount float64) error {
+	a.Lock()
+	var err error
+	if a.version != expectedVersion {
+		err = fmt.Errorf("%w: expected version %d, current version %d", ErrStaleVersion, expectedVersion, a.version)
+	} else {
+		err = a.placeBid(context.Background(), bidder, bidAmount)
+	}
+	onReject := a.onReject
+	fireFirstBid := a.takeFirstBid()
+	fireOutbid := a.takeOutbid()
+	a.Unlock()
+
+	fireReject(onReject, bidder.ID, bidAmount, err)
+	fireFirstBid()
+	fireOutbid()
+	return err
+}
+
+// PlaceBidByID places a bid like PlaceBid for the bidder of the auction with
+// the given ID, looked up under the lock, so that callers need not hold on
+// to the auction's *Bidder pointers. It returns ErrBidderNotFound when the
+// auction has no bidder with that ID.
+func (a *Auction) PlaceBidByID(id uuid.UUID, bidAmount float64) error {
+	a.Lock()
+	var err error
+	if bidder, ok := a.bidderByID(id); ok {
+		err = a.placeBid(context.Background(), bidder, bidAmount)
+	} else {
+		err = fmt.Errorf("%w: %s", ErrBidderNotFound, id)
+	}
+	onReject := a.onReject
+	fireFirstBid := a.takeFirstBid()
+	fireOutbid := a.takeOutbid()
+	a.Unlock()
+
+	fireReject(onReject, id, bidAmount, err)
+	fireFirstBid()
+	fireOutbid()
+	return err
+}
+
+// Version returns the auction version, incremented on each successful bid.
+func (a *Auction) Version() uint64 {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.version
+}
+
+// placeBid places a bid on the auction. The caller must hold the lock.
+func (a *Auction) placeBid(ctx context.Context, bidder *Bidder, bidAmount float64) error {
 	// -----------------------------------------------------------------------
 	// Perform validations.
 
+	if err := a.checkOpen(); err != nil {
+		return err
+	}
+	if a.Mode.sealed() {
+		return fmt.Errorf("%w: use SubmitSealedBid", ErrSealedAuction)
+	}
+	if a.paused {
+		return ErrAuctionPaused
+	}
+	if a.reachedBidCap() {
+		return fmt.Errorf("%w: %d bids", ErrBidCapReached, a.MaxTotalBids)
+	}
+	if err := a.checkMember(bidder); err != nil {
+		return err
+	}
+	if err := bidder.checkCanBid(); err != nil {
+		return err
+	}
+	if err := checkAmount(bidAmount); err != nil {
+		return err
+	}
+	if err := a.checkPrecision(bidder, bidAmount); err != nil {
+		return err
+	}
 	if bidAmount < bidder.StartingBid {
-		return fmt.Errorf("bid amount $%.2f is less than starting bid $%.2f", bidAmount, bidder.StartingBid)
+		return fmt.Errorf("%w: bid amount $%.2f is less than starting bid $%.2f", ErrBelowStartingBid, bidAmount, bidder.StartingBid)
+	}
+	if !a.onGrid(bidder, bidAmount) {
+		if !a.autoAlign {
+			return fmt.Errorf("%w: bid amount $%.2f is not on the $%.2f grid above starting bid $%.2f", ErrOffGrid, bidAmount, a.BidGridStep, bidder.StartingBid)
+		}
+		bidAmount = a.alignToGrid(bidder, bidAmount)
 	}
 	if bidAmount > bidder.MaxBid {
-		return fmt.Errorf("bid amount $%.2f is greater than max bid $%.2f", bidAmount, bidder.MaxBid)
+		return fmt.Errorf("%w: bid amount $%.2f is greater than max bid $%.2f", ErrAboveMaxBid, bidAmount, bidder.MaxBid)
+	}
+	repeat := a.allowEqualBids && bidAmount == bidder.CurrentBid
+	if bidAmount <= bidder.CurrentBid && !repeat {
+		return fmt.Errorf("%w: bid amount $%.2f is less than or equal to current bid $%.2f", ErrNotAboveCurrentBid, bidAmount, bidder.CurrentBid)
+	}
+	if !a.withinCap(bidAmount) {
+		return fmt.Errorf("%w: bid amount $%.2f is greater than auction cap $%.2f", ErrExceedsAuctionCap, bidAmount, a.AuctionMaxBid)
+	}
+	if step := a.minIncrement(); !repeat && bidAmount < bidder.CurrentBid+step-gridTolerance {
+		return fmt.Errorf("%w: bid amount $%.2f must raise current bid $%.2f by at least $%.2f", ErrIncrementTooSmall, bidAmount, bidder.CurrentBid, step)
+	}
+	rate, err := a.exchangeRate(bidder)
+	if err != nil {
+		return err
+	}
+	if err := a.validate(ctx, bidder, bidAmount); err != nil {
+		return err
 	}
-	if bidAmount <= bidder.CurrentBid {
-		return fmt.Errorf("bid amount $%.2f is less than or equal to current bid $%.2f", bidAmount, bidder.CurrentBid)
+	if err := a.checkRateLimit(bidder); err != nil {
+		return err
 	}
 
 	// -----------------------------------------------------------------------
 	// Updates the bidder current bid.
 
-	bidder.CurrentBid = bidAmount
-	bidder.LastBidTime = time.Now()
+	var prevLeader *Bidder
+	if a.onOutbid != nil {
+		prevLeader = a.determineWinner()
+	}
+	now := a.now()
+	cause := a.applyBid(bidder, bidAmount, rate, now, false, 0)
+	a.recordFirstBid()
+	a.version++
+	a.extendForSnipe(now)
+
+	// -----------------------------------------------------------------------
+	// In a first-to-target auction, reaching the target settles the auction
+	// immediately and no other bidder gets to respond.
+
+	if a.Mode == ModeFirstToTarget && bidAmount >= a.TargetPrice {
+		a.winner = bidder
+		a.close(now)
+		a.recordOutbid(prevLeader)
+		return nil
+	}
 
 	// -----------------------------------------------------------------------
 	// For all other bidders, increment their current bid by their respective
-	// AutoIncrement amount, provided this does not exceed their MaxBid.
-
-	for _, otherBidder := range a.Bidders {
-		if otherBidder.ID != bidder.ID {
-			newBid := otherBidder.CurrentBid + otherBidder.AutoIncrement
-			if newBid <= otherBidder.MaxBid {
-				otherBidder.CurrentBid = newBid
-				otherBidder.LastBidTime = time.Now()
+	// AutoIncrement amount, snapped up to the grid, provided this does not
+	// exceed their ProxyMax (or MaxBid) nor the auction cap. A bidder with a
+	// next bid target jumps straight to it instead. Fixed bidders, with a zero
+	// AutoIncrement and no target, never auto-raise. With WithNoSelfOutbid,
+	// paddles of the same owner never bump each other. With
+	// WithShuffledBumps, the bidders react in a random order rather than in
+	// registration order. In a bidding round, auto-raises that would lift
+	// the total of the CurrentBids past MaxRoundRise are skipped. Once
+	// MaxTotalBids is reached, the auction closes instead.
+
+	a.withdrawOutpriced()
+	for _, otherBidder := range a.bumpOrder() {
+		if a.reachedBidCap() {
+			break
+		}
+		if !otherBidder.canBid() || !otherBidder.autoRaises() || (a.noSelfOutbid && otherBidder.sameOwner(bidder)) || a.stops(otherBidder) {
+			continue
+		}
+		if otherBidder.ID != bidder.ID && !otherBidder.missesReaction(now, now) {
+			newBid := a.alignToGrid(otherBidder, otherBidder.bumpAmount())
+			if newBid <= otherBidder.proxyLimit() && a.withinCap(newBid) && a.withinRound(otherBidder, newBid) {
+				a.applyBid(otherBidder, newBid, otherBidder.rate, now, true, cause)
 			}
 		}
 	}
+	a.withdrawOutpriced()
+	if a.reachedBidCap() {
+		a.finish(now)
+	} else {
+		a.notify()
+	}
+	a.recordOutbid(prevLeader)
+
+	return nil
+}
+
+// reachedBidCap reports whether the history holds MaxTotalBids bids. The
+// caller must hold at least a read lock.
+func (a *Auction) reachedBidCap() bool {
+	return a.MaxTotalBids > 0 && len(a.history) >= a.MaxTotalBids
+}
+
+// decayIncrement applies the bidder's IncrementDecay after an accepted bid,
+// flooring the result at minDecayedIncrement.
+func (b *Bidder) decayIncrement() {
+	if b.IncrementDecay == 0 || b.isFixed() {
+		return
+	}
+	b.AutoIncrement = max(b.AutoIncrement*(1-b.IncrementDecay), minDecayedIncrement)
+}
+
+// ForceSettle immediately settles every bidder who can bid at their MaxBid
+// (limited by the auction cap) and closes the auction, or voids it as Close
+// does when fewer than MinParticipants bidders bid. Bidders are stamped via
+// the clock in registration order, so when MaxBids tie the earliest
+// registered bidder wins deterministically. It returns ErrSealedAuction in
+// sealed-bid modes.
+func (a *Auction) ForceSettle() error {
+	a.Lock()
+	defer a.Unlock()
+
+	if err := a.checkOpen(); err != nil {
+		return err
+	}
+	if a.Mode.sealed() {
+		return fmt.Errorf("%w: sealed bids cannot be force-settled", ErrSealedAuction)
+	}
+
+	for _, bidder := range a.Bidders {
+		if !bidder.canBid() {
+			continue
+		}
+		amount := bidder.MaxBid
+		if !a.withinCap(amount) {
+			amount = a.AuctionMaxBid
+		}
+		a.applyBid(bidder, amount, bidder.rate, a.now(), true, 0)
+	}
+	a.version++
+	a.finish(a.now())
 
 	return nil
 }
 
-// DetermineWinner determines the winner of the auction based on the highest current bid.
-// In case of a tie (multiple bidders with the same highest bid), the bidder who placed
-// their bid first (based on LastBidTime) is considered the winner.
+// withinCap reports whether the amount respects the auction-wide cap.
+func (a *Auction) withinCap(amount float64) bool {
+	return a.AuctionMaxBid == 0 || amount <= a.AuctionMaxBid
+}
+
+// DetermineWinner determines the winner of the auction based on the highest
+// current bid. In case of a tie (multiple bidders with the same highest bid),
+// the bidder who placed their bid first (based on LastBidTime) is considered
+// the winner. Bids with identical LastBidTime values, such as untouched
+// bidders constructed together, tie-break on registration and bid order, as
+// given by BidderView.Sequence: a bidder who has not bid ranks by
+// registration order, which NewAuction makes the order of bidder IDs, so the
+// winner is deterministic on every platform and never depends on slice
+// order. WithTieBreakChain puts other rules ahead of these. A first-to-target
+// auction that reached its target is won by the bidder who reached it. A
+// cancelled auction has no winner, nor has one whose hidden reserve is not
+// met.
 func (a *Auction) DetermineWinner() *Bidder {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.determineWinner()
+}
+
+// determineWinner determines the winner of the auction. The caller must hold
+// at least a read lock.
+func (a *Auction) determineWinner() *Bidder {
+	if top := a.topBidder(); a.meetsReserve(top) {
+		return top
+	}
+	return nil
+}
+
+// topBidder determines the winner of the auction regardless of the hidden
+// reserve. The caller must hold at least a read lock.
+func (a *Auction) topBidder() *Bidder {
+	if a.state.withoutWinner() || a.sealedUnrevealed() {
+		return nil
+	}
+	if a.winner != nil {
+		return a.winner
+	}
+
 	var winner *Bidder
 
+	outranks := a.outranksFunc()
 	for _, bidder := range a.Bidders {
-		if isWinner(winner, bidder) {
+		if bidder.inRunning() && outranks(winner, bidder) {
 			winner = bidder
 		}
 	}
@@ -102,22 +708,172 @@ func (a *Auction) DetermineWinner() *Bidder {
 	return winner
 }
 
+// rankBidders returns the bidders still in the running ordered from winner to
+// last place, using the same rules as DetermineWinner. The caller must hold at
+// least a read lock.
+func (a *Auction) rankBidders() []*Bidder {
+	ranked := make([]*Bidder, 0, len(a.Bidders))
+	for _, bidder := range a.Bidders {
+		if bidder.inRunning() {
+			ranked = append(ranked, bidder)
+		}
+	}
+
+	outranks := a.outranksFunc()
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return outranks(ranked[j], ranked[i])
+	})
+
+	// A winner settled early takes first place regardless of its bid.
+	if a.winner != nil {
+		for i, bidder := range ranked {
+			if bidder == a.winner {
+				copy(ranked[1:i+1], ranked[:i])
+				ranked[0] = a.winner
+				break
+			}
+		}
+	}
+
+	return ranked
+}
+
+// ActiveBidders returns a snapshot of the bidders who still have headroom to
+// raise, i.e. whose CurrentBid is below their MaxBid and who are allowed to
+// bid. The returned bidders are copies and modifying them does not affect the
+// auction.
+func (a *Auction) ActiveBidders() []*Bidder {
+	a.RLock()
+	defer a.RUnlock()
+
+	var active []*Bidder
+	for _, bidder := range a.Bidders {
+		if bidder.hasHeadroom() {
+			active = append(active, bidder.copy())
+		}
+	}
+
+	return active
+}
+
+// HasActiveBidders reports whether any bidder can still raise their bid. It is
+// the termination signal for ascending bidding loops.
+func (a *Auction) HasActiveBidders() bool {
+	a.RLock()
+	defer a.RUnlock()
+
+	for _, bidder := range a.Bidders {
+		if bidder.hasHeadroom() {
+			return true
+		}
+	}
+
+	return false
+}
+
 // isWinner checks if the provided bidder should replace the current winner.
 // A bidder becomes the new winner if:
 // - There is no current winner.
 // - Their bid is higher than the current winner's bid.
-// - Their bid is the same as the current winner's but was placed earlier.
-func isWinner(currentWinner, bidder *Bidder) bool {
+// - Their bid is the same as the current winner's but wins the tie-break.
+func (a *Auction) isWinner(currentWinner, bidder *Bidder) bool {
 	return currentWinner == nil || // No current winner, so the bidder wins by default.
-		bidder.CurrentBid > currentWinner.CurrentBid || // Bidder has a higher bid.
-		(bidder.CurrentBid == currentWinner.CurrentBid && // Bidder has the same bid but placed it earlier.
-			bidder.LastBidTime.Before(currentWinner.LastBidTime))
+		bidder.baseBid() > currentWinner.baseBid() || // Bidder has a higher bid.
+		(bidder.baseBid() == currentWinner.baseBid() && // Bidder has the same bid but wins the tie-break.
+			a.breaksTie(bidder, currentWinner))
+}
+
+// bidEarlier reports whether the bidder's last bid was placed before the
+// other's. When the two LastBidTime values are within the tie epsilon, which
+// happens on coarse clocks, the monotonic sequence number decides instead.
+func (a *Auction) bidEarlier(bidder, other *Bidder) bool {
+	diff := bidder.LastBidTime.Sub(other.LastBidTime)
+	if diff.Abs() <= a.tieEpsilon {
+		return bidder.seq < other.seq
+	}
+	return diff < 0
+}
+
+// ValidateAll checks the data for a new auction like NewAuction does, but
+// instead of failing on the first problem it collects every validation error
+// (each bad bidder, duplicate IDs, bad auction settings) and returns them
+// joined with errors.Join, so they can all be reported at once. It returns
+// nil when the data is valid.
+func ValidateAll(na NewAuctionConfig) error {
+	return errors.Join(auctionDataErrors(na)...)
 }
 
-// validateAuctionData checks that the provided data for a new auction is valid.
+// validateAuctionData checks that the provided data for a new auction is
+// valid, failing on the first error.
 func validateAuctionData(na NewAuctionConfig) error {
+	if errs := auctionDataErrors(na); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// auctionDataErrors returns every validation error of the provided data for a
+// new auction, in the order they are checked.
+func auctionDataErrors(na NewAuctionConfig) []error {
+	var errs []error
+
 	if len(na.Bidders) <= 1 {
-		return errors.New("auction must have at least two bidders")
+		errs = append(errs, errors.New("auction must have at least two bidders"))
+	}
+	return append(errs, configErrors(na)...)
+}
+
+// configErrors returns the validation errors of the provided data for an
+// auction other than the number of bidders, which may drop below two once
+// the auction runs.
+func configErrors(na NewAuctionConfig) []error {
+	var errs []error
+
+	if na.AuctionMaxBid < 0 {
+		errs = append(errs, fmt.Errorf("auction max bid must not be negative, got $%.2f", na.AuctionMaxBid))
+	}
+	if na.MinDuration < 0 {
+		errs = append(errs, fmt.Errorf("min duration must not be negative, got %s", na.MinDuration))
+	}
+	if na.MinIncrement < 0 {
+		errs = append(errs, fmt.Errorf("min increment must not be negative, got $%.2f", na.MinIncrement))
+	}
+	if na.ReservePrice < 0 {
+		errs = append(errs, fmt.Errorf("reserve price must not be negative, got $%.2f", na.ReservePrice))
+	}
+	if na.Units < 0 {
+		errs = append(errs, fmt.Errorf("units must not be negative, got %d", na.Units))
+	}
+	if na.MaxRoundRise < 0 {
+		errs = append(errs, fmt.Errorf("max round rise must not be negative, got $%.2f", na.MaxRoundRise))
+	}
+	if na.MinParticipants < 0 {
+		errs = append(errs, fmt.Errorf("min participants must not be negative, got %d", na.MinParticipants))
+	}
+	if na.MaxTotalBids < 0 {
+		errs = append(errs, fmt.Errorf("max total bids must not be negative, got %d", na.MaxTotalBids))
+	}
+	if err := na.IncrementTable.validate(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid increment table: %w", err))
+	}
+	if na.BidGridStep < 0 {
+		errs = append(errs, fmt.Errorf("bid grid step must not be negative, got $%.2f", na.BidGridStep))
+	}
+	errs = append(errs, amountErrors(
+		namedAmount{"auction max bid", na.AuctionMaxBid},
+		namedAmount{"target price", na.TargetPrice},
+		namedAmount{"bid grid step", na.BidGridStep},
+		namedAmount{"min increment", na.MinIncrement},
+		namedAmount{"reserve price", na.ReservePrice},
+		namedAmount{"max round rise", na.MaxRoundRise},
+	)...)
+	if na.Mode == ModeFirstToTarget {
+		if na.TargetPrice <= 0 {
+			errs = append(errs, fmt.Errorf("target price must be positive, got $%.2f", na.TargetPrice))
+		}
+		if na.AuctionMaxBid > 0 && na.TargetPrice > na.AuctionMaxBid {
+			errs = append(errs, fmt.Errorf("target price $%.2f exceeds auction max bid $%.2f", na.TargetPrice, na.AuctionMaxBid))
+		}
 	}
 
 	seenIDs := make(map[uuid.UUID]bool)
@@ -126,30 +882,84 @@ func validateAuctionData(na NewAuctionConfig) error {
 		// Check for unique IDs to prevent duplicate bidders.
 
 		if _, exists := seenIDs[bidder.ID]; exists {
-			return fmt.Errorf("duplicate bidder ID detected: %s", bidder.ID)
+			errs = append(errs, fmt.Errorf("duplicate bidder ID detected: %s", bidder.ID))
 		}
 		seenIDs[bidder.ID] = true
 
 		// -----------------------------------------------------------------------
 		// Validate individual bidder data.
 
-		if err := validateBidder(bidder); err != nil {
-			return fmt.Errorf("invalid bidder data for bidder ID %s: %w", bidder.ID, err)
+		for _, err := range bidderErrors(bidder) {
+			errs = append(errs, fmt.Errorf("invalid bidder data for bidder ID %s: %w", bidder.ID, err))
+		}
+		if na.AuctionMaxBid > 0 && bidder.StartingBid > na.AuctionMaxBid {
+			errs = append(errs, fmt.Errorf("starting bid $%.2f for bidder ID %s exceeds auction max bid $%.2f", bidder.StartingBid, bidder.ID, na.AuctionMaxBid))
+		}
+		if na.Mode == ModeFirstToTarget && bidder.StartingBid >= na.TargetPrice {
+			errs = append(errs, fmt.Errorf("target price $%.2f must be above starting bid $%.2f for bidder ID %s", na.TargetPrice, bidder.StartingBid, bidder.ID))
 		}
 	}
-	return nil
+
+	return errs
 }
 
-// validateBidder checks that a bidder's data is valid.
+// validateBidder checks that a bidder's data is valid, failing on the first
+// error.
 func validateBidder(b *Bidder) error {
+	if errs := bidderErrors(b); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// bidderErrors returns every validation error of a bidder's data.
+func bidderErrors(b *Bidder) []error {
+	var errs []error
+
 	if b.StartingBid <= 0 {
-		return fmt.Errorf("starting bid must be positive, got $%.2f", b.StartingBid)
+		errs = append(errs, fmt.Errorf("starting bid must be positive, got $%.2f", b.StartingBid))
 	}
 	if b.MaxBid < b.StartingBid {
-		return fmt.Errorf("max bid $%.2f must be greater than or equal to starting bid $%.2f", b.MaxBid, b.StartingBid)
+		errs = append(errs, fmt.Errorf("max bid $%.2f must be greater than or equal to starting bid $%.2f", b.MaxBid, b.StartingBid))
 	}
-	if b.AutoIncrement <= 0 {
-		return fmt.Errorf("auto-increment must be positive, got $%.2f", b.AutoIncrement)
+	if b.ProxyMax < 0 {
+		errs = append(errs, fmt.Errorf("proxy max must not be negative, got $%.2f", b.ProxyMax))
 	}
-	return nil
+	if b.ProxyMax > b.MaxBid {
+		errs = append(errs, fmt.Errorf("proxy max $%.2f must be less than or equal to max bid $%.2f", b.ProxyMax, b.MaxBid))
+	}
+	if b.AutoIncrement < 0 {
+		errs = append(errs, fmt.Errorf("auto-increment must not be negative, got $%.2f", b.AutoIncrement))
+	}
+	if b.DesiredQuantity < 0 {
+		errs = append(errs, fmt.Errorf("desired quantity must not be negative, got %d", b.DesiredQuantity))
+	}
+	for i, step := range b.IncrementSequence {
+		if step <= 0 {
+			errs = append(errs, fmt.Errorf("increment sequence step %d must be positive, got $%.2f", i, step))
+		}
+	}
+	if b.ReactionDeadline < 0 {
+		errs = append(errs, fmt.Errorf("reaction deadline must not be negative, got %s", b.ReactionDeadline))
+	}
+	if b.ReactionTime < 0 {
+		errs = append(errs, fmt.Errorf("reaction time must not be negative, got %s", b.ReactionTime))
+	}
+	if b.IncrementDecay < 0 || b.IncrementDecay >= 1 {
+		errs = append(errs, fmt.Errorf("increment decay must be in [0, 1), got %.2f", b.IncrementDecay))
+	}
+	errs = append(errs, amountErrors(
+		namedAmount{"starting bid", b.StartingBid},
+		namedAmount{"max bid", b.MaxBid},
+		namedAmount{"current bid", b.CurrentBid},
+		namedAmount{"proxy max", b.ProxyMax},
+		namedAmount{"auto-increment", b.AutoIncrement},
+	)...)
+	for i, step := range b.IncrementSequence {
+		if err := checkAmount(step); err != nil {
+			errs = append(errs, fmt.Errorf("increment sequence step %d: %w", i, err))
+		}
+	}
+
+	return errs
 }
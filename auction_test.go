@@ -1,6 +1,8 @@
 package dispatchbidder
 
 import (
+	"bytes"
+	"math/rand"
 	"testing"
 	"time"
 
@@ -84,3 +86,585 @@ func TestAuctionScenarios(t *testing.T) {
 		})
 	}
 }
+
+// TestAuctionMaxBid tests that the auction-wide cap is enforced on both manual
+// bids and auto-increments.
+func TestAuctionMaxBid(t *testing.T) {
+	t.Run("Bid within MaxBid but above cap is rejected", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 200.00, 5.00)
+		bob := createBidder("Bob", 60.00, 200.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, AuctionMaxBid: 100.00})
+		assert.NoError(t, err)
+
+		err = auction.PlaceBid(alice, 150.00)
+		assert.ErrorIs(t, err, ErrExceedsAuctionCap)
+		assert.Equal(t, 50.00, alice.CurrentBid)
+
+		assert.NoError(t, auction.PlaceBid(alice, 100.00))
+		assert.Equal(t, 100.00, alice.CurrentBid)
+	})
+
+	t.Run("Auto-increment does not exceed cap", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 200.00, 5.00)
+		bob := createBidder("Bob", 98.00, 200.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, AuctionMaxBid: 100.00})
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.PlaceBid(alice, 60.00))
+		assert.Equal(t, 98.00, bob.CurrentBid, "bump to $103.00 would exceed the cap")
+	})
+
+	t.Run("Invalid cap is rejected", func(t *testing.T) {
+		_, err := NewAuction(NewAuctionConfig{
+			Bidders: []*Bidder{
+				createBidder("Alice", 50.00, 200.00, 5.00),
+				createBidder("Bob", 60.00, 200.00, 5.00),
+			},
+			AuctionMaxBid: 55.00,
+		})
+		assert.Error(t, err)
+	})
+}
+
+// TestBidderOrdering tests that the outcome does not depend on the order of
+// the caller's bidder slice.
+func TestBidderOrdering(t *testing.T) {
+	clock := newManualClock()
+	bidders := []*Bidder{
+		createBidder("Alice", 50.00, 90.00, 5.00),
+		createBidder("Bob", 60.00, 90.00, 5.00),
+		createBidder("Carol", 55.00, 90.00, 5.00),
+		createBidder("Dave", 65.00, 90.00, 5.00),
+	}
+
+	var expected uuid.UUID
+	for i := 0; i < 20; i++ {
+		shuffled := make([]*Bidder, len(bidders))
+		for j, p := range rand.Perm(len(bidders)) {
+			b := *bidders[p]
+			b.LastBidTime = clock.Now() // Identical timestamps force a tie-break.
+			shuffled[j] = &b
+		}
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: shuffled}, WithClock(clock))
+		assert.NoError(t, err)
+		assert.NoError(t, auction.ForceSettle())
+
+		winner := auction.DetermineWinner()
+		if i == 0 {
+			expected = winner.ID
+		}
+		assert.Equal(t, expected, winner.ID, "winner must not depend on slice order")
+	}
+}
+
+// TestFirstToTarget tests that the first bidder to reach the target price wins
+// immediately, regardless of the other bidders' headroom.
+func TestFirstToTarget(t *testing.T) {
+	t.Run("First bidder to hit target wins", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 120.00, 5.00)
+		bob := createBidder("Bob", 60.00, 500.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{
+			Bidders:     []*Bidder{alice, bob},
+			Mode:        ModeFirstToTarget,
+			TargetPrice: 100.00,
+		})
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.PlaceBid(alice, 100.00))
+		assert.Equal(t, StateClosed, auction.State())
+		assert.Equal(t, 60.00, bob.CurrentBid, "no bumps after the target is reached")
+
+		err = auction.PlaceBid(bob, 200.00)
+		assert.ErrorIs(t, err, ErrAuctionClosed)
+
+		winner := auction.DetermineWinner()
+		assert.Equal(t, "Alice", winner.Name)
+	})
+
+	t.Run("Bids below target keep the auction open", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 120.00, 5.00)
+		bob := createBidder("Bob", 60.00, 500.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{
+			Bidders:     []*Bidder{alice, bob},
+			Mode:        ModeFirstToTarget,
+			TargetPrice: 100.00,
+		})
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.PlaceBid(alice, 80.00))
+		assert.Equal(t, StateOpen, auction.State())
+		assert.Equal(t, 65.00, bob.CurrentBid)
+	})
+
+	t.Run("Target must be above starting bids", func(t *testing.T) {
+		_, err := NewAuction(NewAuctionConfig{
+			Bidders: []*Bidder{
+				createBidder("Alice", 50.00, 120.00, 5.00),
+				createBidder("Bob", 100.00, 500.00, 5.00),
+			},
+			Mode:        ModeFirstToTarget,
+			TargetPrice: 100.00,
+		})
+		assert.Error(t, err)
+	})
+}
+
+// TestActiveBidders tests that only bidders with remaining headroom are reported
+// as active.
+func TestActiveBidders(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 100.00, 5.00)
+	bob := createBidder("Bob", 60.00, 60.00, 5.00)
+	carol := createBidder("Carol", 70.00, 90.00, 5.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob, carol}})
+	assert.NoError(t, err)
+
+	ids := func(bidders []*Bidder) []uuid.UUID {
+		var ids []uuid.UUID
+		for _, b := range bidders {
+			ids = append(ids, b.ID)
+		}
+		return ids
+	}
+
+	active := auction.ActiveBidders()
+	assert.ElementsMatch(t, []uuid.UUID{alice.ID, carol.ID}, ids(active))
+	assert.True(t, auction.HasActiveBidders())
+
+	// The snapshot is a copy.
+	for _, b := range active {
+		b.CurrentBid = 99.00
+	}
+	assert.Equal(t, 50.00, alice.CurrentBid)
+
+	assert.NoError(t, auction.PlaceBid(carol, 90.00))
+	assert.Equal(t, []uuid.UUID{alice.ID}, ids(auction.ActiveBidders()))
+
+	assert.NoError(t, auction.PlaceBid(alice, 100.00))
+	assert.Empty(t, auction.ActiveBidders())
+	assert.False(t, auction.HasActiveBidders())
+}
+
+// TestPlaceBidIfVersion tests the compare-and-set bid semantics.
+func TestPlaceBidIfVersion(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 100.00, 5.00)
+	bob := createBidder("Bob", 60.00, 100.00, 5.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), auction.Version())
+
+	// Both clients read the same version.
+	version := auction.Version()
+
+	assert.NoError(t, auction.PlaceBidIfVersion(version, alice, 70.00))
+	assert.Equal(t, uint64(1), auction.Version())
+
+	err = auction.PlaceBidIfVersion(version, bob, 80.00)
+	assert.ErrorIs(t, err, ErrStaleVersion)
+	assert.Equal(t, 65.00, bob.CurrentBid)
+
+	assert.NoError(t, auction.PlaceBidIfVersion(auction.Version(), bob, 80.00))
+	assert.Equal(t, uint64(2), auction.Version())
+
+	// Rejected bids do not advance the version.
+	assert.Error(t, auction.PlaceBid(bob, 10.00))
+	assert.Equal(t, uint64(2), auction.Version())
+}
+
+// TestIncrementDecay tests that a decaying bidder's jumps get smaller over rounds.
+func TestIncrementDecay(t *testing.T) {
+	t.Run("Jumps shrink after each accepted bid", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 1000.00, 5.00)
+		bob := createBidder("Bob", 60.00, 1000.00, 10.00)
+		bob.IncrementDecay = 0.5
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+
+		var jumps []float64
+		for round := 0; round < 4; round++ {
+			before := bob.CurrentBid
+			assert.NoError(t, auction.PlaceBid(alice, alice.CurrentBid+alice.AutoIncrement))
+			jumps = append(jumps, bob.CurrentBid-before)
+		}
+
+		assert.InDeltaSlice(t, []float64{10.00, 5.00, 2.50, 1.25}, jumps, 0.0001)
+	})
+
+	t.Run("Increment is floored at one cent", func(t *testing.T) {
+		bidder := createBidder("Alice", 50.00, 1000.00, 0.02)
+		bidder.IncrementDecay = 0.9
+
+		bidder.decayIncrement()
+		assert.Equal(t, minDecayedIncrement, bidder.AutoIncrement)
+	})
+
+	t.Run("Decay outside [0, 1) is rejected", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 100.00, 5.00)
+		alice.IncrementDecay = 1
+		_, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, createBidder("Bob", 60.00, 100.00, 5.00)}})
+		assert.Error(t, err)
+	})
+}
+
+// TestNoSelfOutbid tests that paddles sharing an owner don't bump each other.
+func TestNoSelfOutbid(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []Option
+		expectedBid float64
+	}{
+		{name: "Without option paddles bump each other", expectedBid: 65.00},
+		{name: "With option paddles are skipped", opts: []Option{WithNoSelfOutbid()}, expectedBid: 60.00},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paddle1 := createBidder("Paddle 1", 50.00, 100.00, 5.00)
+			paddle2 := createBidder("Paddle 2", 60.00, 100.00, 5.00)
+			rival := createBidder("Rival", 55.00, 100.00, 5.00)
+			paddle1.OwnerID = "owner-1"
+			paddle2.OwnerID = "owner-1"
+
+			auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{paddle1, paddle2, rival}}, tt.opts...)
+			assert.NoError(t, err)
+
+			assert.NoError(t, auction.PlaceBid(paddle1, 70.00))
+			assert.Equal(t, tt.expectedBid, paddle2.CurrentBid)
+			assert.Equal(t, 60.00, rival.CurrentBid, "other owners are still bumped")
+		})
+	}
+}
+
+// TestForceSettle tests settling every bidder at their MaxBid.
+func TestForceSettle(t *testing.T) {
+	t.Run("Highest MaxBid wins", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 80.00, 3.00)
+		bob := createBidder("Bob", 60.00, 95.00, 2.00)
+		carol := createBidder("Carol", 55.00, 85.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob, carol}}, WithClock(newManualClock()))
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.ForceSettle())
+		assert.Equal(t, StateClosed, auction.State())
+		assert.Equal(t, 80.00, alice.CurrentBid)
+		assert.Equal(t, 95.00, bob.CurrentBid)
+		assert.Equal(t, 85.00, carol.CurrentBid)
+		assert.Equal(t, "Bob", auction.DetermineWinner().Name)
+
+		assert.ErrorIs(t, auction.ForceSettle(), ErrAuctionClosed)
+	})
+
+	t.Run("Tied MaxBids are won in registration order", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			alice := createBidder("Alice", 50.00, 90.00, 3.00)
+			bob := createBidder("Bob", 60.00, 90.00, 2.00)
+
+			auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, WithClock(newManualClock()))
+			assert.NoError(t, err)
+
+			assert.NoError(t, auction.ForceSettle())
+			assert.Equal(t, auction.Bidders[0], auction.DetermineWinner())
+		}
+	})
+
+	t.Run("Settlement respects the auction cap", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 80.00, 3.00)
+		bob := createBidder("Bob", 60.00, 95.00, 2.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, AuctionMaxBid: 85.00})
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.ForceSettle())
+		assert.Equal(t, 85.00, bob.CurrentBid)
+	})
+
+	t.Run("Bidders who cannot bid are left alone", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 80.00, 3.00)
+		bob := createBidder("Bob", 60.00, 95.00, 2.00)
+		carol := createBidder("Carol", 55.00, 85.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob, carol}})
+		assert.NoError(t, err)
+		assert.NoError(t, auction.FreezeBidder(bob.ID))
+		assert.NoError(t, auction.Disqualify(carol.ID, "shill bidding"))
+
+		assert.NoError(t, auction.ForceSettle())
+		assert.Equal(t, 60.00, bob.CurrentBid)
+		assert.Equal(t, 55.00, carol.CurrentBid)
+		assert.Equal(t, alice, auction.DetermineWinner())
+	})
+
+	t.Run("Voided without MinParticipants", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 80.00, 3.00)
+		bob := createBidder("Bob", 60.00, 95.00, 2.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, MinParticipants: 2})
+		assert.NoError(t, err)
+		assert.NoError(t, auction.PlaceBid(alice, 70.00))
+
+		assert.NoError(t, auction.ForceSettle())
+		assert.Equal(t, StateVoided, auction.State())
+		assert.Nil(t, auction.DetermineWinner())
+	})
+}
+
+// TestValidateAll tests that every validation error is reported at once.
+func TestValidateAll(t *testing.T) {
+	dup := createBidder("Dup", 50.00, 100.00, 5.00)
+	badMax := createBidder("BadMax", 50.00, 40.00, 5.00)
+	badBoth := createBidder("BadBoth", -1.00, 100.00, -1.00)
+
+	na := NewAuctionConfig{
+		Bidders:       []*Bidder{dup, dup, badMax, badBoth},
+		AuctionMaxBid: -5.00,
+	}
+
+	err := ValidateAll(na)
+	assert.Error(t, err)
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if assert.True(t, ok, "errors are joined") {
+		assert.Len(t, joined.Unwrap(), 5)
+	}
+	assert.ErrorContains(t, err, "auction max bid must not be negative")
+	assert.ErrorContains(t, err, "duplicate bidder ID detected: "+dup.ID.String())
+	assert.ErrorContains(t, err, "max bid $40.00 must be greater than or equal to starting bid $50.00")
+	assert.ErrorContains(t, err, "starting bid must be positive")
+	assert.ErrorContains(t, err, "auto-increment must not be negative")
+
+	// NewAuction still fails fast on the first error only.
+	_, err = NewAuction(na)
+	assert.ErrorContains(t, err, "auction max bid must not be negative")
+	assert.NotContains(t, err.Error(), "duplicate bidder ID")
+
+	assert.NoError(t, ValidateAll(newTestConfig()))
+}
+
+// TestFixedBidders tests bidders with a zero AutoIncrement.
+func TestFixedBidders(t *testing.T) {
+	t.Run("Fixed bidder is skipped by bumps", func(t *testing.T) {
+		fixed := createBidder("Fixed", 70.00, 100.00, 0)
+		alice := createBidder("Alice", 50.00, 80.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{fixed, alice}})
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.PlaceBid(alice, 75.00))
+		assert.Equal(t, 70.00, fixed.CurrentBid, "fixed bidders never auto-raise")
+		assert.Equal(t, alice, auction.DetermineWinner())
+
+		assert.NoError(t, auction.PlaceBid(fixed, 90.00), "fixed bidders can still bid manually")
+		assert.Equal(t, 80.00, alice.CurrentBid)
+		assert.Equal(t, fixed, auction.DetermineWinner())
+	})
+
+	t.Run("Fixed bidder wins when nobody beats them", func(t *testing.T) {
+		fixed := createBidder("Fixed", 70.00, 70.00, 0)
+		alice := createBidder("Alice", 50.00, 65.00, 5.00)
+		bob := createBidder("Bob", 40.00, 60.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{fixed, alice, bob}})
+		assert.NoError(t, err)
+
+		winner, err := auction.RunToCompletion()
+		assert.NoError(t, err)
+		assert.Equal(t, fixed, winner)
+		assert.Equal(t, 70.00, fixed.CurrentBid)
+	})
+}
+
+// TestAllowEqualBids tests repeating the current bid under WithAllowEqualBids.
+func TestAllowEqualBids(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           []Option
+		expectedErr    error
+		expectedWinner string
+	}{
+		{name: "Strict by default", expectedErr: ErrNotAboveCurrentBid, expectedWinner: "Bob"},
+		{name: "Equal bid re-stamps and loses the tie", opts: []Option{WithAllowEqualBids()}, expectedWinner: "Alice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Large increments keep auto-bumps out of the way.
+			alice := createBidder("Alice", 50.00, 100.00, 500.00)
+			bob := createBidder("Bob", 50.00, 100.00, 500.00)
+
+			clock := newManualClock()
+			auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, MinIncrement: 1.00}, append(tt.opts, WithClock(clock))...)
+			assert.NoError(t, err)
+
+			assert.NoError(t, auction.PlaceBid(bob, 80.00))
+			clock.Advance(time.Second)
+			assert.NoError(t, auction.PlaceBid(alice, 80.00))
+			assert.Equal(t, "Bob", auction.DetermineWinner().Name, "Bob bid first")
+
+			clock.Advance(time.Second)
+			err = auction.PlaceBid(bob, 80.00)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, clock.Now(), bob.LastBidTime)
+			}
+			assert.Equal(t, tt.expectedWinner, auction.DetermineWinner().Name)
+
+			assert.ErrorIs(t, auction.PlaceBid(bob, 79.00), ErrNotAboveCurrentBid)
+		})
+	}
+}
+
+// TestProxyMax tests capping auto-raises below a bidder's hard MaxBid.
+func TestProxyMax(t *testing.T) {
+	t.Run("Auto-raises stop at ProxyMax, manual bids reach MaxBid", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 100.00, 5.00)
+		alice.ProxyMax = 70.00
+		bob := createBidder("Bob", 50.00, 200.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+
+		for _, amount := range []float64{60.00, 68.00, 75.00, 80.00} {
+			assert.NoError(t, auction.PlaceBid(bob, amount))
+		}
+		assert.Equal(t, 70.00, alice.CurrentBid)
+
+		assert.NoError(t, auction.PlaceBid(bob, 85.00))
+		assert.Equal(t, 70.00, alice.CurrentBid, "the proxy does not raise past ProxyMax")
+
+		assert.NoError(t, auction.PlaceBid(alice, 100.00), "manual bids may reach MaxBid")
+		assert.Equal(t, alice, auction.DetermineWinner())
+	})
+
+	t.Run("ResolveProxies uses ProxyMax as the ceiling", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 150.00, 5.00)
+		alice.ProxyMax = 90.00
+		bob := createBidder("Bob", 50.00, 120.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+
+		leader, err := auction.ResolveProxies()
+		assert.NoError(t, err)
+		assert.Equal(t, bob, leader)
+		assert.Equal(t, 90.00, alice.CurrentBid)
+		assert.Equal(t, 95.00, bob.CurrentBid)
+	})
+
+	t.Run("ProxyMax must not exceed MaxBid", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 100.00, 5.00)
+		alice.ProxyMax = 120.00
+
+		err := ValidateAll(NewAuctionConfig{Bidders: []*Bidder{alice, createBidder("Bob", 50.00, 100.00, 5.00)}})
+		assert.ErrorContains(t, err, "proxy max $120.00 must be less than or equal to max bid $100.00")
+	})
+}
+
+// TestIdenticalTimestampTieBreak tests that untouched bidders with identical
+// LastBidTime values tie-break on registration order.
+func TestIdenticalTimestampTieBreak(t *testing.T) {
+	now := time.Now()
+	var bidders []*Bidder
+	for _, name := range []string{"Alice", "Bob", "Carol", "Dave"} {
+		b := createBidder(name, 50.00, 90.00, 5.00)
+		b.LastBidTime = now
+		bidders = append(bidders, b)
+	}
+
+	for i := 0; i < 10; i++ {
+		shuffled := make([]*Bidder, len(bidders))
+		for j, p := range rand.Perm(len(bidders)) {
+			b := *bidders[p]
+			shuffled[j] = &b
+		}
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: shuffled})
+		assert.NoError(t, err)
+
+		first := auction.BidderList()[0]
+		assert.Equal(t, first, auction.DetermineWinner(), "the first registered bidder wins")
+		for _, b := range shuffled {
+			assert.LessOrEqual(t, bytes.Compare(first.ID[:], b.ID[:]), 0, "NewAuction registers by ID")
+		}
+
+		snapshot := auction.Snapshot()
+		view, _ := snapshot.Leader()
+		assert.Equal(t, uint64(1), view.Sequence)
+
+		late := createBidder("Late", 50.00, 90.00, 5.00)
+		late.LastBidTime = now
+		assert.NoError(t, auction.AddBidder(late))
+		assert.Equal(t, first, auction.DetermineWinner(), "later registrations rank last")
+	}
+}
+
+// TestForeignBidder tests that bids from bidders who are not the auction's
+// own are rejected, and that PlaceBidByID looks the bidder up.
+func TestForeignBidder(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 100.00, 0)
+	bob := createBidder("Bob", 60.00, 100.00, 0)
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+	assert.NoError(t, err)
+
+	other, err := NewAuction(newTestConfig())
+	assert.NoError(t, err)
+	foreign := other.BidderList()[0]
+	assert.ErrorIs(t, auction.PlaceBid(foreign, 70.00), ErrBidderNotInAuction)
+
+	impostor := alice.copy()
+	assert.ErrorIs(t, auction.PlaceBid(impostor, 70.00), ErrBidderNotInAuction, "a copy with the same ID is not the auction's bidder")
+	assert.Equal(t, 50.00, alice.CurrentBid)
+	assert.Equal(t, uint64(0), auction.Version())
+
+	assert.NoError(t, auction.PlaceBidByID(alice.ID, 70.00))
+	assert.Equal(t, 70.00, alice.CurrentBid)
+	assert.Equal(t, alice, auction.DetermineWinner())
+
+	var rejected uuid.UUID
+	auction.OnReject(func(id uuid.UUID, _ float64, _ error) { rejected = id })
+	missing := uuid.New()
+	assert.ErrorIs(t, auction.PlaceBidByID(missing, 80.00), ErrBidderNotFound)
+	assert.Equal(t, missing, rejected)
+}
+
+// TestMaxTotalBids tests that the auction closes with the then-current winner
+// once it has taken MaxTotalBids bids, auto-raises included.
+func TestMaxTotalBids(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 100.00, 5.00)
+	bob := createBidder("Bob", 60.00, 100.00, 5.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, MaxTotalBids: 3})
+	assert.NoError(t, err)
+
+	assert.NoError(t, auction.PlaceBid(alice, 70.00))
+	assert.Equal(t, 65.00, bob.CurrentBid, "the auto-raise counts as the second bid")
+	assert.Equal(t, StateOpen, auction.State())
+
+	assert.NoError(t, auction.PlaceBid(alice, 80.00))
+	assert.Equal(t, StateClosed, auction.State())
+	assert.Equal(t, 65.00, bob.CurrentBid, "no auto-raise past the cap")
+	assert.Len(t, auction.History(), 3)
+
+	result, err := auction.GenerateResult()
+	assert.NoError(t, err)
+	assert.Equal(t, alice, result.Winner)
+	assert.Equal(t, 80.00, result.WinningAmount)
+	assert.ErrorIs(t, auction.PlaceBid(bob, 90.00), ErrAuctionClosed)
+
+	assert.NoError(t, auction.Reopen())
+	assert.ErrorIs(t, auction.PlaceBid(bob, 90.00), ErrBidCapReached)
+	assert.Len(t, auction.History(), 3, "rejected before it is recorded")
+	assert.Equal(t, 65.00, bob.CurrentBid)
+
+	_, err = NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, MaxTotalBids: -1})
+	assert.Error(t, err)
+}
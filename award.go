@@ -0,0 +1,97 @@
+package dispatchbidder
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Award is one link of the award chain of a closed auction: the bidder the
+// item was awarded to, at their own CurrentBid, and whether they defaulted.
+type Award struct {
+	BidderID  uuid.UUID
+	Amount    float64
+	Defaulted bool
+}
+
+// DetermineWinnerExcluding determines the winner using the same rules as
+// DetermineWinner while ignoring the given bidders.
+func (a *Auction) DetermineWinnerExcluding(ids ...uuid.UUID) *Bidder {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.determineWinnerExcluding(ids)
+}
+
+// determineWinnerExcluding determines the winner while ignoring the given
+// bidders. The caller must hold at least a read lock.
+func (a *Auction) determineWinnerExcluding(ids []uuid.UUID) *Bidder {
+	if a.state.withoutWinner() {
+		return nil
+	}
+
+	excluded := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		excluded[id] = true
+	}
+
+	for _, bidder := range a.rankBidders() {
+		if !excluded[bidder.ID] {
+			return bidder
+		}
+	}
+
+	return nil
+}
+
+// AwardNext handles a winner who cannot pay with a second chance offer: it
+// records the current awardee as defaulting and awards the item to the best
+// remaining bidder at their own CurrentBid, returning them. The first call
+// starts the chain with the winner of the closed auction. It returns
+// ErrAuctionNotClosed unless the auction is closed, and ErrNoEligibleWinner
+// once every bidder in the running has defaulted. DetermineWinner keeps
+// reporting the original winner; use AwardChain for the current awardee.
+func (a *Auction) AwardNext() (*Bidder, error) {
+	a.Lock()
+	defer a.Unlock()
+
+	if a.state != StateClosed {
+		return nil, fmt.Errorf("%w: auction is %s", ErrAuctionNotClosed, a.state)
+	}
+
+	if len(a.awards) == 0 {
+		winner := a.determineWinner()
+		if winner == nil {
+			return nil, fmt.Errorf("%w: the auction has no winner", ErrNoEligibleWinner)
+		}
+		a.awards = append(a.awards, Award{BidderID: winner.ID, Amount: winner.CurrentBid})
+	}
+
+	current := &a.awards[len(a.awards)-1]
+	if current.Defaulted {
+		return nil, fmt.Errorf("%w: every bidder has defaulted", ErrNoEligibleWinner)
+	}
+	current.Defaulted = true
+
+	defaulted := make([]uuid.UUID, len(a.awards))
+	for i, award := range a.awards {
+		defaulted[i] = award.BidderID
+	}
+
+	next := a.determineWinnerExcluding(defaulted)
+	if next == nil {
+		return nil, fmt.Errorf("%w: every bidder has defaulted", ErrNoEligibleWinner)
+	}
+	a.awards = append(a.awards, Award{BidderID: next.ID, Amount: next.CurrentBid})
+
+	return next, nil
+}
+
+// AwardChain returns a copy of the award chain, from the original winner to
+// the current awardee. It is empty until AwardNext is first called.
+func (a *Auction) AwardChain() []Award {
+	a.RLock()
+	defer a.RUnlock()
+
+	return append([]Award(nil), a.awards...)
+}
@@ -0,0 +1,47 @@
+package dispatchbidder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAwardNext tests second chance offers cascading down the ranking.
+func TestAwardNext(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 80.00, 3.00)
+	bob := createBidder("Bob", 60.00, 100.00, 2.00)
+	carol := createBidder("Carol", 55.00, 120.00, 5.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob, carol}})
+	assert.NoError(t, err)
+
+	_, err = auction.AwardNext()
+	assert.ErrorIs(t, err, ErrAuctionNotClosed)
+
+	assert.NoError(t, auction.ForceSettle())
+	assert.Equal(t, carol, auction.DetermineWinner())
+	assert.Empty(t, auction.AwardChain())
+
+	next, err := auction.AwardNext()
+	assert.NoError(t, err)
+	assert.Equal(t, bob, next)
+
+	next, err = auction.AwardNext()
+	assert.NoError(t, err)
+	assert.Equal(t, alice, next)
+
+	assert.Equal(t, []Award{
+		{BidderID: carol.ID, Amount: 120.00, Defaulted: true},
+		{BidderID: bob.ID, Amount: 100.00, Defaulted: true},
+		{BidderID: alice.ID, Amount: 80.00},
+	}, auction.AwardChain())
+	assert.Equal(t, carol, auction.DetermineWinner(), "the original winner is unchanged")
+	assert.Equal(t, alice, auction.DetermineWinnerExcluding(carol.ID, bob.ID))
+
+	_, err = auction.AwardNext()
+	assert.ErrorIs(t, err, ErrNoEligibleWinner)
+	_, err = auction.AwardNext()
+	assert.ErrorIs(t, err, ErrNoEligibleWinner)
+	assert.Len(t, auction.AwardChain(), 3)
+	assert.Nil(t, auction.DetermineWinnerExcluding(alice.ID, bob.ID, carol.ID))
+}
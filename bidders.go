@@ -0,0 +1,184 @@
+package dispatchbidder
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+)
+
+// AddBidder registers a new bidder with an open or pending auction. The
+// bidder is validated against the auction's configuration and registers
+// last: Bidders iterates in registration order, which removals preserve, so
+// tie-breaks on registration stay deterministic.
+func (a *Auction) AddBidder(bidder *Bidder) error {
+	a.Lock()
+	defer a.Unlock()
+
+	if a.state.ended() {
+		return a.checkOpen()
+	}
+	if _, exists := a.bidderByID(bidder.ID); exists {
+		return fmt.Errorf("%w: %s", ErrDuplicateBidder, bidder.ID)
+	}
+
+	na := a.config()
+	na.Bidders = append(na.Bidders, bidder)
+	if err := validateAuctionData(na); err != nil {
+		return fmt.Errorf("invalid bidder: %w", err)
+	}
+	if err := a.quoteRates([]*Bidder{bidder}); err != nil {
+		return fmt.Errorf("invalid bidder: %w", err)
+	}
+
+	a.seq++
+	bidder.seq = a.seq
+	a.Bidders = na.Bidders
+	a.reindex(len(a.Bidders) - 1)
+	a.refreshLeader()
+	a.version++
+	a.notify()
+
+	return nil
+}
+
+// RemoveBidder removes a bidder from an open or pending auction, keeping the
+// registration order of the remaining bidders. The bidder's bids stay in the
+// history.
+func (a *Auction) RemoveBidder(id uuid.UUID) error {
+	a.Lock()
+	defer a.Unlock()
+
+	if a.state.ended() {
+		return a.checkOpen()
+	}
+	i, ok := a.bidderIndex(id)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrBidderNotFound, id)
+	}
+
+	a.Bidders[i].setAuction(nil)
+	a.Bidders = append(a.Bidders[:i:i], a.Bidders[i+1:]...)
+	delete(a.index, id)
+	delete(a.limiters, id)
+	a.reindex(i)
+	a.refreshLeader()
+	a.version++
+	a.notify()
+
+	return nil
+}
+
+// BidderList returns the auction bidders in registration order. The slice is
+// a copy taken under the lock, so appending to, reordering or clearing it
+// does not affect the auction; the bidders are the live ones, to pass to
+// PlaceBid.
+func (a *Auction) BidderList() []*Bidder {
+	a.RLock()
+	defer a.RUnlock()
+
+	return append([]*Bidder(nil), a.Bidders...)
+}
+
+// BidderCount returns the number of bidders registered with the auction.
+func (a *Auction) BidderCount() int {
+	a.RLock()
+	defer a.RUnlock()
+
+	return len(a.Bidders)
+}
+
+// ForEachBidder calls fn for each bidder in registration order under the read
+// lock, stopping early when fn returns false. fn receives a shallow copy of
+// the bidder, so assigning its fields does not affect the auction, but its
+// Metadata and IncrementSequence are shared and must not be modified. fn must
+// not call methods of the auction that take the write lock, which would
+// deadlock, and should return quickly, as it holds up every bid meanwhile.
+func (a *Auction) ForEachBidder(fn func(*Bidder) bool) {
+	a.RLock()
+	defer a.RUnlock()
+
+	for _, bidder := range a.Bidders {
+		b := *bidder
+		b.owner = atomic.Value{}
+		if !fn(&b) {
+			return
+		}
+	}
+}
+
+// checkMember returns ErrBidderNotInAuction unless the bidder is one of the
+// auction's own, rather than, say, a bidder of another auction or a copy
+// with the same ID. The caller must hold at least a read lock.
+func (a *Auction) checkMember(bidder *Bidder) error {
+	if member, ok := a.bidderByID(bidder.ID); !ok || member != bidder {
+		return fmt.Errorf("%w: bidder ID %s", ErrBidderNotInAuction, bidder.ID)
+	}
+	return nil
+}
+
+// LookupBidder returns the auction bidder with the given ID.
+func (a *Auction) LookupBidder(id uuid.UUID) (*Bidder, bool) {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.bidderByID(id)
+}
+
+// bidderByID returns the auction bidder with the given ID. The caller must
+// hold at least a read lock.
+func (a *Auction) bidderByID(id uuid.UUID) (*Bidder, bool) {
+	if i, ok := a.bidderIndex(id); ok {
+		return a.Bidders[i], true
+	}
+	return nil, false
+}
+
+// bidderIndex returns the position of the bidder with the given ID in
+// Bidders. Bidders is exported and may have been modified directly, so a
+// stale index falls back to a scan. The caller must hold at least a read lock.
+func (a *Auction) bidderIndex(id uuid.UUID) (int, bool) {
+	if i, ok := a.index[id]; ok && i < len(a.Bidders) && a.Bidders[i].ID == id {
+		return i, true
+	}
+	for i, bidder := range a.Bidders {
+		if bidder.ID == id {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// reindex updates the bidder index for the positions from the given one on.
+// The caller must hold the lock.
+func (a *Auction) reindex(from int) {
+	if a.index == nil {
+		a.index = make(map[uuid.UUID]int, len(a.Bidders))
+	}
+	for i := from; i < len(a.Bidders); i++ {
+		a.index[a.Bidders[i].ID] = i
+		if a.Bidders[i].auction() != a {
+			a.Bidders[i].setAuction(a)
+		}
+	}
+}
+
+// config returns the configuration of the auction as a NewAuctionConfig,
+// with a copy of its bidder slice. The caller must hold at least a read lock.
+func (a *Auction) config() NewAuctionConfig {
+	return NewAuctionConfig{
+		Bidders:         append([]*Bidder(nil), a.Bidders...),
+		AuctionMaxBid:   a.AuctionMaxBid,
+		Mode:            a.Mode,
+		TargetPrice:     a.TargetPrice,
+		BidGridStep:     a.BidGridStep,
+		MinDuration:     a.MinDuration,
+		MinIncrement:    a.MinIncrement,
+		IncrementTable:  a.IncrementTable,
+		ReservePrice:    a.ReservePrice,
+		Units:           a.Units,
+		MaxRoundRise:    a.MaxRoundRise,
+		MaxTotalBids:    a.MaxTotalBids,
+		MinParticipants: a.MinParticipants,
+	}
+}
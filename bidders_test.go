@@ -0,0 +1,143 @@
+package dispatchbidder
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// bidderNames returns the names of the auction bidders in iteration order.
+func bidderNames(a *Auction) []string {
+	var names []string
+	for _, b := range a.BidderList() {
+		names = append(names, b.Name)
+	}
+	return names
+}
+
+// TestAddRemoveBidder tests that the bidder set keeps registration order.
+func TestAddRemoveBidder(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 80.00, 3.00)
+	bob := createBidder("Bob", 60.00, 82.00, 2.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+	assert.NoError(t, err)
+	initial := bidderNames(auction)
+
+	carol := createBidder("Carol", 55.00, 90.00, 5.00)
+	dave := createBidder("Dave", 40.00, 70.00, 5.00)
+	erin := createBidder("Erin", 45.00, 75.00, 5.00)
+	for _, b := range []*Bidder{carol, dave, erin} {
+		assert.NoError(t, auction.AddBidder(b))
+	}
+	assert.Equal(t, append(initial, "Carol", "Dave", "Erin"), bidderNames(auction))
+
+	assert.NoError(t, auction.RemoveBidder(dave.ID))
+	assert.Equal(t, append(initial, "Carol", "Erin"), bidderNames(auction), "removal preserves order")
+
+	assert.NoError(t, auction.RemoveBidder(alice.ID))
+	assert.NoError(t, auction.RemoveBidder(bob.ID))
+	assert.Equal(t, []string{"Carol", "Erin"}, bidderNames(auction))
+
+	// Lookups by ID stay correct after the positions shifted.
+	assert.NoError(t, auction.FreezeBidder(erin.ID))
+	assert.True(t, erin.frozen)
+
+	assert.ErrorIs(t, auction.RemoveBidder(dave.ID), ErrBidderNotFound)
+	assert.ErrorIs(t, auction.AddBidder(carol), ErrDuplicateBidder)
+	assert.Error(t, auction.AddBidder(createBidder("Bad", 50.00, 40.00, 1.00)))
+
+	_, err = auction.Close()
+	assert.NoError(t, err)
+	assert.ErrorIs(t, auction.AddBidder(createBidder("Late", 50.00, 60.00, 1.00)), ErrAuctionClosed)
+	assert.ErrorIs(t, auction.RemoveBidder(carol.ID), ErrAuctionClosed)
+	assert.ErrorIs(t, auction.RemoveBidder(uuid.New()), ErrAuctionClosed)
+}
+
+// TestAddBidderTieBreak tests that a bidder added later loses exact ties.
+func TestAddBidderTieBreak(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 80.00, 3.00)
+	bob := createBidder("Bob", 60.00, 82.00, 2.00)
+	clock := newManualClock()
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, WithClock(clock))
+	assert.NoError(t, err)
+	assert.NoError(t, auction.RemoveBidder(alice.ID))
+
+	late := createBidder("Late", 90.00, 90.00, 0)
+	early := createBidder("Early", 90.00, 90.00, 0)
+	assert.NoError(t, auction.AddBidder(early))
+	assert.NoError(t, auction.AddBidder(late))
+
+	assert.NoError(t, auction.ForceSettle())
+	assert.Equal(t, "Early", auction.DetermineWinner().Name)
+}
+
+// TestBidderList tests that the bidder accessors return copies of the slice.
+func TestBidderList(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 80.00, 3.00)
+	bob := createBidder("Bob", 60.00, 82.00, 2.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+	assert.NoError(t, err)
+	initial := bidderNames(auction)
+
+	list := auction.BidderList()
+	assert.Len(t, list, 2)
+	list[0], list[1] = list[1], nil
+	list = append(list, createBidder("Mallory", 1.00, 1000.00, 1.00))
+	assert.Len(t, list, 3)
+
+	assert.Equal(t, initial, bidderNames(auction), "modifying the list does not affect the auction")
+	assert.Equal(t, 2, auction.BidderCount())
+
+	found, ok := auction.LookupBidder(bob.ID)
+	assert.True(t, ok)
+	assert.Same(t, bob, found, "the bidders are the live ones")
+	assert.NoError(t, auction.PlaceBid(found, 70.00))
+
+	_, ok = auction.LookupBidder(uuid.New())
+	assert.False(t, ok)
+}
+
+// TestForEachBidder tests full and early-terminated iteration over copies of
+// the bidders.
+func TestForEachBidder(t *testing.T) {
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{
+		createBidder("Alice", 50.00, 80.00, 3.00),
+		createBidder("Bob", 60.00, 82.00, 2.00),
+		createBidder("Carol", 55.00, 90.00, 5.00),
+	}})
+	assert.NoError(t, err)
+
+	t.Run("Full iteration", func(t *testing.T) {
+		var names []string
+		auction.ForEachBidder(func(b *Bidder) bool {
+			names = append(names, b.Name)
+			return true
+		})
+		assert.Equal(t, bidderNames(auction), names)
+	})
+
+	t.Run("Early termination", func(t *testing.T) {
+		calls := 0
+		auction.ForEachBidder(func(b *Bidder) bool {
+			calls++
+			return calls < 2
+		})
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("Bidders are copies", func(t *testing.T) {
+		auction.ForEachBidder(func(b *Bidder) bool {
+			b.CurrentBid = 1000.00
+			b.Name = "Mallory"
+			return true
+		})
+		assert.NotContains(t, bidderNames(auction), "Mallory")
+		for _, bidder := range auction.BidderList() {
+			assert.Less(t, bidder.CurrentBid, 1000.00)
+		}
+	})
+}
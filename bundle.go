@@ -0,0 +1,212 @@
+package dispatchbidder
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxBundleItems bounds the number of items of a bundle auction, since the
+// winner determination enumerates every subset of items.
+const maxBundleItems = 16
+
+// Item is a distinct lot offered in a bundle auction.
+type Item struct {
+	ID   uuid.UUID
+	Name string
+}
+
+// BundleBid is an all-or-nothing bid on a set of items: the bidder wins
+// either every item of the bundle or none.
+type BundleBid struct {
+	BidderID uuid.UUID
+	ItemIDs  []uuid.UUID
+	Amount   float64
+	Time     time.Time
+}
+
+// BundleAuction sells several distinct items where bidders may bid on
+// bundles, awarding items so revenue is maximized without selling any item
+// twice.
+type BundleAuction struct {
+	sync.RWMutex
+	ID      uuid.UUID
+	Items   []Item
+	Bidders []*Bidder
+
+	bids  []BundleBid
+	masks []uint32 // Item bitmask of each bid, aligned with bids.
+	clock Clock
+}
+
+// NewBundleAuction creates a bundle auction for the given items and bidders.
+func NewBundleAuction(items []Item, bidders []*Bidder, opts ...Option) (*BundleAuction, error) {
+	if len(items) == 0 {
+		return nil, errors.New("invalid bundle auction data: at least one item is required")
+	}
+	if len(items) > maxBundleItems {
+		return nil, fmt.Errorf("invalid bundle auction data: at most %d items are supported, got %d", maxBundleItems, len(items))
+	}
+
+	seenItems := make(map[uuid.UUID]bool)
+	for _, item := range items {
+		if seenItems[item.ID] {
+			return nil, fmt.Errorf("invalid bundle auction data: duplicate item ID detected: %s", item.ID)
+		}
+		seenItems[item.ID] = true
+	}
+
+	// Reuse the single-item auction options for the clock and ID generator.
+	var cfg Auction
+	cfg.clock = systemClock{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	seenBidders := make(map[uuid.UUID]bool)
+	for _, bidder := range bidders {
+		if seenBidders[bidder.ID] {
+			return nil, fmt.Errorf("invalid bundle auction data: duplicate bidder ID detected: %s", bidder.ID)
+		}
+		seenBidders[bidder.ID] = true
+	}
+
+	return &BundleAuction{
+		ID:      cfg.newID(),
+		Items:   append([]Item(nil), items...),
+		Bidders: append([]*Bidder(nil), bidders...),
+		clock:   cfg.clock,
+	}, nil
+}
+
+// PlaceBundleBid places an all-or-nothing bid on the given items. The amount
+// must be positive and within the bidder's MaxBid. Bidding again on the same
+// set of items replaces the bidder's previous bid on it, which must be raised.
+func (a *BundleAuction) PlaceBundleBid(bidderID uuid.UUID, itemIDs []uuid.UUID, amount float64) error {
+	a.Lock()
+	defer a.Unlock()
+
+	var bidder *Bidder
+	for _, b := range a.Bidders {
+		if b.ID == bidderID {
+			bidder = b
+		}
+	}
+	if bidder == nil {
+		return fmt.Errorf("%w: %s", ErrBidderNotFound, bidderID)
+	}
+	if amount <= 0 {
+		return fmt.Errorf("bundle bid amount must be positive, got $%.2f", amount)
+	}
+	if amount > bidder.MaxBid {
+		return fmt.Errorf("%w: bid amount $%.2f is greater than max bid $%.2f", ErrAboveMaxBid, amount, bidder.MaxBid)
+	}
+
+	mask, err := a.itemMask(itemIDs)
+	if err != nil {
+		return err
+	}
+
+	bid := BundleBid{
+		BidderID: bidderID,
+		ItemIDs:  append([]uuid.UUID(nil), itemIDs...),
+		Amount:   amount,
+		Time:     a.clock.Now(),
+	}
+
+	for i := range a.bids {
+		if a.bids[i].BidderID == bidderID && a.masks[i] == mask {
+			if amount <= a.bids[i].Amount {
+				return fmt.Errorf("%w: bid amount $%.2f is less than or equal to current bid $%.2f", ErrNotAboveCurrentBid, amount, a.bids[i].Amount)
+			}
+			a.bids[i] = bid
+			return nil
+		}
+	}
+
+	a.bids = append(a.bids, bid)
+	a.masks = append(a.masks, mask)
+
+	return nil
+}
+
+// DetermineWinners solves the winner determination problem: it picks the set
+// of bundle bids with the highest total amount such that no item is awarded
+// twice. It returns the winning bids, in bid order, and the total revenue.
+// The solver enumerates every subset of items, which is fine for the small
+// item counts bundle auctions allow.
+func (a *BundleAuction) DetermineWinners() ([]BundleBid, float64) {
+	a.RLock()
+	defer a.RUnlock()
+
+	size := 1 << len(a.Items)
+	best := make([]float64, size)
+	choice := make([]int, size) // Bid taken for the mask, or -1 to leave its lowest item unsold.
+
+	for mask := 1; mask < size; mask++ {
+		lowest := uint32(mask & -mask)
+
+		best[mask] = best[mask&^int(lowest)]
+		choice[mask] = -1
+
+		for i, bidMask := range a.masks {
+			if bidMask&lowest == 0 || uint32(mask)&bidMask != bidMask {
+				continue
+			}
+			if revenue := a.bids[i].Amount + best[mask&^int(bidMask)]; revenue > best[mask] {
+				best[mask] = revenue
+				choice[mask] = i
+			}
+		}
+	}
+
+	var winners []int
+	for mask := size - 1; mask > 0; {
+		if i := choice[mask]; i >= 0 {
+			winners = append(winners, i)
+			mask &^= int(a.masks[i])
+		} else {
+			mask &^= mask & -mask
+		}
+	}
+	sort.Ints(winners)
+
+	bids := make([]BundleBid, len(winners))
+	for i, w := range winners {
+		bids[i] = a.bids[w]
+		bids[i].ItemIDs = append([]uuid.UUID(nil), a.bids[w].ItemIDs...)
+	}
+
+	return bids, best[size-1]
+}
+
+// itemMask converts item IDs into a bitmask over the auction items. The
+// caller must hold at least a read lock.
+func (a *BundleAuction) itemMask(itemIDs []uuid.UUID) (uint32, error) {
+	if len(itemIDs) == 0 {
+		return 0, errors.New("bundle bid must include at least one item")
+	}
+
+	var mask uint32
+	for _, id := range itemIDs {
+		index := -1
+		for i, item := range a.Items {
+			if item.ID == id {
+				index = i
+			}
+		}
+		if index < 0 {
+			return 0, fmt.Errorf("%w: %s", ErrItemNotFound, id)
+		}
+		if mask&(1<<index) != 0 {
+			return 0, fmt.Errorf("duplicate item ID in bundle: %s", id)
+		}
+		mask |= 1 << index
+	}
+
+	return mask, nil
+}
@@ -0,0 +1,83 @@
+package dispatchbidder
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBundleAuction tests winner determination with bundle bids.
+func TestBundleAuction(t *testing.T) {
+	itemA := Item{ID: uuid.New(), Name: "Lamp"}
+	itemB := Item{ID: uuid.New(), Name: "Table"}
+
+	newBundleAuction := func(t *testing.T) (*BundleAuction, *Bidder, *Bidder, *Bidder) {
+		alice := createBidder("Alice", 1.00, 500.00, 1.00)
+		bob := createBidder("Bob", 1.00, 500.00, 1.00)
+		carol := createBidder("Carol", 1.00, 500.00, 1.00)
+
+		auction, err := NewBundleAuction([]Item{itemA, itemB}, []*Bidder{alice, bob, carol})
+		assert.NoError(t, err)
+
+		return auction, alice, bob, carol
+	}
+
+	t.Run("Bundle beats two singletons", func(t *testing.T) {
+		auction, alice, bob, carol := newBundleAuction(t)
+
+		assert.NoError(t, auction.PlaceBundleBid(alice.ID, []uuid.UUID{itemA.ID}, 50.00))
+		assert.NoError(t, auction.PlaceBundleBid(bob.ID, []uuid.UUID{itemB.ID}, 40.00))
+		assert.NoError(t, auction.PlaceBundleBid(carol.ID, []uuid.UUID{itemA.ID, itemB.ID}, 100.00))
+
+		winners, revenue := auction.DetermineWinners()
+		assert.Equal(t, 100.00, revenue)
+		if assert.Len(t, winners, 1) {
+			assert.Equal(t, carol.ID, winners[0].BidderID)
+			assert.ElementsMatch(t, []uuid.UUID{itemA.ID, itemB.ID}, winners[0].ItemIDs)
+		}
+	})
+
+	t.Run("Singletons beat a low bundle", func(t *testing.T) {
+		auction, alice, bob, carol := newBundleAuction(t)
+
+		assert.NoError(t, auction.PlaceBundleBid(alice.ID, []uuid.UUID{itemA.ID}, 50.00))
+		assert.NoError(t, auction.PlaceBundleBid(bob.ID, []uuid.UUID{itemB.ID}, 40.00))
+		assert.NoError(t, auction.PlaceBundleBid(carol.ID, []uuid.UUID{itemA.ID, itemB.ID}, 80.00))
+
+		winners, revenue := auction.DetermineWinners()
+		assert.Equal(t, 90.00, revenue)
+		if assert.Len(t, winners, 2) {
+			assert.Equal(t, alice.ID, winners[0].BidderID)
+			assert.Equal(t, bob.ID, winners[1].BidderID)
+		}
+	})
+
+	t.Run("Raising replaces the previous bid on the same bundle", func(t *testing.T) {
+		auction, alice, bob, carol := newBundleAuction(t)
+
+		assert.NoError(t, auction.PlaceBundleBid(alice.ID, []uuid.UUID{itemA.ID}, 50.00))
+		assert.NoError(t, auction.PlaceBundleBid(bob.ID, []uuid.UUID{itemB.ID}, 40.00))
+		assert.NoError(t, auction.PlaceBundleBid(carol.ID, []uuid.UUID{itemB.ID, itemA.ID}, 80.00))
+		assert.ErrorIs(t, auction.PlaceBundleBid(carol.ID, []uuid.UUID{itemA.ID, itemB.ID}, 70.00), ErrNotAboveCurrentBid)
+		assert.NoError(t, auction.PlaceBundleBid(carol.ID, []uuid.UUID{itemA.ID, itemB.ID}, 95.00))
+
+		winners, revenue := auction.DetermineWinners()
+		assert.Equal(t, 95.00, revenue)
+		assert.Len(t, winners, 1)
+	})
+
+	t.Run("Invalid bids", func(t *testing.T) {
+		auction, alice, _, _ := newBundleAuction(t)
+
+		assert.ErrorIs(t, auction.PlaceBundleBid(uuid.New(), []uuid.UUID{itemA.ID}, 10.00), ErrBidderNotFound)
+		assert.ErrorIs(t, auction.PlaceBundleBid(alice.ID, []uuid.UUID{uuid.New()}, 10.00), ErrItemNotFound)
+		assert.ErrorIs(t, auction.PlaceBundleBid(alice.ID, []uuid.UUID{itemA.ID}, 600.00), ErrAboveMaxBid)
+		assert.Error(t, auction.PlaceBundleBid(alice.ID, []uuid.UUID{itemA.ID, itemA.ID}, 10.00))
+		assert.Error(t, auction.PlaceBundleBid(alice.ID, nil, 10.00))
+
+		winners, revenue := auction.DetermineWinners()
+		assert.Empty(t, winners)
+		assert.Zero(t, revenue)
+	})
+}
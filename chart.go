@@ -0,0 +1,38 @@
+package dispatchbidder
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// RenderChart writes an ASCII bar chart of the current bids for terminals:
+// one line per bidder in leaderboard order, with their name, a bar of up to
+// width characters scaled to the highest bid, compared in the base currency,
+// and their CurrentBid. A width of zero or less draws the labels only.
+func (a *Auction) RenderChart(w io.Writer, width int) error {
+	a.RLock()
+	defer a.RUnlock()
+
+	ranked := a.rankBidders()
+	width = max(width, 0)
+
+	nameWidth, top := 0, 0.0
+	for _, b := range ranked {
+		nameWidth = max(nameWidth, len(b.Name))
+		top = max(top, b.baseBid())
+	}
+
+	var sb strings.Builder
+	for _, b := range ranked {
+		bar := 0
+		if top > 0 {
+			bar = int(math.Round(b.baseBid() / top * float64(width)))
+		}
+		fmt.Fprintf(&sb, "%-*s |%s%s| $%.2f\n", nameWidth, b.Name, strings.Repeat("#", bar), strings.Repeat(" ", width-bar), b.CurrentBid)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
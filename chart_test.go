@@ -0,0 +1,62 @@
+package dispatchbidder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRenderChart tests the ASCII bid chart.
+func TestRenderChart(t *testing.T) {
+	bars := func(chart string) []int {
+		var n []int
+		for _, line := range strings.Split(strings.TrimSuffix(chart, "\n"), "\n") {
+			n = append(n, strings.Count(line, "#"))
+		}
+		return n
+	}
+
+	t.Run("Leader has the longest bar", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 80.00, 5.00)
+		bob := createBidder("Bob", 60.00, 100.00, 5.00)
+		carol := createBidder("Carol", 20.00, 40.00, 0)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob, carol}})
+		assert.NoError(t, err)
+		assert.NoError(t, auction.PlaceBid(bob, 90.00))
+
+		var sb strings.Builder
+		assert.NoError(t, auction.RenderChart(&sb, 30))
+		chart := sb.String()
+
+		for _, want := range []string{"Alice", "Bob", "Carol", "$90.00", "$55.00", "$20.00"} {
+			assert.Contains(t, chart, want)
+		}
+		assert.True(t, strings.HasPrefix(chart, "Bob"), "leaderboard order")
+		assert.Equal(t, []int{30, 18, 7}, bars(chart))
+	})
+
+	t.Run("Zero width and single bidder", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 80.00, 5.00)
+		bob := createBidder("Bob", 60.00, 100.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+
+		var sb strings.Builder
+		assert.NoError(t, auction.RenderChart(&sb, 0))
+		assert.Equal(t, "Bob   || $60.00\nAlice || $50.00\n", sb.String())
+
+		assert.NoError(t, auction.RetractBidder(alice.ID))
+		sb.Reset()
+		assert.NoError(t, auction.RenderChart(&sb, 10))
+		assert.Equal(t, "Bob |##########| $60.00\n", sb.String())
+	})
+
+	t.Run("Write error", func(t *testing.T) {
+		auction, err := NewAuction(newTestConfig())
+		assert.NoError(t, err)
+		assert.Error(t, auction.RenderChart(failingWriter{}, 10))
+	})
+}
@@ -0,0 +1,107 @@
+package dispatchbidder
+
+import "fmt"
+
+// Checkpoint saves the current state of the auction, its configuration,
+// bidders, history and lifecycle, under a new ID for RestoreCheckpoint, so
+// analysts can branch from a point, try bids and roll back. The checkpoint
+// is kept until DropCheckpoint and never changes.
+func (a *Auction) Checkpoint() string {
+	a.Lock()
+	defer a.Unlock()
+
+	id := a.newID().String()
+	if a.checkpoints == nil {
+		a.checkpoints = make(map[string]*Auction)
+	}
+	a.checkpoints[id] = a.clone()
+
+	return id
+}
+
+// RestoreCheckpoint resets the auction to the state saved by Checkpoint,
+// including its version, and notifies subscribers. Bidders that existed at
+// the checkpoint are restored in place, so pointers held by callers remain
+// valid; bidders added since are removed. Hooks, sinks, subscribers and a
+// running countdown are left as they are, and the random source is not
+// rewound. The checkpoint stays available.
+func (a *Auction) RestoreCheckpoint(id string) error {
+	a.Lock()
+	defer a.Unlock()
+
+	checkpoint, ok := a.checkpoints[id]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrCheckpointNotFound, id)
+	}
+	a.restoreCheckpoint(checkpoint)
+
+	return nil
+}
+
+// restoreCheckpoint resets the auction to the checkpoint as described by
+// RestoreCheckpoint. The caller must hold the lock.
+func (a *Auction) restoreCheckpoint(checkpoint *Auction) {
+	c := checkpoint.clone()
+
+	bidders := make([]*Bidder, len(c.Bidders))
+	for i, saved := range c.Bidders {
+		bidders[i] = saved
+		if live, ok := a.bidderByID(saved.ID); ok {
+			live.assign(saved)
+			bidders[i] = live
+		}
+		if c.winner == saved {
+			c.winner = bidders[i]
+		}
+	}
+
+	a.ID = c.ID
+	a.AuctionMaxBid = c.AuctionMaxBid
+	a.Mode = c.Mode
+	a.TargetPrice = c.TargetPrice
+	a.BidGridStep = c.BidGridStep
+	a.MinDuration = c.MinDuration
+	a.MinIncrement = c.MinIncrement
+	a.IncrementTable = c.IncrementTable
+	a.ReservePrice = c.ReservePrice
+	a.Units = c.Units
+	a.MinParticipants = c.MinParticipants
+	a.MaxRoundRise = c.MaxRoundRise
+	a.MaxTotalBids = c.MaxTotalBids
+	a.state = c.state
+	a.winner = c.winner
+	a.version = c.version
+	a.seq = c.seq
+	a.history = c.history
+	a.openedAt = c.openedAt
+	a.closedAt = c.closedAt
+	a.endTime = c.endTime
+	a.paused = c.paused
+	a.pausedAt = c.pausedAt
+	a.pausedFor = c.pausedFor
+	a.cancelReason = c.cancelReason
+	a.voidReason = c.voidReason
+	a.awards = c.awards
+	a.bidReceived = c.bidReceived
+	a.refunds = c.refunds
+	a.commitments = c.commitments
+
+	a.Bidders = bidders
+	a.index = nil
+	a.reindex(0)
+	a.refreshLeader()
+	a.notify()
+}
+
+// DropCheckpoint discards the checkpoint, releasing its memory.
+func (a *Auction) DropCheckpoint(id string) error {
+	a.Lock()
+	defer a.Unlock()
+
+	if _, ok := a.checkpoints[id]; !ok {
+		return fmt.Errorf("%w: %s", ErrCheckpointNotFound, id)
+	}
+	delete(a.checkpoints, id)
+
+	return nil
+}
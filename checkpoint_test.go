@@ -0,0 +1,94 @@
+package dispatchbidder
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCheckpoint tests branching from a checkpoint and rolling back.
+func TestCheckpoint(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 100.00, 5.00)
+	bob := createBidder("Bob", 60.00, 100.00, 5.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, WithClock(newManualClock()))
+	assert.NoError(t, err)
+	assert.NoError(t, auction.PlaceBid(alice, 70.00))
+
+	id := auction.Checkpoint()
+	saved := auction.Snapshot()
+	history := auction.History()
+
+	t.Run("Restore undoes bids, bidders and lifecycle changes", func(t *testing.T) {
+		assert.NoError(t, auction.PlaceBid(bob, 90.00))
+		assert.NoError(t, auction.AddBidder(createBidder("Carol", 55.00, 100.00, 5.00)))
+		_, err = auction.Close()
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.RestoreCheckpoint(id))
+		assert.Equal(t, saved, auction.Snapshot())
+		assert.Equal(t, history, auction.History())
+		assert.Equal(t, StateOpen, auction.State())
+		assert.Equal(t, alice, auction.DetermineWinner())
+	})
+
+	t.Run("Caller-held bidders stay live", func(t *testing.T) {
+		assert.Equal(t, 65.00, bob.CurrentBid)
+		assert.NoError(t, auction.PlaceBid(bob, 80.00))
+		assert.Equal(t, bob, auction.DetermineWinner())
+	})
+
+	t.Run("Checkpoints can be restored again", func(t *testing.T) {
+		assert.NoError(t, auction.RestoreCheckpoint(id))
+		assert.Equal(t, saved, auction.Snapshot())
+	})
+
+	t.Run("Dropped checkpoints are gone", func(t *testing.T) {
+		assert.NoError(t, auction.DropCheckpoint(id))
+		assert.ErrorIs(t, auction.RestoreCheckpoint(id), ErrCheckpointNotFound)
+		assert.ErrorIs(t, auction.DropCheckpoint(id), ErrCheckpointNotFound)
+	})
+}
+
+// TestCheckpointAccessors tests that the bidder accessors can be read while
+// checkpoints are restored; run with -race.
+func TestCheckpointAccessors(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 1000.00, 1.00)
+	bob := createBidder("Bob", 60.00, 1000.00, 1.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, WithClock(newManualClock()))
+	assert.NoError(t, err)
+	id := auction.Checkpoint()
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			_ = alice.Bid()
+			_ = bob.BidTime()
+			_ = bob.View()
+		}
+	}()
+
+	for round := 0; round < 500; round++ {
+		assert.NoError(t, auction.PlaceBid(alice, 70.00))
+		assert.NoError(t, auction.RestoreCheckpoint(id))
+		assert.NoError(t, auction.PlaceBid(alice, 70.00))
+		_, err := auction.Close()
+		assert.NoError(t, err)
+		assert.NoError(t, auction.ReopenFromCheckpoint(id))
+	}
+	close(stop)
+	wg.Wait()
+
+	assert.Equal(t, auction, alice.auction())
+	assert.Equal(t, 50.00, alice.Bid())
+}
@@ -0,0 +1,42 @@
+package dispatchbidder
+
+import "time"
+
+// Clock provides the current time to an auction. Inject a custom clock with
+// WithClock to make time-dependent behavior deterministic.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the default Clock, backed by time.Now.
+type systemClock struct{}
+
+// Now returns the current local time.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// WithClock sets the clock used to stamp bids and lifecycle events.
+func WithClock(c Clock) Option {
+	return func(a *Auction) {
+		a.clock = c
+	}
+}
+
+// WithTieEpsilon makes bids whose LastBidTime values are within epsilon of
+// each other tie-break on their monotonic sequence number instead of their
+// timestamps. This keeps earliest-bid-wins fair on coarse clocks, such as on
+// Windows where the resolution can be around 15ms.
+func WithTieEpsilon(epsilon time.Duration) Option {
+	return func(a *Auction) {
+		a.tieEpsilon = epsilon
+	}
+}
+
+// now returns the current time from the auction clock.
+func (a *Auction) now() time.Time {
+	if a.clock == nil {
+		return time.Now()
+	}
+	return a.clock.Now()
+}
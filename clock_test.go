@@ -0,0 +1,103 @@
+package dispatchbidder
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// manualClock is a Clock that only moves when advanced by the test.
+type manualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// newManualClock creates a manual clock set to a fixed instant.
+func newManualClock() *manualClock {
+	return &manualClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+// Now returns the current time of the clock.
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// Advance moves the clock forward by d.
+func (c *manualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+// scriptedClock is a Clock returning a fixed sequence of times, repeating the
+// last one once exhausted.
+type scriptedClock struct {
+	mu    sync.Mutex
+	times []time.Time
+}
+
+// Now returns the next scripted time.
+func (c *scriptedClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.times[0]
+	if len(c.times) > 1 {
+		c.times = c.times[1:]
+	}
+	return now
+}
+
+// TestTieEpsilon tests that timestamps within the tie epsilon fall back to the
+// bid sequence.
+func TestTieEpsilon(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		times        []time.Time
+		opts         []Option
+		expectedName string
+	}{
+		{
+			name:         "Coarse clock yields identical timestamps",
+			times:        []time.Time{base, base},
+			expectedName: "Alice",
+		},
+		{
+			name:         "Skewed timestamps outside epsilon favor the earlier time",
+			times:        []time.Time{base.Add(10 * time.Millisecond), base.Add(2 * time.Millisecond)},
+			expectedName: "Bob",
+		},
+		{
+			name:         "Skewed timestamps within epsilon favor the earlier sequence",
+			times:        []time.Time{base.Add(10 * time.Millisecond), base.Add(2 * time.Millisecond)},
+			opts:         []Option{WithTieEpsilon(15 * time.Millisecond)},
+			expectedName: "Alice",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Increments larger than the headroom keep auto-bumps out of the way.
+			alice := createBidder("Alice", 50.00, 80.00, 100.00)
+			bob := createBidder("Bob", 50.00, 80.00, 100.00)
+
+			// The first scripted time is consumed when the auction opens.
+			clock := &scriptedClock{times: append([]time.Time{base}, tt.times...)}
+			auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, append(tt.opts, WithClock(clock))...)
+			assert.NoError(t, err)
+
+			assert.NoError(t, auction.PlaceBid(alice, 80.00))
+			assert.NoError(t, auction.PlaceBid(bob, 80.00))
+
+			assert.Equal(t, tt.expectedName, auction.DetermineWinner().Name)
+		})
+	}
+}
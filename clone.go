@@ -0,0 +1,65 @@
+package dispatchbidder
+
+// Clone returns an independent deep copy of the auction, including its
+// configuration, bidders and history, for simulation and what-if analysis.
+// Hooks, event sinks, subscribers, auto-persistence, checkpoints and
+// countdowns belong to the live auction and are not copied. The clone gets
+// its own random source, restarted from the seed, and full rate limit
+// buckets.
+func (a *Auction) Clone() *Auction {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.clone()
+}
+
+// clone returns a deep copy of the auction. The caller must hold at least a
+// read lock.
+func (a *Auction) clone() *Auction {
+	c := &Auction{
+		ID:              a.ID,
+		AuctionMaxBid:   a.AuctionMaxBid,
+		Mode:            a.Mode,
+		TargetPrice:     a.TargetPrice,
+		BidGridStep:     a.BidGridStep,
+		MinDuration:     a.MinDuration,
+		MinIncrement:    a.MinIncrement,
+		IncrementTable:  append(IncrementTable(nil), a.IncrementTable...),
+		ReservePrice:    a.ReservePrice,
+		Units:           a.Units,
+		MaxRoundRise:    a.MaxRoundRise,
+		MaxTotalBids:    a.MaxTotalBids,
+		MinParticipants: a.MinParticipants,
+		settings:        a.settings,
+		state:           a.state,
+		version:         a.version,
+		seq:             a.seq,
+		history:         append([]BidEvent(nil), a.history...),
+		openedAt:        a.openedAt,
+		closedAt:        a.closedAt,
+		endTime:         a.endTime,
+		paused:          a.paused,
+		pausedAt:        a.pausedAt,
+		pausedFor:       a.pausedFor,
+		cancelReason:    a.cancelReason,
+		voidReason:      a.voidReason,
+		awards:          append([]Award(nil), a.awards...),
+		refunds:         copyLedger(a.refunds),
+		commitments:     copyLedger(a.commitments),
+		bidReceived:     a.bidReceived,
+	}
+	c.rng, c.source = newLockedRand(a.seed, 0)
+
+	c.Bidders = make([]*Bidder, len(a.Bidders))
+	for i, bidder := range a.Bidders {
+		b := bidder.copy()
+		c.Bidders[i] = b
+		if a.winner == bidder {
+			c.winner = b
+		}
+	}
+	c.reindex(0)
+	c.refreshLeader()
+
+	return c
+}
@@ -0,0 +1,33 @@
+package dispatchbidder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestClone tests that a clone is independent of the live auction.
+func TestClone(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 100.00, 5.00)
+	bob := createBidder("Bob", 60.00, 100.00, 5.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, AuctionMaxBid: 95.00})
+	assert.NoError(t, err)
+	assert.NoError(t, auction.PlaceBid(alice, 70.00))
+
+	clone := auction.Clone()
+	assert.Equal(t, auction.ID, clone.ID)
+	assert.Equal(t, auction.AuctionMaxBid, clone.AuctionMaxBid)
+	assert.Equal(t, auction.Version(), clone.Version())
+	assert.Equal(t, auction.History(), clone.History())
+
+	cloneAlice, ok := clone.bidderByID(alice.ID)
+	assert.True(t, ok)
+	assert.NotSame(t, alice, cloneAlice)
+
+	assert.NoError(t, clone.PlaceBid(cloneAlice, 90.00))
+	assert.Equal(t, 70.00, alice.CurrentBid)
+	assert.Equal(t, 65.00, bob.CurrentBid)
+	assert.Len(t, auction.History(), 2)
+	assert.Len(t, clone.History(), 4)
+}
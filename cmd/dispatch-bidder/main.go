@@ -0,0 +1,189 @@
+// Command dispatch-bidder runs an auction described by a JSON config file to
+// completion and prints the winner and the leaderboard.
+//
+// Usage:
+//
+//	dispatch-bidder -config auction.json [-format table|json]
+//
+// The config holds the auction settings and its bidders:
+//
+//	{
+//	  "auction_max_bid": 500,
+//	  "bidders": [
+//	    {"name": "Alice", "starting_bid": 50, "max_bid": 100, "auto_increment": 5},
+//	    {"name": "Bob", "starting_bid": 60, "max_bid": 120, "auto_increment": 5}
+//	  ]
+//	}
+//
+// Bidders without an id get sequential ones in file order, so runs are
+// repeatable. The command exits with status 1 when the config is unreadable
+// or invalid and 2 on bad flags.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/google/uuid"
+
+	dispatchbidder "github.com/leandrorichard/dispatch-bidder"
+)
+
+// config is the JSON auction config read by the command.
+type config struct {
+	AuctionMaxBid float64        `json:"auction_max_bid"`
+	Mode          string         `json:"mode"`
+	TargetPrice   float64        `json:"target_price"`
+	BidGridStep   float64        `json:"bid_grid_step"`
+	MinIncrement  float64        `json:"min_increment"`
+	ReservePrice  float64        `json:"reserve_price"`
+	Bidders       []bidderConfig `json:"bidders"`
+}
+
+// bidderConfig is the JSON config of one bidder.
+type bidderConfig struct {
+	ID            uuid.UUID `json:"id"`
+	Name          string    `json:"name"`
+	StartingBid   float64   `json:"starting_bid"`
+	MaxBid        float64   `json:"max_bid"`
+	ProxyMax      float64   `json:"proxy_max"`
+	AutoIncrement float64   `json:"auto_increment"`
+}
+
+// output is the JSON output of the command.
+type output struct {
+	Winner      *dispatchbidder.BidderView  `json:"winner"`
+	Leaderboard []dispatchbidder.BidderView `json:"leaderboard"`
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command with the given arguments and returns its exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("dispatch-bidder", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	configPath := fs.String("config", "", "path of the auction config JSON")
+	format := fs.String("format", "table", "output format: table or json")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *configPath == "" || (*format != "table" && *format != "json") {
+		fmt.Fprintln(stderr, "dispatch-bidder: -config is required and -format must be table or json")
+		fs.Usage()
+		return 2
+	}
+
+	auction, err := loadAuction(*configPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "dispatch-bidder: %v\n", err)
+		return 1
+	}
+	winner, err := auction.RunToCompletion()
+	if err != nil {
+		fmt.Fprintf(stderr, "dispatch-bidder: %v\n", err)
+		return 1
+	}
+
+	board := auction.Leaderboard()
+	if *format == "json" {
+		out := output{Leaderboard: board}
+		for i := range board {
+			if winner != nil && board[i].ID == winner.ID {
+				out.Winner = &board[i]
+			}
+		}
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(out); err != nil {
+			fmt.Fprintf(stderr, "dispatch-bidder: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	writeTable(stdout, winner, board)
+	return 0
+}
+
+// loadAuction reads the config file and builds the auction it describes.
+func loadAuction(path string) (*dispatchbidder.Auction, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+
+	var cfg config
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+
+	mode, err := parseMode(cfg.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids dispatchbidder.SequentialIDs
+	na := dispatchbidder.NewAuctionConfig{
+		AuctionMaxBid: cfg.AuctionMaxBid,
+		Mode:          mode,
+		TargetPrice:   cfg.TargetPrice,
+		BidGridStep:   cfg.BidGridStep,
+		MinIncrement:  cfg.MinIncrement,
+		ReservePrice:  cfg.ReservePrice,
+	}
+	for _, bc := range cfg.Bidders {
+		id := bc.ID
+		if id == uuid.Nil {
+			id = ids.New()
+		}
+		na.Bidders = append(na.Bidders, &dispatchbidder.Bidder{
+			ID:            id,
+			Name:          bc.Name,
+			StartingBid:   bc.StartingBid,
+			MaxBid:        bc.MaxBid,
+			CurrentBid:    bc.StartingBid,
+			ProxyMax:      bc.ProxyMax,
+			AutoIncrement: bc.AutoIncrement,
+		})
+	}
+
+	return dispatchbidder.NewAuction(na, dispatchbidder.WithIDGenerator(&ids))
+}
+
+// parseMode returns the mode named in the config: Standard, the default, or
+// FirstToTarget. Sealed-bid modes take no bidding rounds and are rejected.
+func parseMode(name string) (dispatchbidder.Mode, error) {
+	for _, mode := range []dispatchbidder.Mode{dispatchbidder.ModeStandard, dispatchbidder.ModeFirstToTarget} {
+		if name == "" || strings.EqualFold(name, mode.String()) {
+			return mode, nil
+		}
+	}
+	return 0, errors.New("invalid auction data: mode must be Standard or FirstToTarget")
+}
+
+// writeTable prints the winner and the leaderboard as a text table.
+func writeTable(w io.Writer, winner *dispatchbidder.Bidder, board []dispatchbidder.BidderView) {
+	if winner != nil {
+		fmt.Fprintf(w, "Winner: %s at $%.2f\n\n", winner.Name, winner.CurrentBid)
+	} else {
+		fmt.Fprintf(w, "Winner: none\n\n")
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RANK\tBIDDER\tBID\tMAX BID\tSTATUS")
+	for i, view := range board {
+		fmt.Fprintf(tw, "%d\t%s\t$%.2f\t$%.2f\t%s\n", i+1, view.Name, view.CurrentBid, view.MaxBid, view.Status)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeConfig writes the config to a temporary file and returns its path.
+func writeConfig(t *testing.T, config string) string {
+	path := filepath.Join(t.TempDir(), "auction.json")
+	assert.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+	return path
+}
+
+// TestRun tests running auctions from config files through the command.
+func TestRun(t *testing.T) {
+	valid := writeConfig(t, `{
+		"bidders": [
+			{"name": "Alice", "starting_bid": 50, "max_bid": 100, "auto_increment": 5},
+			{"name": "Bob", "starting_bid": 60, "max_bid": 120, "auto_increment": 5}
+		]
+	}`)
+
+	t.Run("Table", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		code := run([]string{"-config", valid}, &stdout, &stderr)
+
+		assert.Equal(t, 0, code)
+		assert.Empty(t, stderr.String())
+		assert.Equal(t, "Winner: Bob at $120.00\n\n"+
+			"RANK  BIDDER  BID      MAX BID  STATUS\n"+
+			"1     Bob     $120.00  $120.00  Leading\n"+
+			"2     Alice   $100.00  $100.00  Maxed\n", stdout.String())
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		code := run([]string{"-config", valid, "-format", "json"}, &stdout, &stderr)
+		assert.Equal(t, 0, code)
+
+		var out output
+		assert.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+		if assert.NotNil(t, out.Winner) {
+			assert.Equal(t, "Bob", out.Winner.Name)
+			assert.Equal(t, 120.00, out.Winner.CurrentBid)
+		}
+		assert.Len(t, out.Leaderboard, 2)
+	})
+
+	tests := []struct {
+		name    string
+		args    []string
+		code    int
+		message string
+	}{
+		{"Missing config flag", []string{}, 2, "-config is required"},
+		{"Unknown format", []string{"-config", valid, "-format", "xml"}, 2, "-format must be table or json"},
+		{"Missing file", []string{"-config", filepath.Join(t.TempDir(), "missing.json")}, 1, "reading config"},
+		{"Malformed JSON", []string{"-config", writeConfig(t, `{"bidders": [`)}, 1, "parsing config"},
+		{"Single bidder", []string{"-config", writeConfig(t, `{"bidders": [{"name": "Alice", "starting_bid": 50, "max_bid": 100}]}`)}, 1, "invalid auction data: auction must have at least two bidders"},
+		{"Unknown mode", []string{"-config", writeConfig(t, `{"mode": "Dutch", "bidders": []}`)}, 1, "mode must be Standard or FirstToTarget"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, &stdout, &stderr)
+
+			assert.Equal(t, tt.code, code)
+			assert.Contains(t, stderr.String(), tt.message)
+			assert.Empty(t, stdout.String())
+		})
+	}
+}
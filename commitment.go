@@ -0,0 +1,65 @@
+package dispatchbidder
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Commitments returns the amounts held from the bidders, by bidder ID, as
+// escrow would hold them: from the close, the winner's price, or the price
+// of the units each winner won in a multi-unit auction, or every bidder's
+// bid in a ModeAllPay auction, less the commitments released since. It
+// returns nil before the close and for voided and cancelled auctions. The
+// map is a copy.
+func (a *Auction) Commitments() map[uuid.UUID]float64 {
+	a.RLock()
+	defer a.RUnlock()
+
+	return copyLedger(a.commitments)
+}
+
+// ReleaseCommitment releases the commitment held from a bidder who lost, so
+// their funds can be returned. It returns ErrNoCommitment when the bidder
+// holds none or won, since only a loser's commitment may be released.
+func (a *Auction) ReleaseCommitment(id uuid.UUID) error {
+	a.Lock()
+	defer a.Unlock()
+
+	if _, ok := a.commitments[id]; !ok {
+		return fmt.Errorf("%w: bidder %s", ErrNoCommitment, id)
+	}
+	if a.won(id) {
+		return fmt.Errorf("%w: bidder %s won the auction", ErrNoCommitment, id)
+	}
+	delete(a.commitments, id)
+
+	return nil
+}
+
+// recordCommitments fills the commitments of an auction being closed with
+// what each bidder owes. The caller must hold the lock.
+func (a *Auction) recordCommitments() {
+	a.commitments = nil
+	charges := a.owed(func(b *Bidder) float64 { return b.CurrentBid })
+	if len(charges) == 0 {
+		return
+	}
+
+	a.commitments = make(map[uuid.UUID]float64, len(charges))
+	for _, c := range charges {
+		if c.amount > 0 {
+			a.commitments[c.bidder.ID] = c.amount
+		}
+	}
+}
+
+// won reports whether the bidder won the auction, or won units of a
+// multi-unit auction. The caller must hold at least a read lock.
+func (a *Auction) won(id uuid.UUID) bool {
+	if a.Units > 1 {
+		return a.allocate()[id] > 0
+	}
+	winner := a.determineWinner()
+	return winner != nil && winner.ID == id
+}
@@ -0,0 +1,71 @@
+package dispatchbidder
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCommitments tests the amounts held on close and their release.
+func TestCommitments(t *testing.T) {
+	t.Run("Winner held in standard mode", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 100.00, 5.00)
+		bob := createBidder("Bob", 60.00, 100.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+		assert.NoError(t, auction.PlaceBid(alice, 70.00))
+		assert.Nil(t, auction.Commitments(), "nothing is held before the close")
+
+		_, err = auction.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, map[uuid.UUID]float64{alice.ID: 70.00}, auction.Commitments())
+
+		assert.ErrorIs(t, auction.ReleaseCommitment(alice.ID), ErrNoCommitment, "the winner's commitment stays held")
+		assert.ErrorIs(t, auction.ReleaseCommitment(bob.ID), ErrNoCommitment)
+	})
+
+	t.Run("All bids held in all-pay mode", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 100.00, 5.00)
+		bob := createBidder("Bob", 60.00, 100.00, 0)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, Mode: ModeAllPay})
+		assert.NoError(t, err)
+		assert.NoError(t, auction.PlaceBid(alice, 70.00))
+		_, err = auction.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, map[uuid.UUID]float64{alice.ID: 70.00, bob.ID: 60.00}, auction.Commitments())
+
+		assert.NoError(t, auction.ReleaseCommitment(bob.ID))
+		assert.Equal(t, map[uuid.UUID]float64{alice.ID: 70.00}, auction.Commitments())
+		assert.ErrorIs(t, auction.ReleaseCommitment(bob.ID), ErrNoCommitment, "already released")
+	})
+
+	t.Run("Cleared on reopen", func(t *testing.T) {
+		auction, err := NewAuction(newTestConfig())
+		assert.NoError(t, err)
+		_, err = auction.Close()
+		assert.NoError(t, err)
+		assert.NotEmpty(t, auction.Commitments())
+
+		assert.NoError(t, auction.Reopen())
+		assert.Nil(t, auction.Commitments())
+	})
+
+	t.Run("Kept by a closed auction", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 100.00, 5.00)
+		bob := createBidder("Bob", 60.00, 100.00, 0)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, Mode: ModeAllPay})
+		assert.NoError(t, err)
+		assert.NoError(t, auction.PlaceBid(alice, 70.00))
+		_, err = auction.Close()
+		assert.NoError(t, err)
+		assert.NotEmpty(t, auction.Commitments())
+
+		assert.ErrorIs(t, auction.Cancel("fraud"), ErrInvalidTransition)
+		assert.Equal(t, map[uuid.UUID]float64{alice.ID: 70.00, bob.ID: 60.00}, auction.Commitments(), "not refunded as well")
+		assert.Nil(t, auction.Refunds())
+	})
+}
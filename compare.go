@@ -0,0 +1,80 @@
+package dispatchbidder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// AuctionOptions is a rule set to run bidders under: an auction
+// configuration, whose Bidders are ignored, and its options.
+type AuctionOptions struct {
+	Config  NewAuctionConfig
+	Options []Option
+}
+
+// RunOutcome is the outcome of one run of CompareRuns.
+type RunOutcome struct {
+	WinnerID uuid.UUID // uuid.Nil when there is no winner.
+	Price    float64   // The winner's CurrentBid, or zero.
+	Rounds   int       // Rounds in which bids were placed.
+}
+
+// ComparisonReport compares the outcomes of the same bidders under two rule
+// sets.
+type ComparisonReport struct {
+	A, B RunOutcome
+
+	WinnerDiffers bool
+	PriceDelta    float64 // B's price minus A's.
+	RoundsDelta   int     // B's rounds minus A's.
+}
+
+// CompareRuns runs copies of the bidders to completion under each rule set,
+// as RunToCompletion does, and reports how the outcomes differ. Each run gets
+// its own deep copies of the bidders, and the bidders passed in are not
+// touched, so the reported winner IDs refer to them.
+func CompareRuns(a, b AuctionOptions, bidders []*Bidder) (ComparisonReport, error) {
+	outcomeA, err := runRules(a, bidders)
+	if err != nil {
+		return ComparisonReport{}, fmt.Errorf("rule set A: %w", err)
+	}
+	outcomeB, err := runRules(b, bidders)
+	if err != nil {
+		return ComparisonReport{}, fmt.Errorf("rule set B: %w", err)
+	}
+
+	return ComparisonReport{
+		A:             outcomeA,
+		B:             outcomeB,
+		WinnerDiffers: outcomeA.WinnerID != outcomeB.WinnerID,
+		PriceDelta:    outcomeB.Price - outcomeA.Price,
+		RoundsDelta:   outcomeB.Rounds - outcomeA.Rounds,
+	}, nil
+}
+
+// runRules runs copies of the bidders to completion under the rule set.
+func runRules(rules AuctionOptions, bidders []*Bidder) (RunOutcome, error) {
+	na := rules.Config
+	na.Bidders = make([]*Bidder, len(bidders))
+	for i, bidder := range bidders {
+		na.Bidders[i] = bidder.copy()
+	}
+
+	auction, err := NewAuction(na, rules.Options...)
+	if err != nil {
+		return RunOutcome{}, err
+	}
+	winner, rounds, err := auction.runToCompletion(context.Background(), nil)
+	if err != nil {
+		return RunOutcome{}, err
+	}
+
+	outcome := RunOutcome{Rounds: rounds}
+	if winner != nil {
+		outcome.WinnerID = winner.ID
+		outcome.Price = winner.CurrentBid
+	}
+	return outcome, nil
+}
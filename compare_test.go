@@ -0,0 +1,41 @@
+package dispatchbidder
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCompareRuns tests comparing the same bidders under two rule sets.
+func TestCompareRuns(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 100.00, 10.00)
+	bob := createBidder("Bob", 60.00, 95.00, 5.00)
+	bob.ID, alice.ID = uuid.UUID{15: 1}, uuid.UUID{15: 2} // Bob bids first in each round.
+	bidders := []*Bidder{alice, bob}
+
+	report, err := CompareRuns(
+		AuctionOptions{},
+		AuctionOptions{Config: NewAuctionConfig{Mode: ModeFirstToTarget, TargetPrice: 65.00}},
+		bidders,
+	)
+	assert.NoError(t, err)
+
+	assert.Equal(t, RunOutcome{WinnerID: alice.ID, Price: 100.00, Rounds: 5}, report.A)
+	assert.Equal(t, RunOutcome{WinnerID: bob.ID, Price: 65.00, Rounds: 1}, report.B, "Bob reaches the target first")
+	assert.True(t, report.WinnerDiffers)
+	assert.Equal(t, -35.00, report.PriceDelta)
+	assert.Equal(t, -4, report.RoundsDelta)
+
+	assert.Equal(t, 50.00, alice.CurrentBid, "the bidders passed in are untouched")
+	assert.Equal(t, 60.00, bob.CurrentBid)
+	assert.Nil(t, alice.auction())
+
+	same, err := CompareRuns(AuctionOptions{}, AuctionOptions{Options: []Option{WithSeed(1)}}, bidders)
+	assert.NoError(t, err)
+	assert.False(t, same.WinnerDiffers)
+	assert.Zero(t, same.PriceDelta)
+
+	_, err = CompareRuns(AuctionOptions{}, AuctionOptions{Config: NewAuctionConfig{MinIncrement: -1}}, bidders)
+	assert.ErrorContains(t, err, "rule set B")
+}
@@ -0,0 +1,25 @@
+package dispatchbidder
+
+import "github.com/google/uuid"
+
+// OwnerConcentration maps the OwnerID of every owner with bidders still in
+// the running to the IDs of the paddles they control, in leaderboard order,
+// so one party holding several positions can be spotted. Unowned bidders are
+// left out. topTwo reports whether a single owner holds both the leader and
+// the runner-up, which many auction rules forbid.
+func (a *Auction) OwnerConcentration() (owners map[string][]uuid.UUID, topTwo bool) {
+	a.RLock()
+	defer a.RUnlock()
+
+	ranked := a.rankBidders()
+
+	owners = make(map[string][]uuid.UUID)
+	for _, b := range ranked {
+		if b.OwnerID != "" {
+			owners[b.OwnerID] = append(owners[b.OwnerID], b.ID)
+		}
+	}
+	topTwo = len(ranked) > 1 && ranked[0].sameOwner(ranked[1])
+
+	return owners, topTwo
+}
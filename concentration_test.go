@@ -0,0 +1,86 @@
+package dispatchbidder
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOwnerConcentration tests the report of paddles controlled per owner.
+func TestOwnerConcentration(t *testing.T) {
+	owned := func(name, owner string, current float64) *Bidder {
+		b := createBidder(name, current, 200.00, 0)
+		b.OwnerID = owner
+		return b
+	}
+
+	tests := []struct {
+		name     string
+		bidders  []*Bidder
+		expected map[string][]string
+		topTwo   bool
+	}{
+		{
+			name: "One owner holds the top two",
+			bidders: []*Bidder{
+				owned("Alice", "acme", 90.00),
+				owned("Bob", "zeta", 70.00),
+				owned("Alice 2", "acme", 100.00),
+				createBidder("Carol", 80.00, 200.00, 0),
+			},
+			expected: map[string][]string{"acme": {"Alice 2", "Alice"}, "zeta": {"Bob"}},
+			topTwo:   true,
+		},
+		{
+			name: "Interleaved owners",
+			bidders: []*Bidder{
+				owned("Alice", "acme", 100.00),
+				owned("Bob", "zeta", 90.00),
+				owned("Alice 2", "acme", 80.00),
+			},
+			expected: map[string][]string{"acme": {"Alice", "Alice 2"}, "zeta": {"Bob"}},
+		},
+		{
+			name: "Unowned bidders",
+			bidders: []*Bidder{
+				createBidder("Alice", 100.00, 200.00, 0),
+				createBidder("Bob", 90.00, 200.00, 0),
+			},
+			expected: map[string][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auction, err := NewAuction(NewAuctionConfig{Bidders: tt.bidders})
+			assert.NoError(t, err)
+
+			byName := make(map[string]uuid.UUID)
+			for _, b := range tt.bidders {
+				byName[b.Name] = b.ID
+			}
+			expected := make(map[string][]uuid.UUID)
+			for owner, names := range tt.expected {
+				for _, name := range names {
+					expected[owner] = append(expected[owner], byName[name])
+				}
+			}
+
+			owners, topTwo := auction.OwnerConcentration()
+			assert.Equal(t, expected, owners)
+			assert.Equal(t, tt.topTwo, topTwo)
+		})
+	}
+
+	t.Run("Retracted paddles do not count", func(t *testing.T) {
+		alice, alice2 := owned("Alice", "acme", 100.00), owned("Alice 2", "acme", 90.00)
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, alice2, createBidder("Bob", 80.00, 200.00, 0)}})
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.RetractBidder(alice2.ID))
+		owners, topTwo := auction.OwnerConcentration()
+		assert.Equal(t, map[string][]uuid.UUID{"acme": {alice.ID}}, owners)
+		assert.False(t, topTwo)
+	})
+}
@@ -0,0 +1,186 @@
+package dispatchbidder
+
+import (
+	"fmt"
+	"time"
+)
+
+// countdown tracks the goroutine closing the auction at its end time.
+type countdown struct {
+	stop chan struct{}
+	done chan struct{}
+	wake chan struct{} // Signalled when the end time changes or on pause and resume.
+}
+
+// EndTime returns the time the auction is scheduled to close, or the zero time
+// when no countdown was started.
+func (a *Auction) EndTime() time.Time {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.endTime
+}
+
+// StartCountdown schedules the auction to close at end, as measured by the
+// auction clock. The countdown runs in its own goroutine until the auction
+// closes or StopCountdown is called. It picks up extensions of the end time
+// live and does not count down while the auction is paused, though it still
+// closes the auction at the hard end time, if any. It returns
+// ErrPastHardEndTime if end is after the hard end time set with
+// WithHardEndTime.
+func (a *Auction) StartCountdown(end time.Time) error {
+	a.Lock()
+	defer a.Unlock()
+
+	if err := a.checkOpen(); err != nil {
+		return err
+	}
+	if a.countdown != nil {
+		return fmt.Errorf("%w: auction ends at %s", ErrCountdownRunning, a.endTime.Format(time.RFC3339))
+	}
+	if !a.hardEndTime.IsZero() && end.After(a.hardEndTime) {
+		return fmt.Errorf("%w: %s is after %s", ErrPastHardEndTime, end.Format(time.RFC3339), a.hardEndTime.Format(time.RFC3339))
+	}
+
+	cd := &countdown{stop: make(chan struct{}), done: make(chan struct{}), wake: make(chan struct{}, 1)}
+	a.countdown = cd
+	a.endTime = end
+
+	go a.runCountdown(cd)
+
+	return nil
+}
+
+// StopCountdown stops a running countdown and waits for its goroutine to
+// exit. The auction stays in its current state.
+func (a *Auction) StopCountdown() {
+	a.Lock()
+	cd := a.countdown
+	a.countdown = nil
+	a.Unlock()
+
+	if cd != nil {
+		close(cd.stop)
+		<-cd.done
+	}
+}
+
+// runCountdown sleeps until the end time and closes the auction. The sleep
+// is recomputed whenever the countdown is woken, and lasts until the hard
+// end time, if any, while the auction is paused.
+func (a *Auction) runCountdown(cd *countdown) {
+	defer close(cd.done)
+
+	for {
+		a.RLock()
+		deadline := a.deadline()
+		wait := deadline.Sub(a.now())
+		a.RUnlock()
+
+		var timer *time.Timer
+		var fire <-chan time.Time
+		if !deadline.IsZero() {
+			timer = time.NewTimer(wait)
+			fire = timer.C
+		}
+
+		select {
+		case <-cd.stop:
+			stopTimer(timer)
+			return
+		case <-cd.wake:
+			stopTimer(timer)
+		case <-fire:
+			if a.closeIfDue(cd) {
+				return
+			}
+		}
+	}
+}
+
+// stopTimer stops the timer, if any.
+func stopTimer(timer *time.Timer) {
+	if timer != nil {
+		timer.Stop()
+	}
+}
+
+// wakeCountdown makes a running countdown recompute its sleep. It never
+// blocks. The caller must hold the lock.
+func (a *Auction) wakeCountdown() {
+	if a.countdown == nil {
+		return
+	}
+	select {
+	case a.countdown.wake <- struct{}{}:
+	default:
+	}
+}
+
+// closeIfDue closes the auction if its end time has been reached and reports
+// whether the countdown is over.
+func (a *Auction) closeIfDue(cd *countdown) bool {
+	a.Lock()
+	defer a.Unlock()
+
+	if a.countdown != cd {
+		return true // Stopped concurrently.
+	}
+
+	now := a.now()
+	if deadline := a.deadline(); deadline.IsZero() || now.Before(deadline) {
+		return false
+	}
+
+	if a.state == StateOpen {
+		a.expire(now)
+	}
+	a.releaseCountdown()
+
+	return true
+}
+
+// closeExpired ends the auction at now if it is open and its end time, or
+// its hard end time while paused, has been reached by now, reporting whether
+// it did.
+func (a *Auction) closeExpired(now time.Time) bool {
+	a.Lock()
+	defer a.Unlock()
+
+	if deadline := a.deadline(); a.state != StateOpen || deadline.IsZero() || now.Before(deadline) {
+		return false
+	}
+	a.expire(now)
+
+	return true
+}
+
+// expire ends the bidding of an open auction whose end time has come,
+// resolving the proxies first with WithCloseProxyResolution. The caller must
+// hold the lock.
+func (a *Auction) expire(now time.Time) {
+	if a.closeProxies && !a.Mode.sealed() {
+		// Ceilings below the reserve leave the bids as they are.
+		_, _ = a.resolveProxies()
+	}
+	a.finish(now)
+}
+
+// deadline returns when the auction is due to end: its end time, or, while
+// it is paused, its hard end time, which a pause does not push back. It is
+// zero without an end time. The caller must hold at least a read lock.
+func (a *Auction) deadline() time.Time {
+	if a.paused && !a.endTime.IsZero() {
+		return a.hardEndTime
+	}
+	return a.endTime
+}
+
+// releaseCountdown signals a running countdown goroutine to exit without
+// waiting for it. The caller must hold the lock.
+func (a *Auction) releaseCountdown() {
+	if a.countdown != nil {
+		close(a.countdown.stop)
+		a.countdown = nil
+	}
+}
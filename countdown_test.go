@@ -0,0 +1,40 @@
+package dispatchbidder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCountdown tests that a countdown closes the auction at its end time.
+func TestCountdown(t *testing.T) {
+	t.Run("Closes at end time", func(t *testing.T) {
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{
+			createBidder("Alice", 50.00, 100.00, 5.00),
+			createBidder("Bob", 60.00, 100.00, 5.00),
+		}})
+		assert.NoError(t, err)
+
+		end := time.Now().Add(20 * time.Millisecond)
+		assert.NoError(t, auction.StartCountdown(end))
+		assert.Equal(t, end, auction.EndTime())
+		assert.ErrorIs(t, auction.StartCountdown(end), ErrCountdownRunning)
+
+		assert.Eventually(t, func() bool { return auction.State() == StateClosed }, time.Second, time.Millisecond)
+	})
+
+	t.Run("Stop leaves the auction open", func(t *testing.T) {
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{
+			createBidder("Alice", 50.00, 100.00, 5.00),
+			createBidder("Bob", 60.00, 100.00, 5.00),
+		}})
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.StartCountdown(time.Now().Add(20*time.Millisecond)))
+		auction.StopCountdown()
+
+		time.Sleep(40 * time.Millisecond)
+		assert.Equal(t, StateOpen, auction.State())
+	})
+}
@@ -0,0 +1,203 @@
+package dispatchbidder
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// csvHeader lists the columns written by ExportCSV and read by ImportBiddersCSV.
+var csvHeader = []string{
+	"id",
+	"name",
+	"starting_bid",
+	"max_bid",
+	"current_bid",
+	"auto_increment",
+	"increment_decay",
+	"last_bid_time",
+	"owner_id",
+	"metadata",
+}
+
+// csvRequired lists the columns ImportBiddersCSV cannot do without. The id,
+// current_bid, increment_decay, last_bid_time, owner_id and metadata columns
+// may be omitted or left blank.
+var csvRequired = []string{"name", "starting_bid", "max_bid", "auto_increment"}
+
+// ExportCSV writes the auction bidders as CSV, one row per bidder. Amounts
+// are written with the decimal places of the currency each bidder bids in.
+func (a *Auction) ExportCSV(w io.Writer) error {
+	a.RLock()
+	defer a.RUnlock()
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+
+	for _, b := range a.Bidders {
+		metadata, err := formatMetadata(b.Metadata)
+		if err != nil {
+			return fmt.Errorf("writing bidder ID %s: %w", b.ID, err)
+		}
+		places := a.currencyPlaces(a.bidCurrency(b))
+		record := []string{
+			b.ID.String(),
+			b.Name,
+			formatAmount(b.StartingBid, places),
+			formatAmount(b.MaxBid, places),
+			formatAmount(b.CurrentBid, places),
+			formatAmount(b.AutoIncrement, places),
+			strconv.FormatFloat(b.IncrementDecay, 'f', -1, 64),
+			b.LastBidTime.Format(time.RFC3339Nano),
+			b.OwnerID,
+			metadata,
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("writing bidder ID %s: %w", b.ID, err)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ImportBiddersCSV reads bidders in the format written by ExportCSV. Blank IDs
+// are generated, with the generator of WithIDGenerator when given, and a
+// blank current bid defaults to the starting bid. Every row is validated and
+// errors report the offending line number.
+func ImportBiddersCSV(r io.Reader, opts ...Option) ([]*Bidder, error) {
+	// Reuse the auction options for the ID generator.
+	var cfg Auction
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	cr := csv.NewReader(r)
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("missing CSV header")
+		}
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[name] = i
+	}
+	for _, name := range csvRequired {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing required column %q", name)
+		}
+	}
+
+	var bidders []*Bidder
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading CSV: %w", err)
+		}
+
+		line, _ := cr.FieldPos(0)
+		bidder, err := parseBidderRecord(record, columns, cfg.newID)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if err := validateBidder(bidder); err != nil {
+			return nil, fmt.Errorf("line %d: invalid bidder data: %w", line, err)
+		}
+		bidders = append(bidders, bidder)
+	}
+
+	return bidders, nil
+}
+
+// parseBidderRecord converts a CSV record into a bidder.
+func parseBidderRecord(record []string, columns map[string]int, newID func() uuid.UUID) (*Bidder, error) {
+	field := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+	amount := func(name string) (float64, error) {
+		v, err := strconv.ParseFloat(field(name), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s %q", name, field(name))
+		}
+		return v, nil
+	}
+
+	bidder := Bidder{Name: field("name"), OwnerID: field("owner_id")}
+
+	var err error
+	if bidder.StartingBid, err = amount("starting_bid"); err != nil {
+		return nil, err
+	}
+	if bidder.MaxBid, err = amount("max_bid"); err != nil {
+		return nil, err
+	}
+	if bidder.AutoIncrement, err = amount("auto_increment"); err != nil {
+		return nil, err
+	}
+
+	bidder.CurrentBid = bidder.StartingBid
+	if field("current_bid") != "" {
+		if bidder.CurrentBid, err = amount("current_bid"); err != nil {
+			return nil, err
+		}
+	}
+	if field("increment_decay") != "" {
+		if bidder.IncrementDecay, err = amount("increment_decay"); err != nil {
+			return nil, err
+		}
+	}
+
+	if id := field("id"); id != "" {
+		if bidder.ID, err = uuid.Parse(id); err != nil {
+			return nil, fmt.Errorf("invalid id %q: %w", id, err)
+		}
+	} else {
+		bidder.ID = newID()
+	}
+	if m := field("metadata"); m != "" {
+		if err := json.Unmarshal([]byte(m), &bidder.Metadata); err != nil {
+			return nil, fmt.Errorf("invalid metadata %q: %w", m, err)
+		}
+	}
+	if ts := field("last_bid_time"); ts != "" {
+		if bidder.LastBidTime, err = time.Parse(time.RFC3339Nano, ts); err != nil {
+			return nil, fmt.Errorf("invalid last_bid_time %q: %w", ts, err)
+		}
+	}
+
+	return &bidder, nil
+}
+
+// formatAmount formats a monetary amount with the given number of decimal
+// places.
+func formatAmount(v float64, places int) string {
+	return strconv.FormatFloat(v, 'f', places, 64)
+}
+
+// formatMetadata encodes bidder metadata as a JSON object, or blank when
+// there is none.
+func formatMetadata(m map[string]string) (string, error) {
+	if len(m) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(m)
+	return string(data), err
+}
@@ -0,0 +1,109 @@
+package dispatchbidder
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestImportBiddersCSV tests loading bidders from CSV.
+func TestImportBiddersCSV(t *testing.T) {
+	t.Run("Well-formed CSV", func(t *testing.T) {
+		id := uuid.New()
+		data := "id,name,starting_bid,max_bid,auto_increment\n" +
+			id.String() + ",Alice,50.00,80.00,3.00\n" +
+			",Bob,60.00,82.00,2.00\n"
+
+		bidders, err := ImportBiddersCSV(strings.NewReader(data))
+		assert.NoError(t, err)
+		assert.Len(t, bidders, 2)
+
+		assert.Equal(t, id, bidders[0].ID)
+		assert.Equal(t, "Alice", bidders[0].Name)
+		assert.Equal(t, 50.00, bidders[0].StartingBid)
+		assert.Equal(t, 80.00, bidders[0].MaxBid)
+		assert.Equal(t, 50.00, bidders[0].CurrentBid)
+		assert.Equal(t, 3.00, bidders[0].AutoIncrement)
+
+		assert.NotEqual(t, uuid.Nil, bidders[1].ID, "blank IDs are generated")
+
+		_, err = NewAuction(NewAuctionConfig{Bidders: bidders})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Bad numeric cell", func(t *testing.T) {
+		data := "name,starting_bid,max_bid,auto_increment\n" +
+			"Alice,50.00,80.00,3.00\n" +
+			"Bob,sixty,82.00,2.00\n"
+
+		_, err := ImportBiddersCSV(strings.NewReader(data))
+		assert.ErrorContains(t, err, "line 3")
+		assert.ErrorContains(t, err, "starting_bid")
+	})
+
+	t.Run("Invalid bidder data", func(t *testing.T) {
+		data := "name,starting_bid,max_bid,auto_increment\n" +
+			"Alice,50.00,40.00,3.00\n"
+
+		_, err := ImportBiddersCSV(strings.NewReader(data))
+		assert.ErrorContains(t, err, "line 2")
+	})
+
+	t.Run("Missing column", func(t *testing.T) {
+		data := "name,starting_bid,auto_increment\n" +
+			"Alice,50.00,3.00\n"
+
+		_, err := ImportBiddersCSV(strings.NewReader(data))
+		assert.ErrorContains(t, err, `missing required column "max_bid"`)
+	})
+
+	t.Run("Round trip with ExportCSV", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 80.00, 3.00)
+		bob := createBidder("Bob", 60.00, 82.00, 2.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+		assert.NoError(t, auction.PlaceBid(alice, 65.00))
+
+		var buf bytes.Buffer
+		assert.NoError(t, auction.ExportCSV(&buf))
+
+		bidders, err := ImportBiddersCSV(&buf)
+		assert.NoError(t, err)
+		assert.Len(t, bidders, 2)
+
+		byID := make(map[uuid.UUID]*Bidder)
+		for _, b := range bidders {
+			byID[b.ID] = b
+		}
+		if assert.Contains(t, byID, alice.ID) {
+			assert.Equal(t, 65.00, byID[alice.ID].CurrentBid)
+			assert.True(t, alice.LastBidTime.Equal(byID[alice.ID].LastBidTime))
+		}
+		if assert.Contains(t, byID, bob.ID) {
+			assert.Equal(t, 62.00, byID[bob.ID].CurrentBid)
+		}
+	})
+
+	t.Run("ExportCSV keeps the currency precision", func(t *testing.T) {
+		alice := createBidder("Alice", 50.125, 80.00, 0.005)
+		alice.Currency = "KWD"
+		bob := createBidder("Bob", 6000.00, 9000.00, 100.00)
+		bob.Currency = "JPY"
+		carol := createBidder("Carol", 60.25, 82.00, 2.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob, carol}},
+			WithExchangeRates("USD", FixedRates{"KWD": 3.25, "JPY": 0.0067}))
+		assert.NoError(t, err)
+
+		var buf bytes.Buffer
+		assert.NoError(t, auction.ExportCSV(&buf))
+		out := buf.String()
+		assert.Contains(t, out, ",Alice,50.125,80.000,50.125,0.005,")
+		assert.Contains(t, out, ",Bob,6000,9000,6000,100,")
+		assert.Contains(t, out, ",Carol,60.25,82.00,60.25,2.00,")
+	})
+}
@@ -0,0 +1,109 @@
+package dispatchbidder
+
+import "fmt"
+
+// ExchangeRates converts amounts in bidder currencies to the base currency
+// of an auction.
+type ExchangeRates interface {
+	// Rate returns the value of one unit of the currency in the base
+	// currency.
+	Rate(currency string) (float64, error)
+}
+
+// FixedRates is an ExchangeRates backed by a fixed table of rates to the base
+// currency, keyed by currency.
+type FixedRates map[string]float64
+
+// Rate returns the rate of the currency from the table.
+func (r FixedRates) Rate(currency string) (float64, error) {
+	rate, ok := r[currency]
+	if !ok || rate <= 0 {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownCurrency, currency)
+	}
+	return rate, nil
+}
+
+// WithExchangeRates lets bidders bid in their own Currency: each manual bid
+// is converted to the base currency at the rate quoted by rates when it is
+// placed, and DetermineWinner, Leader and rankings compare these base
+// amounts. Auto-increments reuse the rate of the bidder's last manual bid, or
+// the one quoted when they joined. Bidders without a Currency bid in the base
+// currency. NewAuction, AddBidder and MergeBidders fail, and PlaceBid rejects
+// the bid, when a rate cannot be quoted.
+//
+// Every other amount, such as MaxBid, AuctionMaxBid, ReservePrice, the
+// increments and the grid, applies to amounts as bid, and ResolveProxies
+// compares ceilings as bid.
+func WithExchangeRates(base string, rates ExchangeRates) Option {
+	return func(a *Auction) {
+		a.baseCurrency = base
+		a.rates = rates
+	}
+}
+
+// BaseCurrency returns the currency bids are compared in, empty unless set
+// with WithExchangeRates.
+func (a *Auction) BaseCurrency() string {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.baseCurrency
+}
+
+// exchangeRate quotes the rate converting the bidder's amounts to the base
+// currency, or zero for bidders bidding in the base currency. The caller must
+// hold at least a read lock.
+func (a *Auction) exchangeRate(b *Bidder) (float64, error) {
+	if a.rates == nil || b.Currency == "" || b.Currency == a.baseCurrency {
+		return 0, nil
+	}
+
+	rate, err := a.rates.Rate(b.Currency)
+	if err != nil {
+		return 0, fmt.Errorf("bidder ID %s: %w", b.ID, err)
+	}
+	if rate <= 0 {
+		return 0, fmt.Errorf("%w: bidder ID %s: non-positive rate %v for %s", ErrUnknownCurrency, b.ID, rate, b.Currency)
+	}
+	return rate, nil
+}
+
+// quoteRates sets the exchange rate of each of the bidders, changing none if
+// any rate cannot be quoted. The caller must hold the lock.
+func (a *Auction) quoteRates(bidders []*Bidder) error {
+	rates := make([]float64, len(bidders))
+	for i, bidder := range bidders {
+		rate, err := a.exchangeRate(bidder)
+		if err != nil {
+			return err
+		}
+		rates[i] = rate
+	}
+	for i, bidder := range bidders {
+		bidder.rate = rates[i]
+	}
+	return nil
+}
+
+// baseBid returns the bidder's CurrentBid converted to the base currency.
+func (b *Bidder) baseBid() float64 {
+	return b.toBase(b.CurrentBid)
+}
+
+// toBase converts an amount in the bidder's currency to the base currency at
+// the bidder's current rate.
+func (b *Bidder) toBase(amount float64) float64 {
+	if b.rate == 0 {
+		return amount
+	}
+	return amount * b.rate
+}
+
+// fromBase converts an amount in the base currency to the bidder's currency
+// at the bidder's current rate.
+func (b *Bidder) fromBase(amount float64) float64 {
+	if b.rate == 0 {
+		return amount
+	}
+	return amount / b.rate
+}
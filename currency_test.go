@@ -0,0 +1,79 @@
+package dispatchbidder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestExchangeRates tests comparing bids placed in different currencies.
+func TestExchangeRates(t *testing.T) {
+	rates := FixedRates{"EUR": 1.10, "GBP": 1.25}
+
+	t.Run("Winner is decided on base amounts", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 200.00, 0)
+		bob := createBidder("Bob", 50.00, 200.00, 0)
+		bob.Currency = "EUR"
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, WithExchangeRates("USD", rates))
+		assert.NoError(t, err)
+		assert.Equal(t, "USD", auction.BaseCurrency())
+
+		assert.NoError(t, auction.PlaceBid(alice, 100.00))
+		assert.NoError(t, auction.PlaceBid(bob, 95.00))
+		assert.Equal(t, bob, auction.DetermineWinner(), "EUR 95.00 is worth USD 104.50")
+		assert.Equal(t, bob, auction.Leader())
+
+		history := auction.History()
+		assert.Equal(t, 95.00, history[1].Amount)
+		assert.InDelta(t, 104.50, history[1].BaseAmount, 1e-9)
+		assert.Equal(t, 100.00, history[0].BaseAmount)
+
+		view, ok := auction.Snapshot().Bidder(bob.ID)
+		assert.True(t, ok)
+		assert.Equal(t, "EUR", view.Currency)
+		assert.Equal(t, 95.00, view.CurrentBid)
+		assert.InDelta(t, 104.50, view.BaseBid, 1e-9)
+
+		assert.NoError(t, auction.PlaceBid(alice, 105.00))
+		assert.Equal(t, alice, auction.DetermineWinner())
+	})
+
+	t.Run("Bids convert at the rate quoted when placed", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 200.00, 0)
+		bob := createBidder("Bob", 50.00, 200.00, 0)
+		bob.Currency = "GBP"
+
+		live := FixedRates{"GBP": 1.25}
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, WithExchangeRates("USD", live))
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.PlaceBid(bob, 80.00))
+		live["GBP"] = 1.50
+		assert.NoError(t, auction.PlaceBid(alice, 110.00))
+		assert.Equal(t, alice, auction.DetermineWinner(), "GBP 80.00 was worth USD 100.00 when bid")
+
+		assert.NoError(t, auction.PlaceBid(bob, 85.00))
+		assert.Equal(t, bob, auction.DetermineWinner(), "GBP 85.00 is worth USD 127.50")
+
+		assert.NoError(t, auction.UndoLastBid(bob.ID))
+		assert.Equal(t, alice, auction.Leader(), "undo restores the earlier rate")
+	})
+
+	t.Run("Unknown currency", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 200.00, 0)
+		bob := createBidder("Bob", 50.00, 200.00, 0)
+		bob.Currency = "JPY"
+
+		_, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, WithExchangeRates("USD", rates))
+		assert.ErrorIs(t, err, ErrUnknownCurrency)
+
+		bob.Currency = "USD"
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, WithExchangeRates("USD", rates))
+		assert.NoError(t, err, "the base currency needs no rate")
+
+		carol := createBidder("Carol", 50.00, 200.00, 0)
+		carol.Currency = "CHF"
+		assert.ErrorIs(t, auction.AddBidder(carol), ErrUnknownCurrency)
+	})
+}
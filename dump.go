@@ -0,0 +1,94 @@
+package dispatchbidder
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Dump writes a text dump of the auction for bug reports: its configuration
+// and lifecycle state, every bidder's state, a summary of the history and the
+// current winner. With redactNames, bidder names and owner IDs are replaced
+// by short hashes, stable within and across dumps, so reports can be shared
+// without personal data while bidders stay distinguishable.
+func (a *Auction) Dump(w io.Writer, redactNames bool) error {
+	a.RLock()
+	defer a.RUnlock()
+
+	name := func(s string) string {
+		if !redactNames || s == "" {
+			return s
+		}
+		sum := sha256.Sum256([]byte(s))
+		return "#" + hex.EncodeToString(sum[:4])
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Auction:          %s\n", a.ID)
+	fmt.Fprintf(&sb, "State:            %s\n", a.state)
+	fmt.Fprintf(&sb, "Mode:             %s\n", a.Mode)
+	fmt.Fprintf(&sb, "Version:          %d\n", a.version)
+	fmt.Fprintf(&sb, "Seed:             %d\n", a.seed)
+	fmt.Fprintf(&sb, "Auction max bid:  $%.2f\n", a.AuctionMaxBid)
+	fmt.Fprintf(&sb, "Target price:     $%.2f\n", a.TargetPrice)
+	fmt.Fprintf(&sb, "Reserve price:    $%.2f\n", a.ReservePrice)
+	fmt.Fprintf(&sb, "Min increment:    $%.2f\n", a.MinIncrement)
+	fmt.Fprintf(&sb, "Bid grid step:    $%.2f\n", a.BidGridStep)
+	fmt.Fprintf(&sb, "Increment table:  %d bands\n", len(a.IncrementTable))
+	fmt.Fprintf(&sb, "Min duration:     %s\n", a.MinDuration)
+	fmt.Fprintf(&sb, "Units:            %d\n", max(a.Units, 1))
+	fmt.Fprintf(&sb, "Min participants: %d\n", a.MinParticipants)
+	fmt.Fprintf(&sb, "Opened at:        %s\n", formatDumpTime(a.openedAt))
+	fmt.Fprintf(&sb, "Closed at:        %s\n", formatDumpTime(a.closedAt))
+	fmt.Fprintf(&sb, "End time:         %s\n", formatDumpTime(a.endTime))
+	fmt.Fprintf(&sb, "Paused:           %t\n", a.paused)
+	if a.cancelReason != "" {
+		fmt.Fprintf(&sb, "Cancel reason:    %s\n", a.cancelReason)
+	}
+	if a.voidReason != "" {
+		fmt.Fprintf(&sb, "Void reason:      %s\n", a.voidReason)
+	}
+
+	leader := a.determineWinner()
+
+	fmt.Fprintf(&sb, "\nBidders (%d):\n", len(a.Bidders))
+	for _, b := range a.Bidders {
+		fmt.Fprintf(&sb, "  %s %q owner=%q status=%s current=$%.2f start=$%.2f max=$%.2f increment=$%.2f seq=%d last_bid=%s\n",
+			b.ID, name(b.Name), name(b.OwnerID), a.status(b, leader), b.CurrentBid, b.StartingBid, b.MaxBid, b.AutoIncrement, b.seq, formatDumpTime(b.LastBidTime))
+	}
+
+	manual := 0
+	var first, last time.Time
+	for i, event := range a.history {
+		if !event.Auto {
+			manual++
+		}
+		if i == 0 {
+			first = event.Time
+		}
+		last = event.Time
+	}
+	fmt.Fprintf(&sb, "\nHistory: %d bids (%d manual, %d auto), first %s, last %s\n",
+		len(a.history), manual, len(a.history)-manual, formatDumpTime(first), formatDumpTime(last))
+
+	if leader != nil {
+		fmt.Fprintf(&sb, "Winner:  %s %q at $%.2f\n", leader.ID, name(leader.Name), leader.CurrentBid)
+	} else {
+		fmt.Fprintf(&sb, "Winner:  none\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// formatDumpTime formats a time for Dump, with "-" for the zero time.
+func formatDumpTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(time.RFC3339Nano)
+}
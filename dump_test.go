@@ -0,0 +1,70 @@
+package dispatchbidder
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// failingWriter is an io.Writer that always fails.
+type failingWriter struct{}
+
+// Write fails.
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+// TestDump tests the diagnostic dump of an auction.
+func TestDump(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 80.00, 3.00)
+	alice.OwnerID = "alice@example.com"
+	bob := createBidder("Bob", 60.00, 82.00, 2.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, MinIncrement: 1.00}, WithSeed(7))
+	assert.NoError(t, err)
+	assert.NoError(t, auction.PlaceBid(alice, 70.00))
+	assert.NoError(t, auction.FreezeBidder(bob.ID))
+
+	t.Run("Includes key fields", func(t *testing.T) {
+		var sb strings.Builder
+		assert.NoError(t, auction.Dump(&sb, false))
+		dump := sb.String()
+
+		for _, want := range []string{
+			"Auction:          " + auction.ID.String(),
+			"State:            Open",
+			"Mode:             Standard",
+			"Seed:             7",
+			"Min increment:    $1.00",
+			"Bidders (2):",
+			alice.ID.String() + ` "Alice" owner="alice@example.com" status=Leading current=$70.00`,
+			`"Bob"`,
+			"status=Frozen",
+			"History: 2 bids (1 manual, 1 auto)",
+			"Winner:  " + alice.ID.String() + ` "Alice" at $70.00`,
+		} {
+			assert.Contains(t, dump, want)
+		}
+	})
+
+	t.Run("Redaction hides names", func(t *testing.T) {
+		var sb strings.Builder
+		assert.NoError(t, auction.Dump(&sb, true))
+		dump := sb.String()
+
+		assert.NotContains(t, dump, "Alice")
+		assert.NotContains(t, dump, "Bob")
+		assert.NotContains(t, dump, "alice@example.com")
+		assert.Contains(t, dump, alice.ID.String(), "IDs are kept")
+
+		var again strings.Builder
+		assert.NoError(t, auction.Dump(&again, true))
+		assert.Equal(t, dump, again.String(), "hashes are stable")
+	})
+
+	t.Run("Write errors are returned", func(t *testing.T) {
+		assert.ErrorContains(t, auction.Dump(failingWriter{}, false), "write failed")
+	})
+}
@@ -0,0 +1,170 @@
+package dispatchbidder
+
+import "errors"
+
+// Sentinel errors returned by auction operations. Callers can match them
+// with errors.Is; the returned errors wrap them with additional detail.
+var (
+	// ErrBelowStartingBid is returned when a bid is below the bidder's starting bid.
+	ErrBelowStartingBid = errors.New("bid is below starting bid")
+
+	// ErrAboveMaxBid is returned when a bid is above the bidder's max bid.
+	ErrAboveMaxBid = errors.New("bid is above max bid")
+
+	// ErrNotAboveCurrentBid is returned when a bid does not raise the bidder's
+	// current bid.
+	ErrNotAboveCurrentBid = errors.New("bid is not above current bid")
+
+	// ErrIncrementTooSmall is returned when a bid raises the bidder's current
+	// bid by less than the minimum increment.
+	ErrIncrementTooSmall = errors.New("bid increment too small")
+
+	// ErrExceedsAuctionCap is returned when a bid is above the auction-wide cap.
+	ErrExceedsAuctionCap = errors.New("bid exceeds auction cap")
+
+	// ErrAuctionClosed is returned when an operation requires an open auction.
+	ErrAuctionClosed = errors.New("auction is closed")
+
+	// ErrAuctionNotOpen is returned when bidding on an auction not opened yet.
+	ErrAuctionNotOpen = errors.New("auction is not open")
+
+	// ErrInvalidTransition is returned when a lifecycle change is not allowed
+	// from the current state.
+	ErrInvalidTransition = errors.New("invalid state transition")
+
+	// ErrAuctionCancelled is returned when an operation targets a cancelled auction.
+	ErrAuctionCancelled = errors.New("auction is cancelled")
+
+	// ErrStaleVersion is returned when a conditional bid was based on an
+	// outdated auction version.
+	ErrStaleVersion = errors.New("stale auction version")
+
+	// ErrAuctionNotClosed is returned when an operation requires a closed auction.
+	ErrAuctionNotClosed = errors.New("auction is not closed")
+
+	// ErrOffGrid is returned when a bid does not land on the increment grid.
+	ErrOffGrid = errors.New("bid is off the increment grid")
+
+	// ErrBidderNotFound is returned when a bidder ID is not part of the auction.
+	ErrBidderNotFound = errors.New("bidder not found")
+
+	// ErrDuplicateBidder is returned when a bidder ID is already in the auction.
+	ErrDuplicateBidder = errors.New("duplicate bidder ID")
+
+	// ErrAuctionPaused is returned when bidding on a paused auction.
+	ErrAuctionPaused = errors.New("auction is paused")
+
+	// ErrNoEndTime is returned when extending an auction without a scheduled
+	// end time.
+	ErrNoEndTime = errors.New("auction has no end time")
+
+	// ErrReserveNotMet is returned when no bidder can reach the reserve price.
+	ErrReserveNotMet = errors.New("reserve price not met")
+
+	// ErrRateLimited is returned when a bidder places bids faster than the
+	// configured rate limit allows.
+	ErrRateLimited = errors.New("bid rate limit exceeded")
+
+	// ErrNoEligibleWinner is returned when no bidder is left to award the
+	// auction to.
+	ErrNoEligibleWinner = errors.New("no eligible winner")
+
+	// ErrItemNotFound is returned when an item ID is not part of a bundle auction.
+	ErrItemNotFound = errors.New("item not found")
+
+	// ErrBidderDisqualified is returned when a disqualified bidder tries to
+	// bid or is disqualified again.
+	ErrBidderDisqualified = errors.New("bidder is disqualified")
+
+	// ErrBidderFrozen is returned when a frozen bidder tries to bid.
+	ErrBidderFrozen = errors.New("bidder is frozen")
+
+	// ErrBidderRetracted is returned when a retracted bidder tries to bid.
+	ErrBidderRetracted = errors.New("bidder has retracted")
+
+	// ErrBidderWithdrawn is returned when a bidder withdrawn on exceeding
+	// their MaxBid tries to bid.
+	ErrBidderWithdrawn = errors.New("bidder has withdrawn")
+
+	// ErrNothingToUndo is returned when a bidder has no manual bid left to undo.
+	ErrNothingToUndo = errors.New("nothing to undo")
+
+	// ErrCountdownRunning is returned when starting a second countdown.
+	ErrCountdownRunning = errors.New("countdown already running")
+
+	// ErrAuctionNotFound is returned when an auction ID is not registered.
+	ErrAuctionNotFound = errors.New("auction not found")
+
+	// ErrDuplicateAuction is returned when registering an auction ID twice.
+	ErrDuplicateAuction = errors.New("duplicate auction")
+
+	// ErrRegistryClosed is returned when adding auctions to a shut down registry.
+	ErrRegistryClosed = errors.New("registry is shut down")
+
+	// ErrTooManyRounds is returned when a bidding loop fails to settle within
+	// its safety cap.
+	ErrTooManyRounds = errors.New("too many bidding rounds")
+
+	// ErrAuctionVoided is returned when bidding on an auction voided for
+	// lack of participation.
+	ErrAuctionVoided = errors.New("auction is voided")
+
+	// ErrSealedAuction is returned when bidding openly on a sealed-bid
+	// auction.
+	ErrSealedAuction = errors.New("auction takes sealed bids")
+
+	// ErrNotSealedAuction is returned when submitting a sealed bid to an
+	// auction that is not sealed-bid.
+	ErrNotSealedAuction = errors.New("auction does not take sealed bids")
+
+	// ErrSealedBidSubmitted is returned when a bidder submits a second
+	// sealed bid.
+	ErrSealedBidSubmitted = errors.New("sealed bid already submitted")
+
+	// ErrUnknownCurrency is returned when no exchange rate can be quoted for
+	// a bidder's currency.
+	ErrUnknownCurrency = errors.New("unknown currency")
+
+	// ErrInvalidPrecision is returned when a bid has more decimal places than
+	// its currency allows.
+	ErrInvalidPrecision = errors.New("bid has too many decimal places")
+
+	// ErrHistoryConflict is returned when the histories of two replicas of an
+	// auction cannot be merged.
+	ErrHistoryConflict = errors.New("conflicting histories")
+
+	// ErrCheckpointNotFound is returned when a checkpoint ID is unknown.
+	ErrCheckpointNotFound = errors.New("checkpoint not found")
+
+	// ErrUnbalanced is returned when the money totals of an auction do not
+	// reconcile.
+	ErrUnbalanced = errors.New("auction totals do not reconcile")
+
+	// ErrPastHardEndTime is returned when scheduling an end time after the
+	// auction's hard end time.
+	ErrPastHardEndTime = errors.New("end time is past the hard end time")
+
+	// ErrBidNotValidated is returned when a validator set with WithValidators
+	// rejects a bid. The validator's error is wrapped too.
+	ErrBidNotValidated = errors.New("bid rejected by validator")
+
+	// ErrMoneyOverflow is returned for amounts that are not finite or beyond
+	// MaxAmount.
+	ErrMoneyOverflow = errors.New("amount out of range")
+
+	// ErrNoCommitment is returned when releasing a commitment the bidder does
+	// not hold, or one held by the winner.
+	ErrNoCommitment = errors.New("no releasable commitment")
+
+	// ErrBidderNotInAuction is returned when bidding with a bidder that is not
+	// one of the auction's own.
+	ErrBidderNotInAuction = errors.New("bidder is not in the auction")
+
+	// ErrAutoPersisted is returned when a registry store is to persist an
+	// auction that already has auto-persistence.
+	ErrAutoPersisted = errors.New("auction already has auto-persistence")
+
+	// ErrBidCapReached is returned when bidding on an auction whose history
+	// already holds MaxTotalBids bids.
+	ErrBidCapReached = errors.New("bid cap reached")
+)
@@ -0,0 +1,54 @@
+package dispatchbidder
+
+import "fmt"
+
+// Explain narrates how the auction would settle if every bidder kept raising
+// as in RunToCompletion: it runs the bidding rounds on a clone and returns one
+// line per bid, auto-raise and change of leader, followed by the outcome and
+// the number of rounds played. The live auction is not touched.
+func (a *Auction) Explain() []string {
+	sim := a.simulation()
+
+	var lines []string
+	leader := sim.Leader()
+	seen := len(sim.history)
+
+	rounds := 0
+	for rounds < maxRounds && sim.nextRound(nil) {
+		rounds++
+		for _, event := range sim.history[seen:] {
+			lines = append(lines, fmt.Sprintf("Round %d: %s", rounds, sim.describeEvent(event)))
+		}
+		seen = len(sim.history)
+
+		if current := sim.Leader(); current != nil && current != leader {
+			leader = current
+			lines = append(lines, fmt.Sprintf("Round %d: %s takes the lead at $%.2f", rounds, leader.Name, leader.CurrentBid))
+		}
+	}
+
+	switch winner := sim.DetermineWinner(); {
+	case rounds == maxRounds:
+		lines = append(lines, fmt.Sprintf("No settlement after %d rounds", rounds))
+	case winner == nil:
+		lines = append(lines, fmt.Sprintf("No winner after %d rounds", rounds))
+	default:
+		lines = append(lines, fmt.Sprintf("%s wins at $%.2f after %d rounds", winner.Name, winner.CurrentBid, rounds))
+	}
+
+	return lines
+}
+
+// describeEvent returns a human-readable description of a bid. The caller
+// must hold at least a read lock.
+func (a *Auction) describeEvent(event BidEvent) string {
+	name := event.BidderID.String()
+	if bidder, ok := a.bidderByID(event.BidderID); ok {
+		name = bidder.Name
+	}
+
+	if event.Auto {
+		return fmt.Sprintf("%s is auto-raised to $%.2f", name, event.Amount)
+	}
+	return fmt.Sprintf("%s bids $%.2f", name, event.Amount)
+}
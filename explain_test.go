@@ -0,0 +1,43 @@
+package dispatchbidder
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestExplain tests narrating a simulated run to completion.
+func TestExplain(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 80.00, 3.00)
+	bob := createBidder("Bob", 60.00, 82.00, 2.00)
+	carol := createBidder("Carol", 55.00, 85.00, 5.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob, carol}})
+	assert.NoError(t, err)
+	before := auction.Snapshot()
+
+	lines := auction.Explain()
+	if !assert.NotEmpty(t, lines) {
+		return
+	}
+
+	// The narration matches an actual run on a clone.
+	sim := auction.Clone()
+	rounds := 0
+	for sim.nextRound(nil) {
+		rounds++
+	}
+
+	last := lines[len(lines)-1]
+	assert.Contains(t, last, "Carol wins at $85.00")
+	assert.Contains(t, last, "after "+strconv.Itoa(rounds)+" rounds")
+	assert.Contains(t, strings.Join(lines, "\n"), "Round 1: ")
+	assert.Contains(t, strings.Join(lines, "\n"), "Carol takes the lead")
+
+	after := auction.Snapshot()
+	assert.Equal(t, before.Bidders, after.Bidders, "the live auction is untouched")
+	assert.Equal(t, before.Version, after.Version)
+	assert.Empty(t, auction.History())
+}
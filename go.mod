@@ -4,7 +4,9 @@ go 1.21.8
 
 require (
 	github.com/google/uuid v1.6.0
+	github.com/gorilla/websocket v1.5.3
 	github.com/stretchr/testify v1.9.0
+	golang.org/x/time v0.5.0
 )
 
 require (
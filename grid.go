@@ -0,0 +1,28 @@
+package dispatchbidder
+
+import "math"
+
+// gridTolerance absorbs floating point error when checking grid alignment.
+const gridTolerance = 1e-9
+
+// onGrid reports whether the amount lands on the bidder's increment grid,
+// i.e. StartingBid + k*BidGridStep for a non-negative integer k.
+func (a *Auction) onGrid(bidder *Bidder, amount float64) bool {
+	if a.BidGridStep == 0 {
+		return true
+	}
+
+	steps := (amount - bidder.StartingBid) / a.BidGridStep
+	return steps >= -gridTolerance && math.Abs(steps-math.Round(steps)) < gridTolerance
+}
+
+// alignToGrid rounds the amount up to the nearest point on the bidder's
+// increment grid.
+func (a *Auction) alignToGrid(bidder *Bidder, amount float64) float64 {
+	if a.onGrid(bidder, amount) {
+		return amount
+	}
+
+	steps := math.Ceil((amount - bidder.StartingBid) / a.BidGridStep)
+	return bidder.StartingBid + math.Max(steps, 0)*a.BidGridStep
+}
@@ -0,0 +1,57 @@
+package dispatchbidder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBidGrid tests bids against the increment grid.
+func TestBidGrid(t *testing.T) {
+	t.Run("Off-grid bid is rejected", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 200.00, 5.00)
+		bob := createBidder("Bob", 60.00, 200.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, BidGridStep: 5.00})
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, auction.PlaceBid(alice, 57.00), ErrOffGrid)
+		assert.Equal(t, 50.00, alice.CurrentBid)
+
+		assert.NoError(t, auction.PlaceBid(alice, 75.00))
+		assert.Equal(t, 75.00, alice.CurrentBid)
+	})
+
+	t.Run("Off-grid bid is aligned up with WithAutoAlign", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 200.00, 5.00)
+		bob := createBidder("Bob", 60.00, 200.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, BidGridStep: 5.00}, WithAutoAlign())
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.PlaceBid(alice, 57.00))
+		assert.Equal(t, 60.00, alice.CurrentBid)
+	})
+
+	t.Run("Auto-increments snap to the grid", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 200.00, 5.00)
+		bob := createBidder("Bob", 60.00, 200.00, 3.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, BidGridStep: 5.00})
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.PlaceBid(alice, 70.00))
+		assert.Equal(t, 65.00, bob.CurrentBid, "60 + 3 snaps up to 65")
+	})
+
+	t.Run("Snapped bump above MaxBid is skipped", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 200.00, 5.00)
+		bob := createBidder("Bob", 60.00, 64.00, 3.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, BidGridStep: 5.00})
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.PlaceBid(alice, 70.00))
+		assert.Equal(t, 60.00, bob.CurrentBid)
+	})
+}
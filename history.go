@@ -0,0 +1,142 @@
+package dispatchbidder
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BidEvent records a single accepted bid, either placed by the bidder or
+// applied automatically through their AutoIncrement.
+type BidEvent struct {
+	// EventID uniquely identifies the event, so downstream consumers can
+	// dedupe it. It is random, even in seeded runs, so that it stays unique
+	// across replicas, and copies of the event keep it.
+	EventID uuid.UUID
+
+	BidderID uuid.UUID
+	Amount   float64
+	Time     time.Time
+	Auto     bool   // Whether the bid was an auto-increment rather than a manual bid.
+	Seq      uint64 // Monotonic sequence number of the bid within the auction.
+	CausedBy uint64 // Seq of the manual bid that triggered an auto-increment.
+
+	// PrevAmount and PrevTime hold the bidder's CurrentBid and LastBidTime
+	// before this bid, so the bid can be undone.
+	PrevAmount float64
+	PrevTime   time.Time
+
+	// BaseAmount is Amount converted to the auction's base currency, equal
+	// to Amount without exchange rates.
+	BaseAmount float64
+
+	prevSeq       uint64
+	prevIncrement float64
+	prevBumps     int
+	prevTarget    float64
+	prevManual    float64
+	prevRate      float64
+}
+
+// History returns a copy of all accepted bids in the order they were applied.
+func (a *Auction) History() []BidEvent {
+	a.RLock()
+	defer a.RUnlock()
+
+	history := make([]BidEvent, len(a.history))
+	copy(history, a.history)
+
+	return history
+}
+
+// HistoryBetween returns a copy of the accepted bids with a Time in
+// [start, end), sorted by time. Bids with equal times keep the order they were
+// applied in. It returns an error if start is after end.
+func (a *Auction) HistoryBetween(start, end time.Time) ([]BidEvent, error) {
+	if start.After(end) {
+		return nil, fmt.Errorf("invalid time range: start %s is after end %s", start.Format(time.RFC3339Nano), end.Format(time.RFC3339Nano))
+	}
+
+	a.RLock()
+	defer a.RUnlock()
+
+	var events []BidEvent
+	for _, event := range a.history {
+		if !event.Time.Before(start) && event.Time.Before(end) {
+			events = append(events, event)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+
+	return events, nil
+}
+
+// applyBid sets the bidder's bid, and their manual bid unless auto, consumes
+// their next bid target, applies their increment decay and records the bid
+// in the history under the next sequence number, which it returns.
+// Auto-increments pass the sequence number of the manual bid causing them.
+// The caller must hold the lock.
+func (a *Auction) applyBid(bidder *Bidder, amount, rate float64, at time.Time, auto bool, causedBy uint64) uint64 {
+	event := BidEvent{
+		EventID:       a.newEventID(),
+		BidderID:      bidder.ID,
+		Amount:        amount,
+		Time:          at,
+		Auto:          auto,
+		CausedBy:      causedBy,
+		PrevAmount:    bidder.CurrentBid,
+		PrevTime:      bidder.LastBidTime,
+		prevSeq:       bidder.seq,
+		prevIncrement: bidder.AutoIncrement,
+		prevBumps:     bidder.bumps,
+		prevTarget:    bidder.nextTarget,
+		prevManual:    bidder.manualBid,
+		prevRate:      bidder.rate,
+	}
+	prevBase := bidder.baseBid()
+
+	a.seq++
+	event.Seq = a.seq
+	bidder.seq = a.seq
+	bidder.CurrentBid = amount
+	bidder.LastBidTime = at
+	bidder.rate = rate
+	event.BaseAmount = bidder.baseBid()
+	bidder.nextTarget = 0
+	if !auto {
+		bidder.manualBid = amount
+	} else {
+		bidder.bumps++
+	}
+	bidder.decayIncrement()
+	a.history = append(a.history, event)
+	a.trackLeader(bidder, prevBase)
+	for _, sink := range a.sinks {
+		sink.Write(event)
+	}
+
+	return event.Seq
+}
+
+// newEventID returns a random event ID. It does not draw from the seeded
+// random source, which clones and decoded auctions restart from the seed, so
+// the IDs stay unique across replicas and auctions sharing a seed.
+func (a *Auction) newEventID() uuid.UUID {
+	return uuid.New()
+}
+
+// restore reverts the bidder to the state it had before the event.
+func (e BidEvent) restore(bidder *Bidder) {
+	bidder.CurrentBid = e.PrevAmount
+	bidder.LastBidTime = e.PrevTime
+	bidder.seq = e.prevSeq
+	bidder.AutoIncrement = e.prevIncrement
+	bidder.bumps = e.prevBumps
+	bidder.nextTarget = e.prevTarget
+	bidder.manualBid = e.prevManual
+	bidder.rate = e.prevRate
+}
@@ -0,0 +1,119 @@
+package dispatchbidder
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHistoryBetween tests filtering the history by a half-open time range.
+func TestHistoryBetween(t *testing.T) {
+	// Large increments keep auto-bumps out of the history.
+	alice := createBidder("Alice", 10.00, 100.00, 500.00)
+	bob := createBidder("Bob", 10.00, 100.00, 500.00)
+
+	clock := newManualClock()
+	start := clock.Now()
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, WithClock(clock))
+	assert.NoError(t, err)
+
+	amounts := []float64{20.00, 30.00, 40.00, 50.00}
+	for i, amount := range amounts {
+		bidder := alice
+		if i%2 == 1 {
+			bidder = bob
+		}
+		assert.NoError(t, auction.PlaceBid(bidder, amount))
+		clock.Advance(time.Minute)
+	}
+
+	tests := []struct {
+		name     string
+		from, to time.Duration
+		expected []float64
+	}{
+		{name: "Start is inclusive, end exclusive", from: time.Minute, to: 3 * time.Minute, expected: []float64{30.00, 40.00}},
+		{name: "Whole history", from: 0, to: 4 * time.Minute, expected: amounts},
+		{name: "Single instant", from: 2 * time.Minute, to: 2*time.Minute + 1, expected: []float64{40.00}},
+		{name: "Empty range", from: 2 * time.Minute, to: 2 * time.Minute},
+		{name: "After the last bid", from: 4 * time.Minute, to: time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := auction.HistoryBetween(start.Add(tt.from), start.Add(tt.to))
+			assert.NoError(t, err)
+
+			var got []float64
+			for _, event := range events {
+				got = append(got, event.Amount)
+			}
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+
+	_, err = auction.HistoryBetween(start.Add(time.Minute), start)
+	assert.ErrorContains(t, err, "invalid time range")
+}
+
+// TestEventID tests that event IDs are unique and stable.
+func TestEventID(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 200.00, 3.00)
+	bob := createBidder("Bob", 60.00, 200.00, 2.00)
+	sink := &memorySink{}
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, WithEventSink(sink))
+	assert.NoError(t, err)
+
+	for amount := 70.00; amount <= 150.00; amount += 10.00 {
+		assert.NoError(t, auction.PlaceBid(alice, amount))
+	}
+	history := auction.History()
+	assert.NotEmpty(t, history)
+
+	seen := make(map[uuid.UUID]bool)
+	for _, event := range history {
+		assert.NotEqual(t, uuid.Nil, event.EventID)
+		assert.False(t, seen[event.EventID], "duplicate event ID %s", event.EventID)
+		seen[event.EventID] = true
+	}
+
+	assert.NoError(t, auction.Flush())
+	assert.Equal(t, history, sink.flushed, "sinks receive the same IDs")
+	assert.Equal(t, history, auction.Clone().History(), "clones keep the IDs")
+
+	data, err := json.Marshal(history)
+	assert.NoError(t, err)
+	var decoded []BidEvent
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	if assert.Len(t, decoded, len(history)) {
+		for i := range history {
+			assert.Equal(t, history[i].EventID, decoded[i].EventID)
+		}
+	}
+
+	// Undoing a bid keeps the IDs of the remaining events.
+	assert.NoError(t, auction.UndoLastBid(alice.ID))
+	for _, event := range auction.History() {
+		assert.True(t, seen[event.EventID])
+	}
+
+	// Replicas restart the seeded random source, but not the event IDs.
+	clone := auction.Clone()
+	var restored Auction
+	data, err = json.Marshal(auction)
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(data, &restored))
+	seeded, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice.copy(), bob.copy()}}, WithSeed(auction.Seed()))
+	assert.NoError(t, err)
+	for _, replica := range []*Auction{auction, clone, &restored, seeded} {
+		bidder, _ := replica.LookupBidder(bob.ID)
+		assert.NoError(t, replica.PlaceBid(bidder, bidder.MaxBid))
+		event := replica.History()[len(replica.History())-1]
+		assert.False(t, seen[event.EventID], "duplicate event ID %s", event.EventID)
+		seen[event.EventID] = true
+	}
+}
@@ -0,0 +1,112 @@
+package dispatchbidder
+
+import "github.com/google/uuid"
+
+// OutbidFunc is called when a bid takes the lead away from a bidder, with the
+// ID of the bidder who lost the lead, the ID of the new leader and the new
+// leader's bid.
+type OutbidFunc func(outbidBidderID, byBidderID uuid.UUID, newAmount float64)
+
+// RejectFunc is called with the bidder, the attempted amount and the reason
+// whenever a bid is rejected. The error wraps one of the package sentinel
+// errors, so it can be matched with errors.Is.
+type RejectFunc func(bidderID uuid.UUID, amount float64, err error)
+
+// OnReject registers a hook fired on every rejected bid, so operators can
+// monitor rejection patterns centrally. The hook runs outside the auction
+// lock and may call back into the auction. Passing nil removes the hook.
+func (a *Auction) OnReject(fn RejectFunc) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.onReject = fn
+}
+
+// fireReject calls the reject hook if the bid was rejected.
+func fireReject(fn RejectFunc, bidderID uuid.UUID, amount float64, err error) {
+	if fn != nil && err != nil {
+		fn(bidderID, amount, err)
+	}
+}
+
+// OnFirstBid registers a hook fired once, with the bid event, on the first
+// manual bid the auction accepts through PlaceBid or PlaceBidIfVersion, to
+// announce that bidding has started. It never fires again, even if that bid
+// is undone, and does not fire at all when registered after the first bid.
+// Concurrent first bids are serialized by the auction lock, so exactly one
+// fires it. The hook runs outside the lock and may call back into the
+// auction. Passing nil removes the hook.
+func (a *Auction) OnFirstBid(fn func(BidEvent)) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.onFirstBid = fn
+}
+
+// recordFirstBid marks the manual bid just appended to the history as the
+// first one of the auction, if it is, queueing it for the first bid hook. The
+// caller must hold the lock.
+func (a *Auction) recordFirstBid() {
+	if a.bidReceived {
+		return
+	}
+	a.bidReceived = true
+	if a.onFirstBid != nil {
+		event := a.history[len(a.history)-1]
+		a.firstBid = &event
+	}
+}
+
+// takeFirstBid returns a function firing the first bid hook for a queued
+// first bid, or doing nothing if none is queued, to call once the lock is
+// released. The caller must hold the lock.
+func (a *Auction) takeFirstBid() func() {
+	event, fn := a.firstBid, a.onFirstBid
+	a.firstBid = nil
+	if event == nil || fn == nil {
+		return func() {}
+	}
+	return func() { fn(*event) }
+}
+
+// outbid is a change of leader waiting for the OnOutbid hook.
+type outbid struct {
+	outbidBidderID uuid.UUID
+	byBidderID     uuid.UUID
+	newAmount      float64
+}
+
+// OnOutbid registers a hook fired whenever a bid placed through PlaceBid or
+// PlaceBidIfVersion, with the auto-raises it triggers, takes the lead away
+// from the bidder who held it, so they can be told to bid again. Bids placed
+// while nobody leads fire nothing. The hook runs outside the auction lock and
+// may call back into the auction. Passing nil removes the hook.
+func (a *Auction) OnOutbid(fn OutbidFunc) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.onOutbid = fn
+}
+
+// recordOutbid queues a change of leader from prevLeader, if any, for the
+// outbid hook. The caller must hold the lock.
+func (a *Auction) recordOutbid(prevLeader *Bidder) {
+	if prevLeader == nil || a.onOutbid == nil {
+		return
+	}
+	if leader := a.determineWinner(); leader != nil && leader != prevLeader {
+		a.outbid = &outbid{outbidBidderID: prevLeader.ID, byBidderID: leader.ID, newAmount: leader.CurrentBid}
+	}
+}
+
+// takeOutbid returns a function firing the outbid hook for a queued change of
+// leader, or doing nothing if none is queued, to call once the lock is
+// released. The caller must hold the lock.
+func (a *Auction) takeOutbid() func() {
+	event, fn := a.outbid, a.onOutbid
+	a.outbid = nil
+	if event == nil || fn == nil {
+		return func() {}
+	}
+	return func() { fn(event.outbidBidderID, event.byBidderID, event.newAmount) }
+}
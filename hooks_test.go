@@ -0,0 +1,187 @@
+package dispatchbidder
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOnReject tests that the reject hook receives every rejection cause.
+func TestOnReject(t *testing.T) {
+	type rejection struct {
+		bidderID uuid.UUID
+		amount   float64
+		err      error
+	}
+
+	alice := createBidder("Alice", 50.00, 100.00, 5.00)
+	bob := createBidder("Bob", 60.00, 100.00, 5.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, AuctionMaxBid: 90.00, BidGridStep: 1.00})
+	assert.NoError(t, err)
+
+	var rejections []rejection
+	auction.OnReject(func(bidderID uuid.UUID, amount float64, err error) {
+		// The hook runs outside the lock, so calling back in must not deadlock.
+		_ = auction.Version()
+		rejections = append(rejections, rejection{bidderID, amount, err})
+	})
+
+	tests := []struct {
+		name     string
+		bid      func() error
+		bidder   *Bidder
+		amount   float64
+		sentinel error
+	}{
+		{"Below starting bid", func() error { return auction.PlaceBid(alice, 40.00) }, alice, 40.00, ErrBelowStartingBid},
+		{"Above max bid", func() error { return auction.PlaceBid(bob, 150.00) }, bob, 150.00, ErrAboveMaxBid},
+		{"Not above current bid", func() error { return auction.PlaceBid(bob, 60.00) }, bob, 60.00, ErrNotAboveCurrentBid},
+		{"Above auction cap", func() error { return auction.PlaceBid(alice, 95.00) }, alice, 95.00, ErrExceedsAuctionCap},
+		{"Off grid", func() error { return auction.PlaceBid(alice, 70.50) }, alice, 70.50, ErrOffGrid},
+		{"Stale version", func() error { return auction.PlaceBidIfVersion(42, alice, 70.00) }, alice, 70.00, ErrStaleVersion},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.bid()
+			assert.ErrorIs(t, err, tt.sentinel)
+
+			if assert.Len(t, rejections, i+1) {
+				got := rejections[i]
+				assert.Equal(t, tt.bidder.ID, got.bidderID)
+				assert.Equal(t, tt.amount, got.amount)
+				assert.True(t, errors.Is(got.err, tt.sentinel))
+			}
+		})
+	}
+
+	t.Run("Closed auction", func(t *testing.T) {
+		_, err = auction.Close()
+		assert.NoError(t, err)
+		assert.ErrorIs(t, auction.PlaceBid(bob, 70.00), ErrAuctionClosed)
+		assert.ErrorIs(t, rejections[len(rejections)-1].err, ErrAuctionClosed)
+	})
+
+	t.Run("Accepted bids do not fire the hook", func(t *testing.T) {
+		carol := createBidder("Carol", 50.00, 100.00, 5.00)
+		dave := createBidder("Dave", 60.00, 100.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{carol, dave}})
+		assert.NoError(t, err)
+
+		fired := false
+		auction.OnReject(func(uuid.UUID, float64, error) { fired = true })
+		assert.NoError(t, auction.PlaceBid(carol, 70.00))
+		assert.False(t, fired)
+	})
+}
+
+// TestOnFirstBid tests that the first bid hook fires exactly once, even when
+// the first bids race.
+func TestOnFirstBid(t *testing.T) {
+	t.Run("Concurrent first bids fire once", func(t *testing.T) {
+		bidders := make([]*Bidder, 8)
+		for i := range bidders {
+			bidders[i] = createBidder(fmt.Sprintf("Bidder %d", i), 50.00, 500.00, 0)
+		}
+		auction, err := NewAuction(NewAuctionConfig{Bidders: bidders})
+		assert.NoError(t, err)
+
+		var fired atomic.Int32
+		var first BidEvent
+		auction.OnFirstBid(func(event BidEvent) {
+			fired.Add(1)
+			first = event
+		})
+
+		var wg sync.WaitGroup
+		for i, bidder := range bidders {
+			wg.Add(1)
+			go func(bidder *Bidder, amount float64) {
+				defer wg.Done()
+				assert.NoError(t, auction.PlaceBid(bidder, amount))
+			}(bidder, 100.00+float64(i))
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), fired.Load())
+		assert.Equal(t, auction.History()[0], first)
+
+		assert.NoError(t, auction.PlaceBid(bidders[0], 200.00))
+		assert.Equal(t, int32(1), fired.Load())
+	})
+
+	t.Run("Rejected bids do not count", func(t *testing.T) {
+		auction, err := NewAuction(newTestConfig())
+		assert.NoError(t, err)
+		alice := auction.BidderList()[0]
+
+		fired := 0
+		auction.OnFirstBid(func(BidEvent) { fired++ })
+
+		assert.Error(t, auction.PlaceBid(alice, 1000.00))
+		assert.Equal(t, 0, fired)
+		assert.NoError(t, auction.PlaceBid(alice, alice.CurrentBid+10.00))
+		assert.Equal(t, 1, fired)
+	})
+
+	t.Run("Registering after the first bid never fires", func(t *testing.T) {
+		auction, err := NewAuction(newTestConfig())
+		assert.NoError(t, err)
+		alice := auction.BidderList()[0]
+		assert.NoError(t, auction.PlaceBid(alice, alice.CurrentBid+10.00))
+
+		fired := 0
+		auction.OnFirstBid(func(BidEvent) { fired++ })
+		assert.NoError(t, auction.UndoLastBid(alice.ID))
+		assert.NoError(t, auction.PlaceBid(alice, alice.CurrentBid+10.00))
+		assert.Equal(t, 0, fired)
+	})
+}
+
+// TestOnOutbid tests that the outbid hook names the bidder who lost the lead
+// and the one who took it.
+func TestOnOutbid(t *testing.T) {
+	type change struct {
+		outbid, by uuid.UUID
+		amount     float64
+	}
+
+	alice := createBidder("Alice", 50.00, 100.00, 0)
+	bob := createBidder("Bob", 60.00, 100.00, 0)
+	carol := createBidder("Carol", 40.00, 150.00, 10.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob, carol}})
+	assert.NoError(t, err)
+
+	var changes []change
+	auction.OnOutbid(func(outbidBidderID, byBidderID uuid.UUID, newAmount float64) {
+		_ = auction.Version()
+		changes = append(changes, change{outbidBidderID, byBidderID, newAmount})
+	})
+
+	assert.NoError(t, auction.PlaceBid(alice, 70.00))
+	assert.Equal(t, []change{{bob.ID, alice.ID, 70.00}}, changes)
+
+	assert.NoError(t, auction.PlaceBid(alice, 80.00))
+	assert.Len(t, changes, 1, "raising one's own lead outbids nobody")
+
+	assert.NoError(t, auction.PlaceBid(bob, 90.00))
+	assert.Equal(t, change{alice.ID, bob.ID, 90.00}, changes[1])
+
+	assert.NoError(t, auction.PlaceBid(carol, 95.00))
+	assert.Equal(t, change{bob.ID, carol.ID, 95.00}, changes[2])
+
+	assert.NoError(t, auction.PlaceBid(alice, 100.00))
+	assert.Len(t, changes, 3, "Carol is auto-raised past Alice and keeps the lead")
+	assert.Equal(t, carol, auction.DetermineWinner())
+
+	assert.Error(t, auction.PlaceBid(bob, 50.00))
+	assert.Len(t, changes, 3, "rejected bids change nothing")
+}
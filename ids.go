@@ -0,0 +1,58 @@
+package dispatchbidder
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// IDGenerator mints the IDs of auctions and of the bidders the package
+// creates itself, such as CSV imports without an ID and bidders remapped by
+// MergeBidders. Implementations must be safe for concurrent use.
+type IDGenerator interface {
+	New() uuid.UUID
+}
+
+// randomIDs is the default IDGenerator, minting random version 4 UUIDs.
+type randomIDs struct{}
+
+// New returns a random UUID.
+func (randomIDs) New() uuid.UUID {
+	return uuid.New()
+}
+
+// SequentialIDs is a deterministic IDGenerator minting the UUIDs 1, 2, 3 and
+// so on, as big-endian integers, for tests and reproducible fixtures. The
+// zero value is ready to use.
+type SequentialIDs struct {
+	mu   sync.Mutex
+	last uint64
+}
+
+// New returns the next UUID in the sequence.
+func (g *SequentialIDs) New() uuid.UUID {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.last++
+	var id uuid.UUID
+	binary.BigEndian.PutUint64(id[8:], g.last)
+	return id
+}
+
+// WithIDGenerator mints the IDs of the auction, and of any bidders it
+// creates, with gen instead of random UUIDs.
+func WithIDGenerator(gen IDGenerator) Option {
+	return func(a *Auction) {
+		a.ids = gen
+	}
+}
+
+// newID mints a new ID with the auction's generator.
+func (a *Auction) newID() uuid.UUID {
+	if a.ids == nil {
+		return randomIDs{}.New()
+	}
+	return a.ids.New()
+}
@@ -0,0 +1,43 @@
+package dispatchbidder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIDGenerator tests minting IDs with a pluggable generator.
+func TestIDGenerator(t *testing.T) {
+	t.Run("Predictable IDs", func(t *testing.T) {
+		ids := &SequentialIDs{}
+
+		data := "name,starting_bid,max_bid,auto_increment\nAlice,50,80,3\nBob,60,82,2\n"
+		bidders, err := ImportBiddersCSV(strings.NewReader(data), WithIDGenerator(ids))
+		assert.NoError(t, err)
+		assert.Equal(t, uuid.MustParse("00000000-0000-0000-0000-000000000001"), bidders[0].ID)
+		assert.Equal(t, uuid.MustParse("00000000-0000-0000-0000-000000000002"), bidders[1].ID)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: bidders}, WithIDGenerator(ids))
+		assert.NoError(t, err)
+		assert.Equal(t, uuid.MustParse("00000000-0000-0000-0000-000000000003"), auction.ID)
+
+		other, err := NewAuction(NewAuctionConfig{Bidders: bidders}, WithIDGenerator(ids))
+		assert.NoError(t, err)
+		assert.NoError(t, auction.MergeBidders(other, WithRemapCollisions()))
+		list := auction.BidderList()
+		assert.Equal(t, uuid.MustParse("00000000-0000-0000-0000-000000000005"), list[2].ID)
+		assert.Equal(t, uuid.MustParse("00000000-0000-0000-0000-000000000006"), list[3].ID)
+	})
+
+	t.Run("Random IDs by default", func(t *testing.T) {
+		first, err := NewAuction(newTestConfig())
+		assert.NoError(t, err)
+		second, err := NewAuction(newTestConfig())
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, first.ID, second.ID)
+		assert.Equal(t, uuid.Version(4), first.ID.Version())
+	})
+}
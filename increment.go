@@ -0,0 +1,140 @@
+package dispatchbidder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// cent is the smallest monetary step.
+const cent = 0.01
+
+// IncrementBand is a price band of an IncrementTable.
+type IncrementBand struct {
+	// UpTo is the exclusive upper bound of the band. Zero marks the last,
+	// open-ended band.
+	UpTo float64
+	// Increment is the minimum legal raise while the price is in the band.
+	Increment float64
+}
+
+// IncrementTable is a bid-increment table: bands sorted by UpTo, each giving
+// the minimum legal raise for prices below its bound, e.g. $1 under $100 and
+// $5 under $1000.
+type IncrementTable []IncrementBand
+
+// Increment returns the minimum raise for the band containing the price.
+// A price above every bounded band uses the last band.
+func (t IncrementTable) Increment(price float64) float64 {
+	for _, band := range t {
+		if band.UpTo == 0 || price < band.UpTo {
+			return band.Increment
+		}
+	}
+	if len(t) == 0 {
+		return 0
+	}
+	return t[len(t)-1].Increment
+}
+
+// validate checks that the bands are sorted, non-overlapping and positive.
+func (t IncrementTable) validate() error {
+	for i, band := range t {
+		if band.Increment <= 0 {
+			return fmt.Errorf("band %d: increment must be positive, got $%.2f", i, band.Increment)
+		}
+		if band.UpTo == 0 {
+			if i != len(t)-1 {
+				return fmt.Errorf("band %d: only the last band may be open-ended", i)
+			}
+			continue
+		}
+		if band.UpTo < 0 {
+			return fmt.Errorf("band %d: upper bound must be positive, got $%.2f", i, band.UpTo)
+		}
+		if i > 0 && band.UpTo <= t[i-1].UpTo {
+			return errors.New("bands must be sorted by upper bound and must not overlap")
+		}
+	}
+	return nil
+}
+
+// MinBidToLead returns the lowest bid that would make the bidder the leader:
+// the leading bid, converted to the bidder's currency at their current rate,
+// plus the minimum increment for the current price band, aligned to the bid
+// grid. The current leader gets their own CurrentBid back. The amount may
+// exceed what the bidder is allowed to bid.
+func (a *Auction) MinBidToLead(bidderID uuid.UUID) (float64, error) {
+	a.RLock()
+	defer a.RUnlock()
+
+	bidder, ok := a.bidderByID(bidderID)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrBidderNotFound, bidderID)
+	}
+
+	leader := a.determineWinner()
+	if leader == nil || leader == bidder {
+		return bidder.CurrentBid, nil
+	}
+
+	step := a.minIncrement()
+	if step == 0 {
+		step = cent
+	}
+	amount := max(bidder.fromBase(leader.baseBid())+step, bidder.CurrentBid+step)
+
+	return a.alignToGrid(bidder, amount), nil
+}
+
+// IncrementFunc returns the minimum legal raise given the number of active
+// bidders, those who may still bid and are below their MaxBid.
+type IncrementFunc func(activeBidders int) float64
+
+// ConstantIncrement returns an IncrementFunc requiring step whatever the
+// number of active bidders, the default behavior of MinIncrement.
+func ConstantIncrement(step float64) IncrementFunc {
+	return func(int) float64 { return step }
+}
+
+// WithMinIncrementFunc makes fn set the minimum legal raise in place of the
+// increment table and MinIncrement, so the step can shrink as bidders max
+// out or drop out, keeping the auction moving. fn is called under the
+// auction lock and must not call into the auction. It is not serialized.
+func WithMinIncrementFunc(fn IncrementFunc) Option {
+	return func(a *Auction) {
+		a.incrementFunc = fn
+	}
+}
+
+// activeBidders returns the number of bidders who may still raise their bid.
+// The caller must hold at least a read lock.
+func (a *Auction) activeBidders() int {
+	n := 0
+	for _, bidder := range a.Bidders {
+		if bidder.hasHeadroom() {
+			n++
+		}
+	}
+	return n
+}
+
+// minIncrement returns the minimum legal raise at the current price, taken
+// from the WithMinIncrementFunc function when set, then from the increment
+// table when set, otherwise MinIncrement. The caller must hold at least a
+// read lock.
+func (a *Auction) minIncrement() float64 {
+	if a.incrementFunc != nil {
+		return a.incrementFunc(a.activeBidders())
+	}
+	if len(a.IncrementTable) == 0 {
+		return a.MinIncrement
+	}
+
+	price := 0.0
+	if leader := a.determineWinner(); leader != nil {
+		price = leader.CurrentBid
+	}
+	return a.IncrementTable.Increment(price)
+}
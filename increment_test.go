@@ -0,0 +1,207 @@
+package dispatchbidder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testIncrementTable is $1 increments under $100, $5 under $1000 and $10 above.
+var testIncrementTable = IncrementTable{
+	{UpTo: 100.00, Increment: 1.00},
+	{UpTo: 1000.00, Increment: 5.00},
+	{Increment: 10.00},
+}
+
+// TestIncrementTable tests increments across band boundaries.
+func TestIncrementTable(t *testing.T) {
+	t.Run("Band lookup", func(t *testing.T) {
+		tests := []struct {
+			price    float64
+			expected float64
+		}{
+			{0.00, 1.00},
+			{99.99, 1.00},
+			{100.00, 5.00},
+			{999.99, 5.00},
+			{1000.00, 10.00},
+			{50000.00, 10.00},
+		}
+		for _, tt := range tests {
+			assert.Equal(t, tt.expected, testIncrementTable.Increment(tt.price), "price $%.2f", tt.price)
+		}
+	})
+
+	t.Run("PlaceBid and MinBidToLead follow the current band", func(t *testing.T) {
+		// Increments larger than the headroom keep auto-bumps out of the way.
+		alice := createBidder("Alice", 90.00, 2000.00, 5000.00)
+		bob := createBidder("Bob", 95.00, 2000.00, 5000.00)
+
+		auction, err := NewAuction(NewAuctionConfig{
+			Bidders:        []*Bidder{alice, bob},
+			MinIncrement:   50.00, // Overridden by the table.
+			IncrementTable: testIncrementTable,
+		})
+		assert.NoError(t, err)
+
+		minBid, err := auction.MinBidToLead(alice.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 96.00, minBid)
+
+		// Price $95 is in the $1 band.
+		assert.ErrorIs(t, auction.PlaceBid(alice, 90.50), ErrIncrementTooSmall)
+		assert.NoError(t, auction.PlaceBid(alice, 91.00))
+
+		// Bob moves the price into the $5 band.
+		assert.NoError(t, auction.PlaceBid(bob, 150.00))
+		assert.ErrorIs(t, auction.PlaceBid(alice, 93.00), ErrIncrementTooSmall)
+		assert.NoError(t, auction.PlaceBid(alice, 96.00))
+
+		minBid, err = auction.MinBidToLead(alice.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 155.00, minBid)
+
+		minBid, err = auction.MinBidToLead(bob.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 150.00, minBid, "the leader already leads")
+
+		// Alice crosses into the $10 band.
+		assert.NoError(t, auction.PlaceBid(alice, 1000.00))
+		minBid, err = auction.MinBidToLead(bob.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1010.00, minBid)
+	})
+
+	t.Run("MinBidToLead converts the leading bid", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 200.00, 5000.00)
+		alice.Currency = "EUR"
+		bob := createBidder("Bob", 60.00, 200.00, 5000.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, MinIncrement: 1.00},
+			WithExchangeRates("USD", FixedRates{"EUR": 2}))
+		assert.NoError(t, err)
+		assert.NoError(t, auction.PlaceBid(bob, 150.00))
+
+		minBid, err := auction.MinBidToLead(alice.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 76.00, minBid, "150 USD is 75 EUR")
+		assert.NoError(t, auction.PlaceBid(alice, minBid))
+		assert.Equal(t, alice.ID, auction.Leader().ID)
+
+		minBid, err = auction.MinBidToLead(bob.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 153.00, minBid, "76 EUR is 152 USD")
+	})
+
+	t.Run("MinIncrement applies without a table", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 200.00, 5000.00)
+		bob := createBidder("Bob", 60.00, 200.00, 5000.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, MinIncrement: 10.00})
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, auction.PlaceBid(alice, 55.00), ErrIncrementTooSmall)
+		assert.NoError(t, auction.PlaceBid(alice, 60.00))
+	})
+
+	t.Run("Invalid tables are rejected", func(t *testing.T) {
+		tables := map[string]IncrementTable{
+			"Unsorted":               {{UpTo: 1000.00, Increment: 5.00}, {UpTo: 100.00, Increment: 1.00}},
+			"Overlapping":            {{UpTo: 100.00, Increment: 1.00}, {UpTo: 100.00, Increment: 5.00}},
+			"Open-ended not last":    {{Increment: 1.00}, {UpTo: 100.00, Increment: 5.00}},
+			"Non-positive increment": {{UpTo: 100.00, Increment: 0}},
+		}
+		for name, table := range tables {
+			_, err := NewAuction(NewAuctionConfig{
+				Bidders: []*Bidder{
+					createBidder("Alice", 50.00, 200.00, 5.00),
+					createBidder("Bob", 60.00, 200.00, 5.00),
+				},
+				IncrementTable: table,
+			})
+			assert.Error(t, err, name)
+		}
+	})
+}
+
+// TestIncrementSequence tests bidders whose auto-raises escalate along a
+// sequence of steps.
+func TestIncrementSequence(t *testing.T) {
+	t.Run("Jumps grow along the sequence and clamp at its end", func(t *testing.T) {
+		fib := createBidder("Fib", 50.00, 500.00, 0)
+		fib.IncrementSequence = []float64{1, 1, 2, 3, 5}
+		rival := createBidder("Rival", 50.00, 500.00, 0)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{fib, rival}})
+		assert.NoError(t, err)
+
+		var jumps []float64
+		for round := 0; round < 7; round++ {
+			before := fib.CurrentBid
+			assert.NoError(t, auction.PlaceBid(rival, fib.CurrentBid+1.00))
+			jumps = append(jumps, fib.CurrentBid-before)
+		}
+		assert.Equal(t, []float64{1, 1, 2, 3, 5, 5, 5}, jumps)
+	})
+
+	t.Run("Undo rewinds the sequence", func(t *testing.T) {
+		fib := createBidder("Fib", 50.00, 500.00, 0)
+		fib.IncrementSequence = []float64{1, 2, 4}
+		rival := createBidder("Rival", 50.00, 500.00, 0)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{fib, rival}}, WithUndoRevertsBumps())
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.PlaceBid(rival, 60.00))
+		assert.NoError(t, auction.PlaceBid(rival, 70.00))
+		assert.Equal(t, 53.00, fib.CurrentBid)
+		assert.NoError(t, auction.UndoLastBid(rival.ID))
+		assert.Equal(t, 51.00, fib.CurrentBid)
+
+		assert.NoError(t, auction.PlaceBid(rival, 70.00))
+		assert.Equal(t, 53.00, fib.CurrentBid, "the undone raise's step is taken again")
+	})
+
+	t.Run("Steps must be positive", func(t *testing.T) {
+		fib := createBidder("Fib", 50.00, 500.00, 0)
+		fib.IncrementSequence = []float64{1, 0, 2}
+		rival := createBidder("Rival", 50.00, 500.00, 0)
+
+		_, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{fib, rival}})
+		assert.ErrorContains(t, err, "increment sequence step 1 must be positive")
+	})
+}
+
+// TestMinIncrementFunc tests that the required increment follows the number
+// of active bidders.
+func TestMinIncrementFunc(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 200.00, 0)
+	bob := createBidder("Bob", 60.00, 70.00, 0)
+	carol := createBidder("Carol", 60.00, 80.00, 0)
+
+	var counts []int
+	shrinking := func(active int) float64 {
+		counts = append(counts, active)
+		switch {
+		case active >= 3:
+			return 10.00
+		case active == 2:
+			return 5.00
+		default:
+			return 1.00
+		}
+	}
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob, carol}, MinIncrement: 20.00}, WithMinIncrementFunc(shrinking))
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, auction.PlaceBid(alice, 55.00), ErrIncrementTooSmall)
+	assert.NoError(t, auction.PlaceBid(bob, 70.00)) // Bob maxes out.
+	assert.ErrorIs(t, auction.PlaceBid(alice, 54.00), ErrIncrementTooSmall)
+	assert.NoError(t, auction.PlaceBid(alice, 55.00))
+	assert.NoError(t, auction.PlaceBid(carol, 80.00)) // Carol maxes out.
+	assert.NoError(t, auction.PlaceBid(alice, 56.00))
+	assert.Equal(t, []int{3, 3, 2, 2, 2, 1}, counts)
+
+	assert.Equal(t, 5.00, ConstantIncrement(5.00)(1))
+}
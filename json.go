@@ -0,0 +1,482 @@
+package dispatchbidder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SchemaVersion is the version of the JSON encoding of an auction. It is
+// bumped whenever the encoding changes incompatibly.
+const SchemaVersion = 1
+
+// auctionJSON is the JSON encoding of an auction. Fields are encoded in
+// declaration order, so the output is stable and suitable for golden files.
+type auctionJSON struct {
+	SchemaVersion   int                   `json:"schema_version"`
+	ID              uuid.UUID             `json:"id"`
+	State           State                 `json:"state"`
+	Mode            Mode                  `json:"mode"`
+	AuctionMaxBid   float64               `json:"auction_max_bid"`
+	TargetPrice     float64               `json:"target_price"`
+	BidGridStep     float64               `json:"bid_grid_step"`
+	MinDuration     time.Duration         `json:"min_duration"`
+	MinIncrement    float64               `json:"min_increment"`
+	IncrementTable  []bandJSON            `json:"increment_table"`
+	ReservePrice    float64               `json:"reserve_price"`
+	Units           int                   `json:"units"`
+	MaxRoundRise    float64               `json:"max_round_rise"`
+	MaxTotalBids    int                   `json:"max_total_bids"`
+	MinParticipants int                   `json:"min_participants"`
+	Settings        settingsJSON          `json:"settings"`
+	Version         uint64                `json:"version"`
+	Seq             uint64                `json:"seq"`
+	RandDraws       uint64                `json:"rand_draws"`
+	WinnerID        uuid.UUID             `json:"winner_id"`
+	OpenedAt        time.Time             `json:"opened_at"`
+	ClosedAt        time.Time             `json:"closed_at"`
+	EndTime         time.Time             `json:"end_time"`
+	Paused          bool                  `json:"paused"`
+	PausedAt        time.Time             `json:"paused_at"`
+	PausedFor       time.Duration         `json:"paused_for"`
+	CancelReason    string                `json:"cancel_reason"`
+	VoidReason      string                `json:"void_reason"`
+	Bidders         []bidderJSON          `json:"bidders"`
+	History         []bidEventJSON        `json:"history"`
+	Awards          []awardJSON           `json:"awards"`
+	Refunds         map[uuid.UUID]float64 `json:"refunds,omitempty"`
+	Commitments     map[uuid.UUID]float64 `json:"commitments,omitempty"`
+}
+
+// bandJSON is the JSON encoding of an IncrementBand.
+type bandJSON struct {
+	UpTo      float64 `json:"up_to"`
+	Increment float64 `json:"increment"`
+}
+
+// settingsJSON is the JSON encoding of the plain-data options of an auction.
+type settingsJSON struct {
+	AutoAlign        bool          `json:"auto_align"`
+	NoSelfOutbid     bool          `json:"no_self_outbid"`
+	TieEpsilon       time.Duration `json:"tie_epsilon"`
+	UndoRevertsBumps bool          `json:"undo_reverts_bumps"`
+	Seed             int64         `json:"seed"`
+	AllowEqualBids   bool          `json:"allow_equal_bids"`
+	SnipeWindow      time.Duration `json:"snipe_window"`
+	SnipeExtension   time.Duration `json:"snipe_extension"`
+	HiddenReserve    float64       `json:"hidden_reserve"`
+	CloseProxies     bool          `json:"close_proxies"`
+	HardEndTime      time.Time     `json:"hard_end_time"`
+	ShuffleBumps     bool          `json:"shuffle_bumps"`
+}
+
+// bidderJSON is the JSON encoding of a bidder, including its internal state.
+type bidderJSON struct {
+	ID               uuid.UUID         `json:"id"`
+	Name             string            `json:"name"`
+	OwnerID          string            `json:"owner_id"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+	Currency         string            `json:"currency"`
+	StartingBid      float64           `json:"starting_bid"`
+	MaxBid           float64           `json:"max_bid"`
+	ProxyMax         float64           `json:"proxy_max"`
+	CurrentBid       float64           `json:"current_bid"`
+	AutoIncrement    float64           `json:"auto_increment"`
+	IncrementSeq     []float64         `json:"increment_sequence,omitempty"`
+	Bumps            int               `json:"bumps"`
+	IncrementDecay   float64           `json:"increment_decay"`
+	ReactionDeadline time.Duration     `json:"reaction_deadline"`
+	ReactionTime     time.Duration     `json:"reaction_time"`
+	DesiredQuantity  int               `json:"desired_quantity"`
+	LastBidTime      time.Time         `json:"last_bid_time"`
+	Rate             float64           `json:"rate"`
+	Seq              uint64            `json:"seq"`
+	ManualBid        float64           `json:"manual_bid"`
+	NextTarget       float64           `json:"next_target"`
+	Frozen           bool              `json:"frozen"`
+	Retracted        bool              `json:"retracted"`
+	WithdrawAboveMax bool              `json:"withdraw_above_max"`
+	Withdrawn        bool              `json:"withdrawn"`
+	Disqualified     bool              `json:"disqualified"`
+	DisqualifyReason string            `json:"disqualify_reason"`
+	SealedBid        float64           `json:"sealed_bid"`
+	SealedAt         time.Time         `json:"sealed_at"`
+	Abstained        bool              `json:"abstained"`
+}
+
+// bidEventJSON is the JSON encoding of a BidEvent, including what undo needs.
+type bidEventJSON struct {
+	EventID       uuid.UUID `json:"event_id"`
+	BidderID      uuid.UUID `json:"bidder_id"`
+	Amount        float64   `json:"amount"`
+	BaseAmount    float64   `json:"base_amount"`
+	Time          time.Time `json:"time"`
+	Auto          bool      `json:"auto"`
+	Seq           uint64    `json:"seq"`
+	CausedBy      uint64    `json:"caused_by"`
+	PrevAmount    float64   `json:"prev_amount"`
+	PrevTime      time.Time `json:"prev_time"`
+	PrevSeq       uint64    `json:"prev_seq"`
+	PrevIncrement float64   `json:"prev_increment"`
+	PrevBumps     int       `json:"prev_bumps"`
+	PrevTarget    float64   `json:"prev_target"`
+	PrevManual    float64   `json:"prev_manual"`
+	PrevRate      float64   `json:"prev_rate"`
+}
+
+// awardJSON is the JSON encoding of an Award.
+type awardJSON struct {
+	BidderID  uuid.UUID `json:"bidder_id"`
+	Amount    float64   `json:"amount"`
+	Defaulted bool      `json:"defaulted"`
+}
+
+// MarshalJSON encodes the auction, tagged with SchemaVersion, with its
+// configuration, plain-data options, bidders, history and lifecycle state.
+// The random source is encoded as its seed and the number of values drawn,
+// so a decoded auction resumes it and breaks ties as the original would.
+// Options carrying behavior or external resources (the clock, event sinks,
+// hooks, subscribers, rate limits and recency weighting) and running
+// countdowns are not encoded.
+func (a *Auction) MarshalJSON() ([]byte, error) {
+	a.RLock()
+	defer a.RUnlock()
+
+	return json.Marshal(a.toJSON())
+}
+
+// UnmarshalJSON decodes an auction encoded by MarshalJSON into the receiver,
+// replacing its state. It rejects blobs without a schema version or with a
+// version newer than SchemaVersion, and configurations NewAuction would
+// reject, save for having fewer than two bidders, as well as bids out of
+// range or off the grid. Non-encoded options already set on the receiver are
+// kept; the clock defaults to the system clock. Any running countdown is
+// released; restart one with StartCountdown if needed.
+func (a *Auction) UnmarshalJSON(data []byte) error {
+	var header struct {
+		SchemaVersion *int `json:"schema_version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return err
+	}
+	switch {
+	case header.SchemaVersion == nil:
+		return errors.New("invalid auction JSON: missing schema_version")
+	case *header.SchemaVersion > SchemaVersion:
+		return fmt.Errorf("invalid auction JSON: schema version %d is newer than the supported version %d", *header.SchemaVersion, SchemaVersion)
+	case *header.SchemaVersion < 1:
+		return fmt.Errorf("invalid auction JSON: unknown schema version %d", *header.SchemaVersion)
+	}
+
+	var aj auctionJSON
+	if err := json.Unmarshal(data, &aj); err != nil {
+		return err
+	}
+
+	a.Lock()
+	defer a.Unlock()
+
+	return a.fromJSON(aj)
+}
+
+// toJSON returns the JSON encoding of the auction. The caller must hold at
+// least a read lock.
+func (a *Auction) toJSON() auctionJSON {
+	aj := auctionJSON{
+		SchemaVersion:   SchemaVersion,
+		ID:              a.ID,
+		State:           a.state,
+		Mode:            a.Mode,
+		AuctionMaxBid:   a.AuctionMaxBid,
+		TargetPrice:     a.TargetPrice,
+		BidGridStep:     a.BidGridStep,
+		MinDuration:     a.MinDuration,
+		MinIncrement:    a.MinIncrement,
+		ReservePrice:    a.ReservePrice,
+		Units:           a.Units,
+		MaxRoundRise:    a.MaxRoundRise,
+		MaxTotalBids:    a.MaxTotalBids,
+		MinParticipants: a.MinParticipants,
+		Settings: settingsJSON{
+			AutoAlign:        a.autoAlign,
+			NoSelfOutbid:     a.noSelfOutbid,
+			TieEpsilon:       a.tieEpsilon,
+			UndoRevertsBumps: a.undoRevertsBumps,
+			Seed:             a.seed,
+			AllowEqualBids:   a.allowEqualBids,
+			SnipeWindow:      a.snipeWindow,
+			SnipeExtension:   a.snipeExtension,
+			HiddenReserve:    a.hiddenReserve,
+			CloseProxies:     a.closeProxies,
+			HardEndTime:      a.hardEndTime,
+			ShuffleBumps:     a.shuffleBumps,
+		},
+		Version:        a.version,
+		Seq:            a.seq,
+		RandDraws:      a.source.drawn(),
+		OpenedAt:       a.openedAt,
+		ClosedAt:       a.closedAt,
+		EndTime:        a.endTime,
+		Paused:         a.paused,
+		PausedAt:       a.pausedAt,
+		PausedFor:      a.pausedFor,
+		CancelReason:   a.cancelReason,
+		VoidReason:     a.voidReason,
+		IncrementTable: make([]bandJSON, len(a.IncrementTable)),
+		Bidders:        make([]bidderJSON, len(a.Bidders)),
+		History:        make([]bidEventJSON, len(a.history)),
+		Awards:         make([]awardJSON, len(a.awards)),
+	}
+	if a.winner != nil {
+		aj.WinnerID = a.winner.ID
+	}
+	for i, band := range a.IncrementTable {
+		aj.IncrementTable[i] = bandJSON{UpTo: band.UpTo, Increment: band.Increment}
+	}
+	for i, b := range a.Bidders {
+		aj.Bidders[i] = bidderJSON{
+			ID:               b.ID,
+			Name:             b.Name,
+			OwnerID:          b.OwnerID,
+			Metadata:         b.Metadata,
+			Currency:         b.Currency,
+			StartingBid:      b.StartingBid,
+			MaxBid:           b.MaxBid,
+			ProxyMax:         b.ProxyMax,
+			CurrentBid:       b.CurrentBid,
+			AutoIncrement:    b.AutoIncrement,
+			IncrementSeq:     b.IncrementSequence,
+			Bumps:            b.bumps,
+			IncrementDecay:   b.IncrementDecay,
+			ReactionDeadline: b.ReactionDeadline,
+			ReactionTime:     b.ReactionTime,
+			DesiredQuantity:  b.DesiredQuantity,
+			LastBidTime:      b.LastBidTime,
+			Rate:             b.rate,
+			Seq:              b.seq,
+			ManualBid:        b.manualBid,
+			NextTarget:       b.nextTarget,
+			Frozen:           b.frozen,
+			Retracted:        b.retracted,
+			WithdrawAboveMax: b.WithdrawAboveMax,
+			Withdrawn:        b.withdrawn,
+			Disqualified:     b.disqualified,
+			DisqualifyReason: b.disqualifyReason,
+			SealedBid:        b.sealedBid,
+			SealedAt:         b.sealedAt,
+			Abstained:        b.abstained,
+		}
+	}
+	for i, e := range a.history {
+		aj.History[i] = bidEventJSON{
+			EventID:       e.EventID,
+			BidderID:      e.BidderID,
+			Amount:        e.Amount,
+			BaseAmount:    e.BaseAmount,
+			Time:          e.Time,
+			Auto:          e.Auto,
+			Seq:           e.Seq,
+			CausedBy:      e.CausedBy,
+			PrevAmount:    e.PrevAmount,
+			PrevTime:      e.PrevTime,
+			PrevSeq:       e.prevSeq,
+			PrevIncrement: e.prevIncrement,
+			PrevBumps:     e.prevBumps,
+			PrevTarget:    e.prevTarget,
+			PrevManual:    e.prevManual,
+			PrevRate:      e.prevRate,
+		}
+	}
+	for i, award := range a.awards {
+		aj.Awards[i] = awardJSON{BidderID: award.BidderID, Amount: award.Amount, Defaulted: award.Defaulted}
+	}
+	aj.Refunds = a.refunds
+	aj.Commitments = a.commitments
+
+	return aj
+}
+
+// fromJSON replaces the auction state with the decoded one. The caller must
+// hold the lock.
+func (a *Auction) fromJSON(aj auctionJSON) error {
+	bidders := make([]*Bidder, len(aj.Bidders))
+	byID := make(map[uuid.UUID]*Bidder, len(aj.Bidders))
+	for i, bj := range aj.Bidders {
+		if _, exists := byID[bj.ID]; exists {
+			return fmt.Errorf("invalid auction JSON: duplicate bidder ID detected: %s", bj.ID)
+		}
+		bidders[i] = &Bidder{
+			ID:                bj.ID,
+			Name:              bj.Name,
+			OwnerID:           bj.OwnerID,
+			Metadata:          bj.Metadata,
+			Currency:          bj.Currency,
+			StartingBid:       bj.StartingBid,
+			MaxBid:            bj.MaxBid,
+			ProxyMax:          bj.ProxyMax,
+			CurrentBid:        bj.CurrentBid,
+			AutoIncrement:     bj.AutoIncrement,
+			IncrementSequence: bj.IncrementSeq,
+			bumps:             bj.Bumps,
+			IncrementDecay:    bj.IncrementDecay,
+			ReactionDeadline:  bj.ReactionDeadline,
+			ReactionTime:      bj.ReactionTime,
+			DesiredQuantity:   bj.DesiredQuantity,
+			LastBidTime:       bj.LastBidTime,
+			rate:              bj.Rate,
+			seq:               bj.Seq,
+			manualBid:         bj.ManualBid,
+			nextTarget:        bj.NextTarget,
+			frozen:            bj.Frozen,
+			retracted:         bj.Retracted,
+			WithdrawAboveMax:  bj.WithdrawAboveMax,
+			withdrawn:         bj.Withdrawn,
+			disqualified:      bj.Disqualified,
+			disqualifyReason:  bj.DisqualifyReason,
+			sealedBid:         bj.SealedBid,
+			sealedAt:          bj.SealedAt,
+			abstained:         bj.Abstained,
+		}
+		byID[bj.ID] = bidders[i]
+	}
+
+	var winner *Bidder
+	if aj.WinnerID != uuid.Nil {
+		var ok bool
+		if winner, ok = byID[aj.WinnerID]; !ok {
+			return fmt.Errorf("invalid auction JSON: winner %s is not a bidder", aj.WinnerID)
+		}
+	}
+
+	table := make(IncrementTable, len(aj.IncrementTable))
+	for i, band := range aj.IncrementTable {
+		table[i] = IncrementBand{UpTo: band.UpTo, Increment: band.Increment}
+	}
+
+	// -----------------------------------------------------------------------
+	// Validate the configuration as NewAuction does, and the bids on top.
+
+	na := NewAuctionConfig{
+		Bidders:         bidders,
+		AuctionMaxBid:   aj.AuctionMaxBid,
+		Mode:            aj.Mode,
+		TargetPrice:     aj.TargetPrice,
+		BidGridStep:     aj.BidGridStep,
+		MinDuration:     aj.MinDuration,
+		MinIncrement:    aj.MinIncrement,
+		IncrementTable:  table,
+		ReservePrice:    aj.ReservePrice,
+		Units:           aj.Units,
+		MinParticipants: aj.MinParticipants,
+		MaxRoundRise:    aj.MaxRoundRise,
+		MaxTotalBids:    aj.MaxTotalBids,
+	}
+	if errs := configErrors(na); len(errs) > 0 {
+		return fmt.Errorf("invalid auction JSON: %w", errs[0])
+	}
+	grid := &Auction{BidGridStep: aj.BidGridStep}
+	for _, b := range bidders {
+		if b.CurrentBid < 0 || b.CurrentBid > b.MaxBid {
+			return fmt.Errorf("invalid auction JSON: current bid $%.2f for bidder ID %s is not within max bid $%.2f", b.CurrentBid, b.ID, b.MaxBid)
+		}
+		// Bids settled at the MaxBid or the auction cap may be off the grid.
+		settled := b.CurrentBid == b.MaxBid || b.CurrentBid == aj.AuctionMaxBid
+		if b.CurrentBid > b.StartingBid && !settled && !grid.onGrid(b, b.CurrentBid) {
+			return fmt.Errorf("invalid auction JSON: current bid $%.2f for bidder ID %s is not on the $%.2f grid", b.CurrentBid, b.ID, aj.BidGridStep)
+		}
+	}
+	for _, ej := range aj.History {
+		if err := checkAmount(ej.Amount); err != nil || ej.Amount < 0 {
+			return fmt.Errorf("invalid auction JSON: event %s has invalid amount $%.2f", ej.EventID, ej.Amount)
+		}
+	}
+
+	history := make([]BidEvent, len(aj.History))
+	for i, ej := range aj.History {
+		history[i] = BidEvent{
+			EventID:       ej.EventID,
+			BidderID:      ej.BidderID,
+			Amount:        ej.Amount,
+			BaseAmount:    ej.BaseAmount,
+			Time:          ej.Time,
+			Auto:          ej.Auto,
+			Seq:           ej.Seq,
+			CausedBy:      ej.CausedBy,
+			PrevAmount:    ej.PrevAmount,
+			PrevTime:      ej.PrevTime,
+			prevSeq:       ej.PrevSeq,
+			prevIncrement: ej.PrevIncrement,
+			prevBumps:     ej.PrevBumps,
+			prevTarget:    ej.PrevTarget,
+			prevManual:    ej.PrevManual,
+			prevRate:      ej.PrevRate,
+		}
+		if ej.BaseAmount == 0 {
+			// Encoded before exchange rates were tracked.
+			history[i].BaseAmount = ej.Amount
+		}
+	}
+
+	awards := make([]Award, len(aj.Awards))
+	for i, award := range aj.Awards {
+		awards[i] = Award{BidderID: award.BidderID, Amount: award.Amount, Defaulted: award.Defaulted}
+	}
+
+	a.releaseCountdown()
+	a.ID = aj.ID
+	a.Bidders = bidders
+	a.AuctionMaxBid = aj.AuctionMaxBid
+	a.Mode = aj.Mode
+	a.TargetPrice = aj.TargetPrice
+	a.BidGridStep = aj.BidGridStep
+	a.MinDuration = aj.MinDuration
+	a.MinIncrement = aj.MinIncrement
+	a.IncrementTable = table
+	a.ReservePrice = aj.ReservePrice
+	a.Units = aj.Units
+	a.MaxRoundRise = aj.MaxRoundRise
+	a.MaxTotalBids = aj.MaxTotalBids
+	a.MinParticipants = aj.MinParticipants
+	a.autoAlign = aj.Settings.AutoAlign
+	a.noSelfOutbid = aj.Settings.NoSelfOutbid
+	a.tieEpsilon = aj.Settings.TieEpsilon
+	a.undoRevertsBumps = aj.Settings.UndoRevertsBumps
+	a.seed = aj.Settings.Seed
+	a.allowEqualBids = aj.Settings.AllowEqualBids
+	a.snipeWindow = aj.Settings.SnipeWindow
+	a.snipeExtension = aj.Settings.SnipeExtension
+	a.hiddenReserve = aj.Settings.HiddenReserve
+	a.closeProxies = aj.Settings.CloseProxies
+	a.hardEndTime = aj.Settings.HardEndTime
+	a.shuffleBumps = aj.Settings.ShuffleBumps
+	if a.clock == nil {
+		a.clock = systemClock{}
+	}
+	a.state = aj.State
+	a.winner = winner
+	a.version = aj.Version
+	a.seq = aj.Seq
+	a.history = history
+	a.refunds = aj.Refunds
+	a.commitments = aj.Commitments
+	a.bidReceived = a.hasManualBid()
+	a.openedAt = aj.OpenedAt
+	a.closedAt = aj.ClosedAt
+	a.endTime = aj.EndTime
+	a.paused = aj.Paused
+	a.pausedAt = aj.PausedAt
+	a.pausedFor = aj.PausedFor
+	a.cancelReason = aj.CancelReason
+	a.voidReason = aj.VoidReason
+	a.awards = awards
+	a.rng, a.source = newLockedRand(aj.Settings.Seed, aj.RandDraws)
+	a.limiters = nil
+	a.index = nil
+	a.reindex(0)
+	a.refreshLeader()
+
+	return nil
+}
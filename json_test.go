@@ -0,0 +1,164 @@
+package dispatchbidder
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAuctionJSON tests the versioned JSON encoding of an auction.
+func TestAuctionJSON(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 80.00, 3.00)
+		bob := createBidder("Bob", 60.00, 120.00, 2.00)
+		carol := createBidder("Carol", 55.00, 100.00, 5.00)
+		clock := newManualClock()
+		for _, b := range []*Bidder{alice, bob, carol} {
+			b.LastBidTime = clock.Now()
+		}
+
+		auction, err := NewAuction(NewAuctionConfig{
+			Bidders:        []*Bidder{alice, bob, carol},
+			IncrementTable: IncrementTable{{UpTo: 100.00, Increment: 1.00}, {Increment: 5.00}},
+			ReservePrice:   70.00,
+		}, WithSeed(3), WithUndoRevertsBumps(), WithClock(clock))
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.PlaceBid(alice, 70.00))
+		clock.Advance(time.Second)
+		assert.NoError(t, auction.PlaceBid(bob, 90.00))
+		assert.NoError(t, auction.FreezeBidder(carol.ID))
+
+		data, err := json.Marshal(auction)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(data), `{"schema_version":1,"id":"`+auction.ID.String()+`",`), "fields are encoded in a stable order")
+
+		var decoded Auction
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, auction.ID, decoded.ID)
+		assert.Equal(t, auction.IncrementTable, decoded.IncrementTable)
+		assert.Equal(t, auction.History(), decoded.History())
+		assert.Equal(t, auction.Snapshot().Bidders, decoded.Snapshot().Bidders)
+		assert.Equal(t, auction.DetermineWinner().ID, decoded.Leader().ID)
+		assert.Equal(t, int64(3), decoded.Seed())
+
+		again, err := json.Marshal(&decoded)
+		assert.NoError(t, err)
+		assert.Equal(t, string(data), string(again), "the encoding is stable")
+
+		// The decoded auction keeps working, including undo.
+		assert.NoError(t, auction.UndoLastBid(bob.ID))
+		assert.NoError(t, decoded.UndoLastBid(bob.ID))
+		assert.Equal(t, auction.Snapshot().Bidders, decoded.Snapshot().Bidders)
+		decodedAlice, _ := decoded.bidderByID(alice.ID)
+		assert.ErrorIs(t, decoded.PlaceBid(decodedAlice, 1000.00), ErrAboveMaxBid)
+	})
+
+	t.Run("Version 1 blob", func(t *testing.T) {
+		blob := `{
+			"schema_version": 1,
+			"id": "7d0a3c52-2f4b-4a54-9b4e-0c7f1c6e2b11",
+			"state": 1,
+			"bidders": [
+				{"id": "0b6f0c6e-0b7e-4d6c-9f3e-8b1d5e0d2a01", "name": "Alice", "starting_bid": 50, "max_bid": 80, "current_bid": 75, "auto_increment": 3, "seq": 3},
+				{"id": "9c2e7b4a-6f1d-4e2b-8a3c-5d7e9f1a3b02", "name": "Bob", "starting_bid": 60, "max_bid": 90, "current_bid": 90, "auto_increment": 2, "seq": 4}
+			],
+			"history": [
+				{"event_id": "1f3e5d7c-9b1a-4c2e-8d4f-6a8b0c2e4f03", "bidder_id": "9c2e7b4a-6f1d-4e2b-8a3c-5d7e9f1a3b02", "amount": 90, "seq": 4}
+			],
+			"closed_at": "2024-01-01T12:00:00Z"
+		}`
+
+		var auction Auction
+		assert.NoError(t, json.Unmarshal([]byte(blob), &auction))
+		assert.Equal(t, uuid.MustParse("7d0a3c52-2f4b-4a54-9b4e-0c7f1c6e2b11"), auction.ID)
+		assert.Equal(t, StateClosed, auction.State())
+		assert.Len(t, auction.Bidders, 2)
+		assert.Equal(t, "Bob", auction.DetermineWinner().Name)
+		assert.Len(t, auction.History(), 1)
+
+		result, err := auction.GenerateResult()
+		assert.NoError(t, err)
+		assert.Equal(t, 90.00, result.WinningAmount)
+		assert.Equal(t, "Alice", result.RunnerUp.Name)
+	})
+
+	t.Run("Unsupported versions", func(t *testing.T) {
+		var auction Auction
+		assert.ErrorContains(t, json.Unmarshal([]byte(`{"schema_version": 2, "id": "7d0a3c52-2f4b-4a54-9b4e-0c7f1c6e2b11"}`), &auction),
+			"schema version 2 is newer than the supported version 1")
+		assert.ErrorContains(t, json.Unmarshal([]byte(`{"id": "7d0a3c52-2f4b-4a54-9b4e-0c7f1c6e2b11"}`), &auction), "missing schema_version")
+		assert.ErrorContains(t, json.Unmarshal([]byte(`{"schema_version": 0}`), &auction), "unknown schema version 0")
+	})
+
+	t.Run("Invalid references", func(t *testing.T) {
+		var auction Auction
+		blob := `{"schema_version": 1, "winner_id": "7d0a3c52-2f4b-4a54-9b4e-0c7f1c6e2b11", "bidders": []}`
+		assert.ErrorContains(t, json.Unmarshal([]byte(blob), &auction), "is not a bidder")
+	})
+
+	t.Run("Invalid payloads", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			modify   func(aj *auctionJSON)
+			expected string
+		}{
+			{"Negative starting bid", func(aj *auctionJSON) { aj.Bidders[0].StartingBid = -10.00 }, "starting bid must be positive"},
+			{"Current bid above max bid", func(aj *auctionJSON) { aj.Bidders[0].CurrentBid = 500.00 }, "is not within max bid"},
+			{"Off-grid current bid", func(aj *auctionJSON) { aj.Bidders[0].CurrentBid += 2.50 }, "is not on the $5.00 grid"},
+			{"Negative min increment", func(aj *auctionJSON) { aj.MinIncrement = -1.00 }, "min increment must not be negative"},
+			{"Negative bid", func(aj *auctionJSON) { aj.History[0].Amount = -70.00 }, "has invalid amount"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				auction, err := NewAuction(NewAuctionConfig{
+					Bidders:     []*Bidder{createBidder("Alice", 50.00, 100.00, 5.00), createBidder("Bob", 50.00, 100.00, 5.00)},
+					BidGridStep: 5.00,
+				})
+				assert.NoError(t, err)
+				assert.NoError(t, auction.PlaceBid(auction.BidderList()[0], 70.00))
+
+				aj := auction.toJSON()
+				tt.modify(&aj)
+				data, err := json.Marshal(aj)
+				assert.NoError(t, err)
+
+				var decoded Auction
+				assert.ErrorContains(t, json.Unmarshal(data, &decoded), tt.expected)
+			})
+		}
+	})
+
+	t.Run("Random source resumes", func(t *testing.T) {
+		bidders := make([]*Bidder, 5)
+		for i := range bidders {
+			bidders[i] = createBidder("Bidder", 50.00, 500.00, 1.00)
+		}
+		auction, err := NewAuction(NewAuctionConfig{Bidders: bidders}, WithSeed(7), WithShuffledBumps(), WithClock(newManualClock()))
+		assert.NoError(t, err)
+		assert.NoError(t, auction.PlaceBid(bidders[0], 60.00))
+
+		data, err := json.Marshal(auction)
+		assert.NoError(t, err)
+		var decoded Auction
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+
+		for _, a := range []*Auction{auction, &decoded} {
+			bidder, _ := a.bidderByID(bidders[1].ID)
+			assert.NoError(t, a.PlaceBid(bidder, 100.00))
+		}
+		order := func(a *Auction) []uuid.UUID {
+			var ids []uuid.UUID
+			for _, event := range a.History() {
+				ids = append(ids, event.BidderID)
+			}
+			return ids
+		}
+		assert.Equal(t, order(auction), order(&decoded), "the bumps come in the same order")
+		assert.Equal(t, auction.Rand().Int63(), decoded.Rand().Int63())
+	})
+}
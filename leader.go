@@ -0,0 +1,57 @@
+package dispatchbidder
+
+// Leader returns the current provisional winner, like DetermineWinner, in
+// constant time for dashboards polling frequently. The leader is cached and
+// kept up to date by every Auction method that changes the standings; changes
+// made by modifying Bidders directly are not seen until the next such
+// method call. With recency weighting, where the leader changes as time
+// passes, it falls back to a full DetermineWinner. Like DetermineWinner, it
+// returns nil while the hidden reserve is not met.
+func (a *Auction) Leader() *Bidder {
+	a.RLock()
+	defer a.RUnlock()
+
+	var leader *Bidder
+	switch {
+	case a.state.withoutWinner(), a.sealedUnrevealed():
+		return nil
+	case a.winner != nil:
+		leader = a.winner
+	case a.recencyDecay != nil:
+		leader = a.topBidder()
+	default:
+		leader = a.leader
+	}
+	if !a.meetsReserve(leader) {
+		return nil
+	}
+	return leader
+}
+
+// trackLeader updates the cached leader after the bidder's bid changed from
+// prevBase, in the base currency, without rescanning the bidders when
+// possible. The caller must hold the lock.
+func (a *Auction) trackLeader(bidder *Bidder, prevBase float64) {
+	switch {
+	case !bidder.inRunning():
+	case bidder == a.leader:
+		// A leader raising stays ahead; anything else may reorder the top.
+		if bidder.baseBid() <= prevBase {
+			a.refreshLeader()
+		}
+	case a.isWinner(a.leader, bidder):
+		a.leader = bidder
+	}
+}
+
+// refreshLeader recomputes the cached leader with a full scan. The caller
+// must hold the lock.
+func (a *Auction) refreshLeader() {
+	var leader *Bidder
+	for _, bidder := range a.Bidders {
+		if bidder.inRunning() && a.isWinner(leader, bidder) {
+			leader = bidder
+		}
+	}
+	a.leader = leader
+}
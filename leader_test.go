@@ -0,0 +1,67 @@
+package dispatchbidder
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLeader tests that the cached leader matches a fresh DetermineWinner
+// across every mutation path.
+func TestLeader(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	var bidders []*Bidder
+	for i := 0; i < 6; i++ {
+		bidders = append(bidders, createBidder(fmt.Sprintf("Bidder%d", i), 10.00, 100.00+float64(i*10), float64(1+i%3)))
+	}
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: bidders}, WithAllowEqualBids(), WithUndoRevertsBumps())
+	assert.NoError(t, err)
+	assert.Equal(t, auction.DetermineWinner(), auction.Leader())
+
+	for i := 0; i < 500; i++ {
+		current := auction.BidderList()
+		bidder := current[rng.Intn(len(current))]
+
+		switch op := rng.Intn(10); op {
+		case 0:
+			_ = auction.FreezeBidder(bidder.ID)
+		case 1:
+			_ = auction.UnfreezeBidder(bidder.ID)
+		case 2:
+			_ = auction.UndoLastBid(bidder.ID)
+		case 3:
+			if rng.Intn(10) == 0 {
+				_ = auction.RetractBidder(bidder.ID)
+			}
+		case 4:
+			if rng.Intn(10) == 0 {
+				_ = auction.Disqualify(bidder.ID, "test")
+			}
+		case 5:
+			if rng.Intn(5) == 0 && len(current) > 3 {
+				_ = auction.RemoveBidder(bidder.ID)
+			} else if rng.Intn(5) == 0 {
+				_ = auction.AddBidder(createBidder(fmt.Sprintf("Late%d", i), 10.00+float64(rng.Intn(50)), 150.00, 2.00))
+			}
+		case 6:
+			_ = auction.PlaceBid(bidder, bidder.CurrentBid)
+		default:
+			_ = auction.PlaceBid(bidder, bidder.CurrentBid+float64(rng.Intn(20)))
+		}
+
+		assert.Same(t, auction.DetermineWinner(), auction.Leader(), "after operation %d", i)
+	}
+
+	clone := auction.Clone()
+	assert.Same(t, clone.DetermineWinner(), clone.Leader())
+
+	assert.NoError(t, auction.ForceSettle())
+	assert.Same(t, auction.DetermineWinner(), auction.Leader())
+
+	assert.NoError(t, clone.Cancel("test"))
+	assert.Nil(t, clone.Leader())
+}
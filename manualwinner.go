@@ -0,0 +1,33 @@
+package dispatchbidder
+
+// DetermineWinnerByManualBid determines the winner of a format that ignores
+// proxy inflation: among the bidders in the running who placed a manual bid,
+// the one whose last manual bid, in the base currency, is the highest wins,
+// whatever auto-raises did to the CurrentBids since. Equal manual bids are
+// broken as in DetermineWinner. It returns nil when nobody has bid manually,
+// and for cancelled and voided auctions.
+func (a *Auction) DetermineWinnerByManualBid() *Bidder {
+	a.RLock()
+	defer a.RUnlock()
+
+	if a.state.withoutWinner() || a.sealedUnrevealed() {
+		return nil
+	}
+
+	var winner *Bidder
+	for _, bidder := range a.Bidders {
+		if !bidder.inRunning() || bidder.manualBid == 0 {
+			continue
+		}
+		if winner == nil {
+			winner = bidder
+			continue
+		}
+		amount, top := bidder.toBase(bidder.manualBid), winner.toBase(winner.manualBid)
+		if amount > top || (amount == top && a.breaksTie(bidder, winner)) {
+			winner = bidder
+		}
+	}
+
+	return winner
+}
@@ -0,0 +1,43 @@
+package dispatchbidder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDetermineWinnerByManualBid tests that the manual-bid winner ignores the
+// auto-raises that decide the standard winner.
+func TestDetermineWinnerByManualBid(t *testing.T) {
+	t.Run("Differs from the standard winner", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 100.00, 5.00)
+		bob := createBidder("Bob", 60.00, 100.00, 20.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+		assert.Nil(t, auction.DetermineWinnerByManualBid(), "nobody bid manually")
+
+		assert.NoError(t, auction.PlaceBid(alice, 70.00))
+		assert.Equal(t, 80.00, bob.CurrentBid)
+		assert.Equal(t, bob, auction.DetermineWinner(), "Bob leads on his auto-raise")
+		assert.Equal(t, alice, auction.DetermineWinnerByManualBid())
+	})
+
+	t.Run("Highest manual bid", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 100.00, 0)
+		bob := createBidder("Bob", 60.00, 100.00, 0)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.PlaceBid(alice, 70.00))
+		assert.NoError(t, auction.PlaceBid(bob, 70.00))
+		assert.Equal(t, alice, auction.DetermineWinnerByManualBid(), "the earlier of equal bids wins")
+
+		assert.NoError(t, auction.PlaceBid(bob, 75.00))
+		assert.Equal(t, bob, auction.DetermineWinnerByManualBid())
+
+		assert.NoError(t, auction.Cancel("item withdrawn"))
+		assert.Nil(t, auction.DetermineWinnerByManualBid())
+	})
+}
@@ -0,0 +1,104 @@
+package dispatchbidder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// MergeOption configures MergeBidders.
+type MergeOption func(*mergeConfig)
+
+// mergeConfig holds the options of a merge.
+type mergeConfig struct {
+	remap bool
+}
+
+// WithRemapCollisions makes MergeBidders give a fresh ID to any merged bidder
+// whose ID is already in the auction, instead of rejecting the merge.
+func WithRemapCollisions() MergeOption {
+	return func(c *mergeConfig) {
+		c.remap = true
+	}
+}
+
+// MergeBidders adds copies of other's bidders to this auction, for
+// consolidating duplicate listings. Bidder ID collisions fail the merge with
+// ErrDuplicateBidder unless WithRemapCollisions is given. The merged set is
+// validated as a whole against this auction's configuration; on any error
+// the auction is left unchanged. Merged bidders keep their current bids but
+// not their history, and register after the existing bidders in their
+// original order. other is not modified.
+func (a *Auction) MergeBidders(other *Auction, opts ...MergeOption) error {
+	if a == other {
+		return errors.New("cannot merge an auction into itself")
+	}
+
+	var cfg mergeConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	// Lock in a consistent order so concurrent merges in both directions
+	// cannot deadlock.
+	if bytes.Compare(a.ID[:], other.ID[:]) < 0 {
+		a.Lock()
+		other.RLock()
+	} else {
+		other.RLock()
+		a.Lock()
+	}
+	defer a.Unlock()
+	defer other.RUnlock()
+
+	if a.state.ended() {
+		return a.checkOpen()
+	}
+
+	existing := make(map[uuid.UUID]bool, len(a.Bidders))
+	for _, bidder := range a.Bidders {
+		existing[bidder.ID] = true
+	}
+
+	incoming := make([]*Bidder, len(other.Bidders))
+	copy(incoming, other.Bidders)
+	sort.Slice(incoming, func(i, j int) bool { return incoming[i].seq < incoming[j].seq })
+
+	merged := make([]*Bidder, 0, len(incoming))
+	for _, bidder := range incoming {
+		b := bidder.copy()
+		if existing[b.ID] {
+			if !cfg.remap {
+				return fmt.Errorf("%w: %s", ErrDuplicateBidder, b.ID)
+			}
+			b.ID = a.newID()
+		}
+		existing[b.ID] = true
+		merged = append(merged, b)
+	}
+
+	na := a.config()
+	na.Bidders = append(na.Bidders, merged...)
+	if err := validateAuctionData(na); err != nil {
+		return fmt.Errorf("invalid merged bidders: %w", err)
+	}
+	if err := a.quoteRates(merged); err != nil {
+		return fmt.Errorf("invalid merged bidders: %w", err)
+	}
+
+	for _, bidder := range merged {
+		a.seq++
+		bidder.seq = a.seq
+	}
+	from := len(a.Bidders)
+	a.Bidders = na.Bidders
+	a.reindex(from)
+	a.refreshLeader()
+	a.version++
+	a.notify()
+
+	return nil
+}
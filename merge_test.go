@@ -0,0 +1,104 @@
+package dispatchbidder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMergeBidders tests combining the bidder pools of two auctions.
+func TestMergeBidders(t *testing.T) {
+	t.Run("Merged pool determines the winner", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 80.00, 3.00)
+		bob := createBidder("Bob", 60.00, 82.00, 2.00)
+		carol := createBidder("Carol", 55.00, 120.00, 5.00)
+		dave := createBidder("Dave", 40.00, 70.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+		duplicate, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{carol, dave}})
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.MergeBidders(duplicate))
+		assert.Len(t, auction.Bidders, 4)
+		assert.Len(t, duplicate.Bidders, 2, "the other auction is not modified")
+
+		winner, err := auction.RunToCompletion()
+		assert.NoError(t, err)
+		if assert.NotNil(t, winner) {
+			assert.Equal(t, carol.ID, winner.ID)
+			assert.NotSame(t, carol, winner, "merged bidders are copies")
+		}
+	})
+
+	t.Run("ID collision", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 80.00, 3.00)
+		bob := createBidder("Bob", 60.00, 82.00, 2.00)
+		clone := *alice
+		clone.MaxBid = 200.00
+		carol := createBidder("Carol", 55.00, 120.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+		duplicate, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{&clone, carol}})
+		assert.NoError(t, err)
+
+		err = auction.MergeBidders(duplicate)
+		assert.ErrorIs(t, err, ErrDuplicateBidder)
+		assert.Len(t, auction.Bidders, 2, "a rejected merge changes nothing")
+
+		assert.NoError(t, auction.MergeBidders(duplicate, WithRemapCollisions()))
+		assert.Len(t, auction.Bidders, 4)
+
+		ids := make(map[string]bool)
+		for _, b := range auction.Bidders {
+			ids[b.ID.String()] = true
+		}
+		assert.Len(t, ids, 4, "the colliding bidder got a fresh ID")
+
+		remapped := 0
+		for _, b := range auction.Bidders {
+			if b.Name == "Alice" && b.ID != alice.ID {
+				remapped++
+				assert.Equal(t, 200.00, b.MaxBid)
+			}
+		}
+		assert.Equal(t, 1, remapped)
+	})
+
+	t.Run("Merged set must be valid", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 80.00, 3.00)
+		bob := createBidder("Bob", 60.00, 82.00, 2.00)
+		carol := createBidder("Carol", 150.00, 220.00, 5.00)
+		dave := createBidder("Dave", 40.00, 70.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, AuctionMaxBid: 100.00})
+		assert.NoError(t, err)
+		duplicate, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{carol, dave}})
+		assert.NoError(t, err)
+
+		assert.Error(t, auction.MergeBidders(duplicate))
+		assert.Len(t, auction.Bidders, 2)
+		assert.Error(t, auction.MergeBidders(auction))
+	})
+
+	t.Run("Concurrent merges in both directions", func(t *testing.T) {
+		first, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{
+			createBidder("Alice", 50.00, 80.00, 3.00),
+			createBidder("Bob", 60.00, 82.00, 2.00),
+		}})
+		assert.NoError(t, err)
+		second, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{
+			createBidder("Carol", 55.00, 120.00, 5.00),
+			createBidder("Dave", 40.00, 70.00, 5.00),
+		}})
+		assert.NoError(t, err)
+
+		done := make(chan error, 2)
+		go func() { done <- first.MergeBidders(second, WithRemapCollisions()) }()
+		go func() { done <- second.MergeBidders(first, WithRemapCollisions()) }()
+		for i := 0; i < 2; i++ {
+			assert.NoError(t, <-done)
+		}
+	})
+}
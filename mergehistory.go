@@ -0,0 +1,145 @@
+package dispatchbidder
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// MergeHistories reconciles the auction history with the history of another
+// replica of the same auction, such as one that kept taking bids through a
+// network partition, and returns the merged history without changing the
+// auction. Events are matched by EventID: those in both histories are kept
+// once, and the rest interleave by Time, then Seq, then EventID, so the
+// latest write lands last and wins. Both replicas may have bid for the same
+// bidder, as every manual bid auto-raises the other bidders: the bidder then
+// ends at their last bid in that order, and each event's PrevAmount and
+// PrevTime are rebuilt from the bidder's previous event in the merge, so the
+// chain stays consistent for undo. MergeReplica adopts the result.
+//
+// It returns ErrHistoryConflict when one EventID carries different bids.
+func (a *Auction) MergeHistories(other []BidEvent) ([]BidEvent, error) {
+	a.RLock()
+	local := append([]BidEvent(nil), a.history...)
+	a.RUnlock()
+
+	return mergeHistories(local, other)
+}
+
+// MergeReplica merges the history of another replica into the open auction,
+// as MergeHistories does, and adopts the result: the auction takes the
+// merged history, and each bidder in it the state of their last bid in it,
+// their CurrentBid, LastBidTime and last manual bid included. Their other
+// settings are kept. It returns ErrBidderNotFound, leaving the auction
+// unchanged, when the other history bids for a bidder the auction does not
+// have, and fails as MergeHistories does otherwise.
+func (a *Auction) MergeReplica(other []BidEvent) error {
+	a.Lock()
+	defer a.Unlock()
+
+	if err := a.checkOpen(); err != nil {
+		return err
+	}
+	merged, err := mergeHistories(a.history, other)
+	if err != nil {
+		return err
+	}
+
+	last := make(map[uuid.UUID]BidEvent)
+	manual := make(map[uuid.UUID]float64)
+	for _, event := range merged {
+		if _, ok := a.bidderByID(event.BidderID); !ok {
+			return fmt.Errorf("%w: %s", ErrBidderNotFound, event.BidderID)
+		}
+		last[event.BidderID] = event
+		if !event.Auto {
+			manual[event.BidderID] = event.Amount
+		}
+		a.seq = max(a.seq, event.Seq)
+	}
+	for id, event := range last {
+		bidder, _ := a.bidderByID(id)
+		bidder.CurrentBid = event.Amount
+		bidder.LastBidTime = event.Time
+		bidder.seq = event.Seq
+		bidder.rate = event.rate()
+		if amount, ok := manual[id]; ok {
+			bidder.manualBid = amount
+		}
+	}
+	a.history = merged
+	a.refreshLeader()
+	a.version++
+	a.notify()
+
+	return nil
+}
+
+// mergeHistories merges two histories as MergeHistories describes. It does
+// not modify them.
+func mergeHistories(local, other []BidEvent) ([]BidEvent, error) {
+	byID := make(map[uuid.UUID]BidEvent, len(local)+len(other))
+	merged := make([]BidEvent, 0, len(local)+len(other))
+	for _, event := range local {
+		byID[event.EventID] = event
+		merged = append(merged, event)
+	}
+	for _, event := range other {
+		if seen, ok := byID[event.EventID]; ok {
+			if !seen.sameBid(event) {
+				return nil, fmt.Errorf("%w: event %s differs between replicas", ErrHistoryConflict, event.EventID)
+			}
+			continue
+		}
+		byID[event.EventID] = event
+		merged = append(merged, event)
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		ei, ej := merged[i], merged[j]
+		if !ei.Time.Equal(ej.Time) {
+			return ei.Time.Before(ej.Time)
+		}
+		if ei.Seq != ej.Seq {
+			return ei.Seq < ej.Seq
+		}
+		return bytes.Compare(ei.EventID[:], ej.EventID[:]) < 0
+	})
+
+	// Each bidder's first event keeps the state it was bid from; the later
+	// ones follow on from the bidder's previous event in the merge.
+	prev := make(map[uuid.UUID]BidEvent)
+	for i := range merged {
+		event := &merged[i]
+		if p, ok := prev[event.BidderID]; ok {
+			event.PrevAmount = p.Amount
+			event.PrevTime = p.Time
+			event.prevSeq = p.Seq
+			event.prevRate = p.rate()
+		}
+		prev[event.BidderID] = *event
+	}
+
+	return merged, nil
+}
+
+// rate returns the exchange rate the event was bid at, or zero for a bid in
+// the base currency.
+func (e BidEvent) rate() float64 {
+	if e.Amount == 0 || e.BaseAmount == e.Amount {
+		return 0
+	}
+	return e.BaseAmount / e.Amount
+}
+
+// sameBid reports whether both events record the same bid.
+func (e BidEvent) sameBid(other BidEvent) bool {
+	return e.BidderID == other.BidderID &&
+		e.Amount == other.Amount &&
+		e.Time.Equal(other.Time) &&
+		e.Auto == other.Auto &&
+		e.Seq == other.Seq &&
+		e.PrevAmount == other.PrevAmount
+}
@@ -0,0 +1,161 @@
+package dispatchbidder
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMergeHistories tests reconciling the histories of diverged replicas.
+func TestMergeHistories(t *testing.T) {
+	// replicas returns two replicas of an auction that share its first bid.
+	replicas := func(t *testing.T, increment float64) (*Auction, *Auction, []*Bidder) {
+		clock := newManualClock()
+		bidders := []*Bidder{
+			createBidder("Alice", 50.00, 200.00, increment),
+			createBidder("Bob", 50.00, 200.00, increment),
+			createBidder("Carol", 50.00, 200.00, increment),
+		}
+		auction, err := NewAuction(NewAuctionConfig{Bidders: bidders}, WithClock(clock))
+		assert.NoError(t, err)
+		assert.NoError(t, auction.PlaceBid(bidders[0], 60.00))
+
+		replica := auction.Clone()
+
+		clock.Advance(time.Second)
+		return auction, replica, bidders
+	}
+
+	lookup := func(a *Auction, b *Bidder) *Bidder {
+		bidder, _ := a.LookupBidder(b.ID)
+		return bidder
+	}
+
+	t.Run("Divergence on different bidders merges by time", func(t *testing.T) {
+		auction, replica, bidders := replicas(t, 0)
+		clock := auction.clock.(*manualClock)
+
+		assert.NoError(t, auction.PlaceBid(bidders[1], 70.00))
+		clock.Advance(time.Second)
+		assert.NoError(t, replica.PlaceBid(lookup(replica, bidders[2]), 80.00))
+		clock.Advance(time.Second)
+		assert.NoError(t, auction.PlaceBid(bidders[1], 90.00))
+
+		merged, err := auction.MergeHistories(replica.History())
+		assert.NoError(t, err)
+
+		var amounts []float64
+		for _, event := range merged {
+			amounts = append(amounts, event.Amount)
+		}
+		assert.Equal(t, []float64{60.00, 70.00, 80.00, 90.00}, amounts)
+		assert.Equal(t, bidders[0].ID, merged[0].BidderID, "the shared bid is kept once")
+		assert.Len(t, auction.History(), 3, "the auction is left unchanged")
+
+		again, err := replica.MergeHistories(auction.History())
+		assert.NoError(t, err)
+		assert.Equal(t, merged, again, "merging is symmetric")
+	})
+
+	t.Run("Replicas restored from JSON", func(t *testing.T) {
+		auction, _, bidders := replicas(t, 0)
+		data, err := json.Marshal(auction)
+		assert.NoError(t, err)
+
+		var first, second Auction
+		assert.NoError(t, json.Unmarshal(data, &first))
+		assert.NoError(t, json.Unmarshal(data, &second))
+		assert.NoError(t, first.PlaceBid(lookup(&first, bidders[1]), 70.00))
+		assert.NoError(t, second.PlaceBid(lookup(&second, bidders[2]), 80.00))
+
+		merged, err := first.MergeHistories(second.History())
+		assert.NoError(t, err)
+		assert.Len(t, merged, 3)
+		assert.Equal(t, bidders[0].ID, merged[0].BidderID, "the shared bid is kept once")
+	})
+
+	t.Run("Both replicas bidding for one bidder merge by last write", func(t *testing.T) {
+		auction, replica, bidders := replicas(t, 0)
+		clock := auction.clock.(*manualClock)
+
+		assert.NoError(t, replica.PlaceBid(lookup(replica, bidders[1]), 75.00))
+		clock.Advance(time.Second)
+		assert.NoError(t, auction.PlaceBid(bidders[1], 70.00))
+
+		merged, err := auction.MergeHistories(replica.History())
+		assert.NoError(t, err)
+		assert.Len(t, merged, 3)
+		assert.Equal(t, 70.00, merged[2].Amount, "the later bid lands last")
+		assert.Equal(t, 75.00, merged[2].PrevAmount, "it follows on from the earlier one")
+		assert.Equal(t, 50.00, merged[1].PrevAmount)
+	})
+
+	t.Run("Auto-raising replicas merge into one chain", func(t *testing.T) {
+		auction, replica, bidders := replicas(t, 5.00)
+		clock := auction.clock.(*manualClock)
+
+		assert.NoError(t, auction.PlaceBid(bidders[1], 70.00))
+		clock.Advance(time.Second)
+		assert.NoError(t, replica.PlaceBid(lookup(replica, bidders[2]), 80.00))
+
+		merged, err := auction.MergeHistories(replica.History())
+		assert.NoError(t, err)
+		again, err := replica.MergeHistories(auction.History())
+		assert.NoError(t, err)
+		assert.Equal(t, merged, again, "merging is symmetric")
+
+		last := map[uuid.UUID]BidEvent{}
+		for i, event := range merged {
+			if i > 0 {
+				assert.False(t, event.Time.Before(merged[i-1].Time), "events are in time order")
+			}
+			if prev, ok := last[event.BidderID]; ok {
+				assert.Equal(t, prev.Amount, event.PrevAmount, "event %d follows on from the bidder's previous one", i)
+				assert.True(t, prev.Time.Equal(event.PrevTime))
+			}
+			last[event.BidderID] = event
+		}
+		assert.Equal(t, 80.00, last[bidders[2].ID].Amount)
+		assert.Equal(t, 60.00, last[bidders[1].ID].Amount, "the replica's later auto-raise wins")
+
+		assert.NoError(t, auction.MergeReplica(replica.History()))
+		assert.Equal(t, merged, auction.History())
+		for _, bidder := range bidders {
+			assert.Equal(t, last[bidder.ID].Amount, bidder.CurrentBid)
+			assert.True(t, last[bidder.ID].Time.Equal(bidder.LastBidTime))
+		}
+		assert.Equal(t, bidders[2].ID, auction.Leader().ID)
+
+		assert.NoError(t, auction.UndoLastBid(bidders[2].ID))
+		assert.Equal(t, last[bidders[2].ID].PrevAmount, bidders[2].CurrentBid, "undo follows the merged chain")
+	})
+
+	t.Run("Merging a replica with unknown bidders fails", func(t *testing.T) {
+		auction, _, _ := replicas(t, 0)
+
+		other := auction.History()
+		other = append(other, BidEvent{EventID: uuid.New(), BidderID: uuid.New(), Amount: 70.00, Time: other[0].Time.Add(time.Second)})
+		assert.ErrorIs(t, auction.MergeReplica(other), ErrBidderNotFound)
+		assert.Len(t, auction.History(), 1, "the auction is left unchanged")
+	})
+
+	t.Run("Diverging copies of one event conflict", func(t *testing.T) {
+		auction, _, _ := replicas(t, 0)
+
+		other := auction.History()
+		other[0].Amount = 65.00
+		_, err := auction.MergeHistories(other)
+		assert.ErrorIs(t, err, ErrHistoryConflict)
+	})
+
+	t.Run("Identical histories merge to themselves", func(t *testing.T) {
+		auction, replica, _ := replicas(t, 0)
+
+		merged, err := auction.MergeHistories(replica.History())
+		assert.NoError(t, err)
+		assert.Equal(t, auction.History(), merged)
+	})
+}
@@ -0,0 +1,61 @@
+package dispatchbidder
+
+import "sync/atomic"
+
+// copy returns a copy of the bidder that shares no mutable state with it.
+func (b *Bidder) copy() *Bidder {
+	c := *b
+	c.owner = atomic.Value{}
+	c.Metadata = copyMetadata(b.Metadata)
+	c.IncrementSequence = append([]float64(nil), b.IncrementSequence...)
+	return &c
+}
+
+// assign sets the bidder's fields to those of from, keeping the auction the
+// bidder belongs to. Unlike assigning the struct, it leaves the owner alone,
+// which the accessors read without the lock. The caller must hold the lock.
+func (b *Bidder) assign(from *Bidder) {
+	b.ID = from.ID
+	b.Name = from.Name
+	b.StartingBid = from.StartingBid
+	b.MaxBid = from.MaxBid
+	b.CurrentBid = from.CurrentBid
+	b.AutoIncrement = from.AutoIncrement
+	b.LastBidTime = from.LastBidTime
+	b.IncrementSequence = from.IncrementSequence
+	b.ProxyMax = from.ProxyMax
+	b.DesiredQuantity = from.DesiredQuantity
+	b.WithdrawAboveMax = from.WithdrawAboveMax
+	b.StopCondition = from.StopCondition
+	b.ReactionDeadline = from.ReactionDeadline
+	b.ReactionTime = from.ReactionTime
+	b.IncrementDecay = from.IncrementDecay
+	b.Currency = from.Currency
+	b.OwnerID = from.OwnerID
+	b.Metadata = from.Metadata
+	b.seq = from.seq
+	b.nextTarget = from.nextTarget
+	b.bumps = from.bumps
+	b.manualBid = from.manualBid
+	b.rate = from.rate
+	b.frozen = from.frozen
+	b.retracted = from.retracted
+	b.withdrawn = from.withdrawn
+	b.disqualified = from.disqualified
+	b.disqualifyReason = from.disqualifyReason
+	b.sealedBid = from.sealedBid
+	b.sealedAt = from.sealedAt
+	b.abstained = from.abstained
+}
+
+// copyMetadata returns a copy of the metadata, or nil when there is none.
+func copyMetadata(m map[string]string) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	c := make(map[string]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
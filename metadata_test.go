@@ -0,0 +1,78 @@
+package dispatchbidder
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBidderMetadata tests that bidder metadata survives exports, clones and
+// snapshots without being shared.
+func TestBidderMetadata(t *testing.T) {
+	newAuction := func(t *testing.T) (*Auction, *Bidder) {
+		alice := createBidder("Alice", 50.00, 100.00, 5.00)
+		alice.Metadata = map[string]string{"customer_id": "C-42", "email": "alice@example.com"}
+		bob := createBidder("Bob", 60.00, 100.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+		return auction, alice
+	}
+
+	t.Run("JSON round trip", func(t *testing.T) {
+		auction, alice := newAuction(t)
+
+		data, err := json.Marshal(auction)
+		assert.NoError(t, err)
+		var decoded Auction
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+
+		bidder, ok := decoded.LookupBidder(alice.ID)
+		if assert.True(t, ok) {
+			assert.Equal(t, alice.Metadata, bidder.Metadata)
+		}
+	})
+
+	t.Run("CSV round trip", func(t *testing.T) {
+		auction, alice := newAuction(t)
+
+		var buf bytes.Buffer
+		assert.NoError(t, auction.ExportCSV(&buf))
+		bidders, err := ImportBiddersCSV(&buf)
+		assert.NoError(t, err)
+
+		for _, bidder := range bidders {
+			if bidder.ID == alice.ID {
+				assert.Equal(t, alice.Metadata, bidder.Metadata)
+			} else {
+				assert.Nil(t, bidder.Metadata)
+			}
+		}
+	})
+
+	t.Run("Clone and snapshot hold copies", func(t *testing.T) {
+		auction, alice := newAuction(t)
+
+		clone := auction.Clone()
+		view, ok := auction.Snapshot().Bidder(alice.ID)
+		assert.True(t, ok)
+		view.Metadata["email"] = "changed@example.com"
+
+		cloned, ok := clone.LookupBidder(alice.ID)
+		if assert.True(t, ok) {
+			assert.Equal(t, alice.Metadata, cloned.Metadata)
+			cloned.Metadata["customer_id"] = "C-43"
+		}
+		assert.Equal(t, map[string]string{"customer_id": "C-42", "email": "alice@example.com"}, alice.Metadata)
+	})
+
+	t.Run("Ignored by the auction logic", func(t *testing.T) {
+		auction, alice := newAuction(t)
+
+		assert.NoError(t, auction.PlaceBid(alice, 80.00))
+		assert.Equal(t, alice, auction.DetermineWinner())
+		assert.Equal(t, "C-42", auction.Leaderboard()[0].Metadata["customer_id"])
+	})
+}
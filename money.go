@@ -0,0 +1,38 @@
+package dispatchbidder
+
+import (
+	"fmt"
+	"math"
+)
+
+// MaxAmount is the largest amount an auction accepts, about $70 trillion:
+// 2^46 dollars. Below it float64 amounts lie at most 1/128 of a dollar
+// apart, so every cent stays distinct; above it the spacing reaches 1/64,
+// and cent increments would silently round away.
+const MaxAmount = float64(1 << 46)
+
+// checkAmount returns ErrMoneyOverflow unless the amount is a finite number
+// within MaxAmount of zero.
+func checkAmount(amount float64) error {
+	if math.IsNaN(amount) || math.Abs(amount) > MaxAmount {
+		return fmt.Errorf("%w: $%.2f is beyond $%.2f", ErrMoneyOverflow, amount, MaxAmount)
+	}
+	return nil
+}
+
+// namedAmount is an amount to validate with the name used in its error.
+type namedAmount struct {
+	name   string
+	amount float64
+}
+
+// amountErrors returns an error for every amount out of range, in order.
+func amountErrors(amounts ...namedAmount) []error {
+	var errs []error
+	for _, a := range amounts {
+		if err := checkAmount(a.amount); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", a.name, err))
+		}
+	}
+	return errs
+}
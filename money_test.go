@@ -0,0 +1,60 @@
+package dispatchbidder
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMoneyOverflow tests that amounts beyond MaxAmount are caught.
+func TestMoneyOverflow(t *testing.T) {
+	t.Run("Construction", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			modify func(na *NewAuctionConfig)
+		}{
+			{name: "Max bid", modify: func(na *NewAuctionConfig) { na.Bidders[0].MaxBid = MaxAmount * 2 }},
+			{name: "Infinite max bid", modify: func(na *NewAuctionConfig) { na.Bidders[0].MaxBid = math.Inf(1) }},
+			{name: "Auto-increment", modify: func(na *NewAuctionConfig) { na.Bidders[0].AutoIncrement = math.MaxFloat64 }},
+			{name: "Increment sequence", modify: func(na *NewAuctionConfig) { na.Bidders[0].IncrementSequence = []float64{1.00, MaxAmount + 1} }},
+			{name: "Min increment", modify: func(na *NewAuctionConfig) { na.MinIncrement = math.NaN() }},
+			{name: "Auction max bid", modify: func(na *NewAuctionConfig) { na.AuctionMaxBid = math.MaxFloat64 }},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				na := newTestConfig()
+				tt.modify(&na)
+				_, err := NewAuction(na)
+				assert.ErrorIs(t, err, ErrMoneyOverflow)
+			})
+		}
+	})
+
+	t.Run("Cents stay exact near the ceiling", func(t *testing.T) {
+		for c := 1; c <= 100; c++ {
+			amount := MaxAmount - float64(c)*cent
+			assert.InDelta(t, cent, (amount+cent)-amount, cent/2, "a cent below $%.2f", amount)
+		}
+	})
+
+	t.Run("At the boundary", func(t *testing.T) {
+		alice := createBidder("Alice", MaxAmount-1.00, MaxAmount, 0.01)
+		bob := createBidder("Bob", MaxAmount-1.00, MaxAmount, 0.01)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.PlaceBid(alice, MaxAmount-0.50))
+		assert.InDelta(t, MaxAmount-0.99, bob.CurrentBid, cent/2, "cent increments stay exact near the ceiling")
+		assert.ErrorIs(t, auction.PlaceBid(bob, MaxAmount+10.00), ErrMoneyOverflow)
+		assert.ErrorIs(t, auction.PlaceBid(bob, math.NaN()), ErrMoneyOverflow)
+		assert.ErrorIs(t, auction.PlaceBid(bob, math.Inf(1)), ErrMoneyOverflow)
+
+		_, err = auction.RunToCompletion()
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, alice.CurrentBid, MaxAmount)
+		assert.LessOrEqual(t, bob.CurrentBid, MaxAmount)
+	})
+}
@@ -0,0 +1,38 @@
+package dispatchbidder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Normalize puts the configuration in canonical form in place, so it can be
+// stored as is: bidder names are trimmed of surrounding whitespace, bidders
+// without an ID get a random one, a zero CurrentBid defaults to the
+// StartingBid and a zero LastBidTime to the current time. It then validates
+// the configuration as NewAuction does and returns the first error found.
+// The bidders are modified in place too.
+func (na *NewAuctionConfig) Normalize() error {
+	now := systemClock{}.Now()
+	for i, bidder := range na.Bidders {
+		if bidder == nil {
+			return fmt.Errorf("invalid auction data: bidder %d is nil", i)
+		}
+		bidder.Name = strings.TrimSpace(bidder.Name)
+		if bidder.ID == uuid.Nil {
+			bidder.ID = randomIDs{}.New()
+		}
+		if bidder.CurrentBid == 0 {
+			bidder.CurrentBid = bidder.StartingBid
+		}
+		if bidder.LastBidTime.IsZero() {
+			bidder.LastBidTime = now
+		}
+	}
+
+	if err := validateAuctionData(*na); err != nil {
+		return fmt.Errorf("invalid auction data: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package dispatchbidder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNormalize tests filling in the derived fields of a sparse config.
+func TestNormalize(t *testing.T) {
+	t.Run("Sparse config", func(t *testing.T) {
+		set := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+		keptID := uuid.New()
+		na := NewAuctionConfig{Bidders: []*Bidder{
+			{Name: "  Alice ", StartingBid: 50.00, MaxBid: 100.00, AutoIncrement: 5.00},
+			{ID: keptID, Name: "Bob", StartingBid: 60.00, MaxBid: 100.00, CurrentBid: 70.00, LastBidTime: set},
+		}}
+
+		before := time.Now()
+		assert.NoError(t, na.Normalize())
+
+		alice, bob := na.Bidders[0], na.Bidders[1]
+		assert.Equal(t, "Alice", alice.Name)
+		assert.NotEqual(t, uuid.Nil, alice.ID)
+		assert.Equal(t, 50.00, alice.CurrentBid)
+		assert.False(t, alice.LastBidTime.Before(before))
+
+		assert.Equal(t, keptID, bob.ID, "set fields are kept")
+		assert.Equal(t, 70.00, bob.CurrentBid)
+		assert.Equal(t, set, bob.LastBidTime)
+
+		normalized := *alice
+		assert.NoError(t, na.Normalize())
+		assert.Equal(t, normalized, *alice, "normalizing is idempotent")
+
+		_, err := NewAuction(na)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Invalid config", func(t *testing.T) {
+		na := NewAuctionConfig{Bidders: []*Bidder{
+			{Name: "Alice", StartingBid: 50.00, MaxBid: 40.00},
+			{Name: "Bob", StartingBid: 60.00, MaxBid: 100.00},
+		}}
+		assert.Error(t, na.Normalize())
+		assert.NotEqual(t, uuid.Nil, na.Bidders[0].ID, "fields are filled in before validating")
+
+		na.Bidders[0] = nil
+		assert.ErrorContains(t, na.Normalize(), "bidder 0 is nil")
+	})
+}
@@ -0,0 +1,67 @@
+package dispatchbidder
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Option configures optional behavior of an auction.
+type Option func(*Auction)
+
+// settings holds the optional behavior configured through Options. It is
+// plain data, copied as a whole when an auction is cloned.
+type settings struct {
+	clock            Clock
+	autoAlign        bool
+	noSelfOutbid     bool
+	tieEpsilon       time.Duration
+	undoRevertsBumps bool
+	seed             int64
+	rateLimited      bool
+	bidRate          rate.Limit
+	bidBurst         int
+	recencyWindow    time.Duration
+	recencyDecay     DecayFunc
+	allowEqualBids   bool
+	snipeWindow      time.Duration
+	snipeExtension   time.Duration
+	baseCurrency     string
+	rates            ExchangeRates
+	ids              IDGenerator
+	precision        map[string]int
+	hiddenReserve    float64
+	tieBreaks        []TieBreakFunc
+	closeProxies     bool
+	hardEndTime      time.Time
+	validators       []Validator
+	shuffleBumps     bool
+	incrementFunc    IncrementFunc
+}
+
+// WithAutoAlign makes PlaceBid round off-grid bids up to the nearest grid
+// point instead of rejecting them with ErrOffGrid. It has no effect unless
+// BidGridStep is set.
+func WithAutoAlign() Option {
+	return func(a *Auction) {
+		a.autoAlign = true
+	}
+}
+
+// WithNoSelfOutbid prevents auto-increments between paddles sharing the same
+// OwnerID, so a person bidding under several paddles cannot inflate their own
+// price. DetermineWinner still treats each paddle separately.
+func WithNoSelfOutbid() Option {
+	return func(a *Auction) {
+		a.noSelfOutbid = true
+	}
+}
+
+// WithAllowEqualBids lets PlaceBid accept a bid equal to the bidder's current
+// bid, which re-stamps their LastBidTime so the earliest-bid tie-break then
+// counts the repeated bid. Bids below the current bid are still rejected.
+func WithAllowEqualBids() Option {
+	return func(a *Auction) {
+		a.allowEqualBids = true
+	}
+}
@@ -0,0 +1,63 @@
+package dispatchbidder
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Participants returns the number of distinct bidders who placed at least one
+// manual bid, as recorded in the history.
+func (a *Auction) Participants() int {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.participants()
+}
+
+// VoidReason returns the reason the auction was voided, empty unless its
+// state is StateVoided.
+func (a *Auction) VoidReason() string {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.voidReason
+}
+
+// participants counts the distinct bidders with a manual bid in the history.
+// The caller must hold at least a read lock.
+func (a *Auction) participants() int {
+	seen := make(map[uuid.UUID]bool)
+	for _, event := range a.history {
+		if !event.Auto {
+			seen[event.BidderID] = true
+		}
+	}
+	return len(seen)
+}
+
+// finish ends the bidding at the given time, revealing any sealed bids, then
+// closing the auction, or voiding it when fewer than MinParticipants bidders
+// took part. The caller must hold the lock.
+func (a *Auction) finish(at time.Time) {
+	a.revealSealedBids()
+	if n := a.participants(); n < a.MinParticipants {
+		a.void(at, fmt.Sprintf("%d of %d required participants placed a bid", n, a.MinParticipants))
+		return
+	}
+	a.close(at)
+}
+
+// void transitions the auction to StateVoided at the given time for the given
+// reason, releasing its countdown and notifying subscribers. The caller must
+// hold the lock.
+func (a *Auction) void(at time.Time, reason string) {
+	a.state = StateVoided
+	a.voidReason = reason
+	a.closedAt = at
+	a.winner = nil
+	a.unpause(at)
+	a.releaseCountdown()
+	a.notify()
+}
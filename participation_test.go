@@ -0,0 +1,65 @@
+package dispatchbidder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMinParticipants tests voiding auctions without enough participation.
+func TestMinParticipants(t *testing.T) {
+	tests := []struct {
+		name           string
+		bidders        []string
+		expectedState  State
+		expectedWinner string
+	}{
+		{name: "Participation met", bidders: []string{"Alice", "Bob"}, expectedState: StateClosed, expectedWinner: "Bob"},
+		{name: "Participation not met", bidders: []string{"Alice", "Alice"}, expectedState: StateVoided},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Large increments keep auto-bumps out of the way; they would not
+			// count as participation anyway.
+			alice := createBidder("Alice", 50.00, 100.00, 500.00)
+			bob := createBidder("Bob", 50.00, 100.00, 500.00)
+			carol := createBidder("Carol", 50.00, 100.00, 500.00)
+			byName := map[string]*Bidder{"Alice": alice, "Bob": bob}
+
+			auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob, carol}, MinParticipants: 2})
+			assert.NoError(t, err)
+
+			for i, name := range tt.bidders {
+				assert.NoError(t, auction.PlaceBid(byName[name], 60.00+float64(i)*10))
+			}
+			_, err = auction.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedState, auction.State())
+
+			winner := auction.DetermineWinner()
+			if tt.expectedWinner == "" {
+				assert.Nil(t, winner)
+				assert.Nil(t, auction.Leader())
+				assert.Equal(t, "1 of 2 required participants placed a bid", auction.VoidReason())
+				assert.ErrorIs(t, auction.PlaceBid(bob, 90.00), ErrAuctionVoided)
+			} else {
+				assert.Equal(t, tt.expectedWinner, winner.Name)
+				assert.Empty(t, auction.VoidReason())
+			}
+		})
+	}
+
+	t.Run("Participants are counted from manual bids", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 100.00, 5.00)
+		bob := createBidder("Bob", 50.00, 100.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.PlaceBid(alice, 60.00))
+		assert.Equal(t, 1, auction.Participants(), "Bob's auto-increment does not count")
+		assert.NoError(t, auction.PlaceBid(bob, 70.00))
+		assert.Equal(t, 2, auction.Participants())
+	})
+}
@@ -0,0 +1,176 @@
+package dispatchbidder
+
+import (
+	"fmt"
+	"time"
+)
+
+// Pause suspends an open auction: bids are rejected with ErrAuctionPaused
+// and the countdown stops counting down until Resume is called. The hard end
+// time set with WithHardEndTime still closes a paused auction.
+func (a *Auction) Pause() error {
+	a.Lock()
+	defer a.Unlock()
+
+	if err := a.checkOpen(); err != nil {
+		return err
+	}
+	if a.paused {
+		return fmt.Errorf("%w: auction is already paused", ErrInvalidTransition)
+	}
+	a.paused = true
+	a.pausedAt = a.now()
+	a.wakeCountdown()
+	a.notify()
+
+	return nil
+}
+
+// Resume resumes a paused auction, pushing its end time back by the time
+// spent paused so the pause does not count against the bidding time, though
+// never past the hard end time.
+func (a *Auction) Resume() error {
+	a.Lock()
+	defer a.Unlock()
+
+	if err := a.checkOpen(); err != nil {
+		return err
+	}
+	if !a.paused {
+		return fmt.Errorf("%w: auction is not paused", ErrInvalidTransition)
+	}
+	if !a.endTime.IsZero() {
+		a.endTime = a.capEndTime(a.endTime.Add(a.now().Sub(a.pausedAt)))
+	}
+	a.unpause(a.now())
+	a.wakeCountdown()
+	a.notify()
+
+	return nil
+}
+
+// Paused reports whether the auction is paused.
+func (a *Auction) Paused() bool {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.paused
+}
+
+// Elapsed returns how long the auction has been open, as measured by the
+// auction clock, not counting the time spent paused. It stops growing once
+// the auction closes or is voided, and is zero before it opens.
+func (a *Auction) Elapsed() time.Duration {
+	a.RLock()
+	defer a.RUnlock()
+
+	if a.openedAt.IsZero() {
+		return 0
+	}
+	end := a.now()
+	if !a.closedAt.IsZero() {
+		end = a.closedAt
+	}
+	d := end.Sub(a.openedAt) - a.pausedFor
+	if a.paused {
+		d -= end.Sub(a.pausedAt)
+	}
+	return max(d, 0)
+}
+
+// Remaining returns how long until the scheduled end time, as measured by
+// the auction clock, frozen while the auction is paused. It is zero once the
+// end time passes, when the auction is not open or when it has no end time.
+func (a *Auction) Remaining() time.Duration {
+	a.RLock()
+	defer a.RUnlock()
+
+	if a.state != StateOpen || a.endTime.IsZero() {
+		return 0
+	}
+	now := a.now()
+	if a.paused {
+		now = a.pausedAt
+	}
+	return max(a.endTime.Sub(now), 0)
+}
+
+// unpause ends the current pause, if any, at the given time, adding it to
+// the time spent paused. The caller must hold the lock.
+func (a *Auction) unpause(at time.Time) {
+	if a.paused {
+		a.pausedFor += at.Sub(a.pausedAt)
+	}
+	a.paused = false
+	a.pausedAt = time.Time{}
+}
+
+// ExtendEndTime pushes the scheduled end time of an open auction back by d,
+// up to the hard end time, and notifies subscribers. A running countdown
+// picks up the new end time immediately.
+func (a *Auction) ExtendEndTime(d time.Duration) error {
+	a.Lock()
+	defer a.Unlock()
+
+	if err := a.checkOpen(); err != nil {
+		return err
+	}
+	if a.endTime.IsZero() {
+		return ErrNoEndTime
+	}
+	a.endTime = a.capEndTime(a.endTime.Add(d))
+	a.version++
+	a.wakeCountdown()
+	a.notify()
+
+	return nil
+}
+
+// WithAntiSnipe extends the auction against last-second sniping: a manual bid
+// accepted within window of the scheduled end time moves the end time to
+// extension after the bid, if that is later, but never past the hard end
+// time.
+func WithAntiSnipe(window, extension time.Duration) Option {
+	return func(a *Auction) {
+		a.snipeWindow = window
+		a.snipeExtension = extension
+	}
+}
+
+// extendForSnipe applies WithAntiSnipe after a manual bid at the given time.
+// The caller must hold the lock.
+func (a *Auction) extendForSnipe(at time.Time) {
+	if a.snipeWindow <= 0 || a.endTime.IsZero() || a.endTime.Sub(at) >= a.snipeWindow {
+		return
+	}
+	if end := a.capEndTime(at.Add(a.snipeExtension)); end.After(a.endTime) {
+		a.endTime = end
+		a.wakeCountdown()
+	}
+}
+
+// WithHardEndTime caps how late the auction can run: anti-sniping
+// extensions, ExtendEndTime and resuming after a pause never push the end
+// time past end, so a running countdown closes the auction at end at the
+// latest, and StartCountdown rejects a later end time.
+func WithHardEndTime(end time.Time) Option {
+	return func(a *Auction) {
+		a.hardEndTime = end
+	}
+}
+
+// HardEndTime returns the time set with WithHardEndTime, or the zero time.
+func (a *Auction) HardEndTime() time.Time {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.hardEndTime
+}
+
+// capEndTime limits end to the hard end time, if any.
+func (a *Auction) capEndTime(end time.Time) time.Time {
+	if !a.hardEndTime.IsZero() && end.After(a.hardEndTime) {
+		return a.hardEndTime
+	}
+	return end
+}
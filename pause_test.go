@@ -0,0 +1,199 @@
+package dispatchbidder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPauseResume tests that pauses and extensions adjust the end time.
+func TestPauseResume(t *testing.T) {
+	t.Run("Adjusted end time", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 100.00, 5.00)
+		bob := createBidder("Bob", 60.00, 100.00, 5.00)
+
+		clock := newManualClock()
+		start := clock.Now()
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, WithClock(clock), WithAntiSnipe(2*time.Minute, 3*time.Minute))
+		assert.NoError(t, err)
+		defer auction.StopCountdown()
+
+		assert.ErrorIs(t, auction.ExtendEndTime(time.Minute), ErrNoEndTime)
+		assert.NoError(t, auction.StartCountdown(start.Add(10*time.Minute)))
+
+		clock.Advance(4 * time.Minute)
+		assert.NoError(t, auction.Pause())
+		assert.True(t, auction.Paused())
+		assert.ErrorIs(t, auction.Pause(), ErrInvalidTransition)
+		assert.ErrorIs(t, auction.PlaceBid(alice, 70.00), ErrAuctionPaused)
+
+		clock.Advance(30 * time.Minute)
+		assert.NoError(t, auction.Resume())
+		assert.False(t, auction.Paused())
+		assert.ErrorIs(t, auction.Resume(), ErrInvalidTransition)
+		assert.Equal(t, start.Add(40*time.Minute), auction.EndTime(), "paused time does not count down")
+
+		version := auction.Snapshot().Version
+		assert.NoError(t, auction.ExtendEndTime(5*time.Minute))
+		assert.Equal(t, start.Add(45*time.Minute), auction.EndTime())
+		assert.Greater(t, auction.Snapshot().Version, version, "extending bumps the version")
+
+		// A bid outside the anti-snipe window leaves the end time alone.
+		clock.Advance(5 * time.Minute)
+		assert.NoError(t, auction.PlaceBid(alice, 70.00))
+		assert.Equal(t, start.Add(45*time.Minute), auction.EndTime())
+
+		clock.Advance(5 * time.Minute)
+		assert.NoError(t, auction.PlaceBid(alice, 80.00))
+		assert.Equal(t, start.Add(47*time.Minute), auction.EndTime(), "a late bid extends the auction")
+	})
+
+	t.Run("Countdown closes at the adjusted time", func(t *testing.T) {
+		auction, err := NewAuction(newTestConfig())
+		assert.NoError(t, err)
+
+		begin := time.Now()
+		assert.NoError(t, auction.StartCountdown(begin.Add(60*time.Millisecond)))
+		assert.NoError(t, auction.Pause())
+
+		time.Sleep(100 * time.Millisecond)
+		assert.Equal(t, StateOpen, auction.State(), "a paused countdown does not close the auction")
+
+		assert.NoError(t, auction.Resume())
+		assert.NoError(t, auction.ExtendEndTime(30*time.Millisecond))
+		end := auction.EndTime()
+		assert.False(t, end.Before(begin.Add(190*time.Millisecond)))
+
+		assert.Eventually(t, func() bool { return auction.State() == StateClosed }, 2*time.Second, time.Millisecond)
+		result, err := auction.GenerateResult()
+		assert.NoError(t, err)
+		assert.False(t, result.ClosedAt.Before(end), "closed at %s, before the end time %s", result.ClosedAt, end)
+	})
+
+	t.Run("Close while paused", func(t *testing.T) {
+		auction, err := NewAuction(newTestConfig())
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.Pause())
+		_, err = auction.Close()
+		assert.NoError(t, err)
+		assert.False(t, auction.Paused())
+		assert.ErrorIs(t, auction.Resume(), ErrAuctionClosed)
+	})
+}
+
+// TestHardEndTime tests that late bids stop extending the auction once they
+// reach the hard end time, where the countdown closes it.
+func TestHardEndTime(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 100.00, 5.00)
+	bob := createBidder("Bob", 60.00, 100.00, 5.00)
+
+	clock := newManualClock()
+	start := clock.Now()
+	hard := start.Add(14 * time.Minute)
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, WithClock(clock), WithAntiSnipe(2*time.Minute, 3*time.Minute), WithHardEndTime(hard))
+	assert.NoError(t, err)
+	defer auction.StopCountdown()
+	assert.Equal(t, hard, auction.HardEndTime())
+
+	assert.ErrorIs(t, auction.StartCountdown(hard.Add(time.Second)), ErrPastHardEndTime)
+	assert.NoError(t, auction.StartCountdown(start.Add(10*time.Minute)))
+
+	clock.Advance(9 * time.Minute)
+	assert.NoError(t, auction.PlaceBid(alice, 70.00))
+	assert.Equal(t, start.Add(12*time.Minute), auction.EndTime())
+
+	clock.Advance(2 * time.Minute)
+	assert.NoError(t, auction.PlaceBid(alice, 80.00))
+	assert.Equal(t, hard, auction.EndTime(), "extended up to the hard end time")
+
+	clock.Advance(2 * time.Minute)
+	assert.NoError(t, auction.PlaceBid(alice, 90.00))
+	assert.Equal(t, hard, auction.EndTime(), "no extension past the hard end time")
+	assert.NoError(t, auction.ExtendEndTime(time.Hour))
+	assert.Equal(t, hard, auction.EndTime())
+
+	clock.Advance(time.Minute)
+	assert.NoError(t, auction.ExtendEndTime(0)) // Wakes the countdown.
+	assert.Eventually(t, func() bool { return auction.State() == StateClosed }, time.Second, time.Millisecond)
+	result, err := auction.GenerateResult()
+	assert.NoError(t, err)
+	assert.Equal(t, hard, result.ClosedAt)
+
+	t.Run("Paused auctions close at the hard end time", func(t *testing.T) {
+		clock := newManualClock()
+		hard := clock.Now().Add(10 * time.Minute)
+		auction, err := NewAuction(newTestConfig(), WithClock(clock), WithHardEndTime(hard))
+		assert.NoError(t, err)
+		defer auction.StopCountdown()
+		assert.NoError(t, auction.StartCountdown(hard.Add(-time.Minute)))
+
+		clock.Advance(5 * time.Minute)
+		assert.NoError(t, auction.Pause())
+		clock.Advance(5 * time.Minute)
+		assert.NoError(t, auction.ExtendEndTime(0)) // Wakes the countdown.
+		assert.Eventually(t, func() bool { return auction.State() == StateClosed }, time.Second, time.Millisecond)
+		assert.False(t, auction.Paused())
+		result, err := auction.GenerateResult()
+		assert.NoError(t, err)
+		assert.Equal(t, hard, result.ClosedAt)
+	})
+
+	t.Run("Registry closes paused auctions at the hard end time", func(t *testing.T) {
+		clock := newManualClock()
+		hard := clock.Now().Add(10 * time.Minute)
+		registry := NewRegistry()
+		auction, err := registry.Create(newTestConfig(), WithClock(clock), WithHardEndTime(hard))
+		assert.NoError(t, err)
+		assert.NoError(t, auction.StartCountdown(hard))
+		auction.StopCountdown()
+		assert.NoError(t, auction.Pause())
+
+		ids, err := registry.CloseExpired(hard.Add(-time.Second))
+		assert.NoError(t, err)
+		assert.Empty(t, ids, "paused before the hard end time")
+		ids, err = registry.CloseExpired(hard)
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{auction.ID}, ids)
+	})
+}
+
+// TestElapsedRemaining tests the elapsed and remaining time across pauses
+// and extensions.
+func TestElapsedRemaining(t *testing.T) {
+	clock := newManualClock()
+	start := clock.Now()
+	auction, err := NewAuction(newTestConfig(), WithClock(clock))
+	assert.NoError(t, err)
+	defer auction.StopCountdown()
+
+	assert.Equal(t, time.Duration(0), auction.Remaining(), "no end time")
+	assert.NoError(t, auction.StartCountdown(start.Add(10*time.Minute)))
+
+	clock.Advance(3 * time.Minute)
+	assert.Equal(t, 3*time.Minute, auction.Elapsed())
+	assert.Equal(t, 7*time.Minute, auction.Remaining())
+
+	assert.NoError(t, auction.Pause())
+	clock.Advance(5 * time.Minute)
+	assert.Equal(t, 3*time.Minute, auction.Elapsed(), "paused time does not count")
+	assert.Equal(t, 7*time.Minute, auction.Remaining(), "frozen while paused")
+
+	assert.NoError(t, auction.Resume())
+	clock.Advance(time.Minute)
+	assert.Equal(t, 4*time.Minute, auction.Elapsed())
+	assert.Equal(t, 6*time.Minute, auction.Remaining())
+
+	assert.NoError(t, auction.ExtendEndTime(2*time.Minute))
+	assert.Equal(t, 8*time.Minute, auction.Remaining())
+
+	assert.NoError(t, auction.Pause())
+	clock.Advance(time.Minute)
+	_, err = auction.Close()
+	assert.NoError(t, err)
+	clock.Advance(time.Hour)
+	assert.Equal(t, 4*time.Minute, auction.Elapsed(), "stops at the close")
+	assert.Equal(t, time.Duration(0), auction.Remaining())
+}
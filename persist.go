@@ -0,0 +1,252 @@
+package dispatchbidder
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// persistErrorBuffer is the number of save errors PersistErrors buffers
+// before dropping new ones.
+const persistErrorBuffer = 16
+
+// Store persists auctions for crash recovery, as their MarshalJSON
+// encoding keyed by auction ID. It takes the encoding rather than an
+// AuctionSnapshot because a snapshot is a read-only view: it lacks the
+// configuration, history and lifecycle state LoadAuction needs to rebuild
+// the auction, and has no stable wire format to store. Implementations must
+// be safe for concurrent use.
+type Store interface {
+	// Save stores the encoding as the latest state of the auction.
+	Save(id uuid.UUID, data []byte) error
+	// Load returns the latest encoding saved for the auction.
+	Load(id uuid.UUID) ([]byte, error)
+}
+
+// persister saves the states of an auction to its store in order, on a
+// goroutine running only while saves are pending.
+type persister struct {
+	store Store
+	errs  chan error
+
+	mu      sync.Mutex
+	idle    *sync.Cond // Broadcast when the queue drains.
+	queue   []savedState
+	running bool
+}
+
+// savedState is a queued save: the encoding of the auction at a version.
+type savedState struct {
+	id      uuid.UUID
+	version uint64
+	data    []byte
+	done    chan<- error // Receives the outcome instead of PersistErrors, if set.
+}
+
+// WithAutoPersist saves the auction, encoded as by MarshalJSON, to the store
+// after every change: accepted bids, bidder updates and lifecycle
+// transitions. The encoding is taken under the auction lock, while the saves
+// run asynchronously, in order, and never block bidding; Flush waits for
+// pending saves and PersistErrors reports failed ones. LoadAuction
+// reconstructs an auction from the store.
+func WithAutoPersist(store Store) Option {
+	return func(a *Auction) {
+		p := &persister{store: store, errs: make(chan error, persistErrorBuffer)}
+		p.idle = sync.NewCond(&p.mu)
+		a.persister = p
+	}
+}
+
+// PersistErrors returns the channel receiving the errors of failed saves of
+// WithAutoPersist, or nil without auto-persistence. Errors are dropped while
+// the channel is full.
+func (a *Auction) PersistErrors() <-chan error {
+	a.RLock()
+	defer a.RUnlock()
+
+	if a.persister == nil {
+		return nil
+	}
+	return a.persister.errs
+}
+
+// persist queues the encoding of the auction for saving, if
+// auto-persistence is enabled. The caller must hold the lock.
+func (a *Auction) persist() {
+	if a.persister != nil {
+		a.save(nil)
+	}
+}
+
+// save queues the encoding of the auction for saving, after the saves
+// already queued. The outcome is sent to done, if not nil, and failures to
+// PersistErrors otherwise. The caller must hold the lock, and the auction
+// must have a persister.
+func (a *Auction) save(done chan<- error) {
+	data, err := json.Marshal(a.toJSON())
+	if err != nil {
+		a.persister.finish(done, fmt.Errorf("encoding auction %s version %d: %w", a.ID, a.version, err))
+		return
+	}
+	a.persister.enqueue(savedState{id: a.ID, version: a.version, data: data, done: done})
+}
+
+// saveNow saves the auction to the store of its persister, after the saves
+// already queued, and returns the outcome. It does nothing without
+// auto-persistence.
+func (a *Auction) saveNow() error {
+	done := make(chan error, 1)
+
+	a.Lock()
+	if a.persister == nil {
+		a.Unlock()
+		return nil
+	}
+	a.save(done)
+	a.Unlock()
+
+	return <-done
+}
+
+// persistTo enables auto-persistence to the store, as WithAutoPersist, and
+// saves the auction right away, disabling it again if that save fails. It
+// fails with ErrAutoPersisted if the auction already has auto-persistence.
+func (a *Auction) persistTo(store Store) error {
+	a.Lock()
+	if a.persister != nil {
+		a.Unlock()
+		return fmt.Errorf("%w: %s", ErrAutoPersisted, a.ID)
+	}
+	WithAutoPersist(store)(a)
+	p := a.persister
+	a.Unlock()
+
+	if err := a.saveNow(); err != nil {
+		a.Lock()
+		if a.persister == p {
+			a.persister = nil
+		}
+		a.Unlock()
+		return err
+	}
+	return nil
+}
+
+// stopPersisting disables auto-persistence and waits for the pending saves,
+// returning the persister to pass to resumePersisting, or nil if there was
+// none.
+func (a *Auction) stopPersisting() *persister {
+	a.Lock()
+	p := a.persister
+	a.persister = nil
+	a.Unlock()
+
+	if p != nil {
+		p.wait()
+	}
+	return p
+}
+
+// resumePersisting re-enables the auto-persistence disabled by
+// stopPersisting, saving the current state.
+func (a *Auction) resumePersisting(p *persister) {
+	if p == nil {
+		return
+	}
+
+	a.Lock()
+	defer a.Unlock()
+
+	a.persister = p
+	a.persist()
+}
+
+// enqueue queues the state, starting the save loop if needed.
+func (p *persister) enqueue(state savedState) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.queue = append(p.queue, state)
+	if !p.running {
+		p.running = true
+		go p.run()
+	}
+}
+
+// run saves queued states until the queue is empty.
+func (p *persister) run() {
+	for {
+		p.mu.Lock()
+		if len(p.queue) == 0 {
+			p.running = false
+			p.idle.Broadcast()
+			p.mu.Unlock()
+			return
+		}
+		state := p.queue[0]
+		p.queue = p.queue[1:]
+		p.mu.Unlock()
+
+		var err error
+		if err = p.store.Save(state.id, state.data); err != nil {
+			err = fmt.Errorf("saving auction %s version %d: %w", state.id, state.version, err)
+		}
+		p.finish(state.done, err)
+	}
+}
+
+// finish sends the outcome of a save to done, if not nil. Otherwise, a
+// failure is sent to PersistErrors, dropped when the channel is full.
+func (p *persister) finish(done chan<- error, err error) {
+	if done != nil {
+		done <- err
+		return
+	}
+	if err == nil {
+		return
+	}
+	select {
+	case p.errs <- err:
+	default:
+	}
+}
+
+// wait blocks until every queued state has been saved.
+func (p *persister) wait() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for p.running {
+		p.idle.Wait()
+	}
+}
+
+// LoadAuction reconstructs an auction from the latest state saved in the
+// store, decoded as by UnmarshalJSON: its configuration, bidders, history and
+// lifecycle state. The options are applied first, so those that are not
+// encoded, such as WithAutoPersist to keep persisting or WithExchangeRates,
+// are passed again and kept. No countdown is restarted.
+func LoadAuction(store Store, id uuid.UUID, opts ...Option) (*Auction, error) {
+	data, err := store.Load(id)
+	if err != nil {
+		return nil, fmt.Errorf("loading auction %s: %w", id, err)
+	}
+
+	return decodeAuction(id, data, opts...)
+}
+
+// decodeAuction reconstructs the auction from its encoding as LoadAuction
+// does.
+func decodeAuction(id uuid.UUID, data []byte, opts ...Option) (*Auction, error) {
+	a := &Auction{settings: settings{clock: systemClock{}, seed: defaultSeed(), ids: randomIDs{}}}
+	for _, opt := range opts {
+		opt(a)
+	}
+	if err := a.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("loading auction %s: %w", id, err)
+	}
+
+	return a, nil
+}
@@ -0,0 +1,176 @@
+package dispatchbidder
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryStore is a Store keeping every saved encoding in memory.
+type memoryStore struct {
+	mu    sync.Mutex
+	ids   []uuid.UUID
+	saves [][]byte
+	err   error
+}
+
+// Save records the encoding, or fails with the store error if set.
+func (s *memoryStore) Save(id uuid.UUID, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	s.ids = append(s.ids, id)
+	s.saves = append(s.saves, data)
+	return nil
+}
+
+// Load returns the latest encoding saved for the auction.
+func (s *memoryStore) Load(id uuid.UUID) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := len(s.saves) - 1; i >= 0; i-- {
+		if s.ids[i] == id {
+			return s.saves[i], nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+// saved decodes the nth saved encoding.
+func (s *memoryStore) saved(t *testing.T, n int) *Auction {
+	var a Auction
+	assert.NoError(t, json.Unmarshal(s.saves[n], &a))
+	return &a
+}
+
+// TestAutoPersist tests saving snapshots on every change and reloading them.
+func TestAutoPersist(t *testing.T) {
+	t.Run("Persists every change and reloads the winner", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 80.00, 3.00)
+		bob := createBidder("Bob", 60.00, 82.00, 2.00)
+		carol := createBidder("Carol", 55.00, 85.00, 5.00)
+
+		store := &memoryStore{}
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob, carol}}, WithAutoPersist(store))
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.PlaceBid(alice, 70.00))
+		assert.NoError(t, auction.PlaceBid(carol, 75.00))
+		assert.NoError(t, auction.FreezeBidder(bob.ID))
+		_, err = auction.Close()
+		assert.NoError(t, err)
+		assert.NoError(t, auction.Flush())
+
+		if assert.Len(t, store.saves, 4, "one save per change") {
+			for i, version := range []uint64{1, 2, 3, 3} {
+				assert.Equal(t, version, store.saved(t, i).Version())
+			}
+			assert.Equal(t, StateClosed, store.saved(t, 3).State())
+			assert.Len(t, store.saved(t, 3).History(), len(auction.History()), "bumps are included")
+		}
+
+		loaded, err := LoadAuction(store, auction.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, auction.ID, loaded.ID)
+		assert.Equal(t, StateClosed, loaded.State())
+		assert.Equal(t, auction.DetermineWinner().ID, loaded.DetermineWinner().ID)
+		assert.Equal(t, bidderNames(auction), bidderNames(loaded))
+
+		view, _ := loaded.Snapshot().Bidder(bob.ID)
+		assert.Equal(t, StatusFrozen, view.Status)
+	})
+
+	t.Run("Reloaded auctions keep bidding and persisting", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 80.00, 3.00)
+		bob := createBidder("Bob", 60.00, 82.00, 2.00)
+
+		store := &memoryStore{}
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, WithAutoPersist(store))
+		assert.NoError(t, err)
+		assert.NoError(t, auction.PlaceBid(alice, 65.00))
+		assert.NoError(t, auction.Flush())
+
+		loaded, err := LoadAuction(store, auction.ID, WithAutoPersist(store))
+		assert.NoError(t, err)
+		assert.Equal(t, "Alice", loaded.Leader().Name)
+
+		loadedBob, _ := loaded.LookupBidder(bob.ID)
+		assert.NoError(t, loaded.PlaceBid(loadedBob, 75.00))
+		assert.NoError(t, loaded.Flush())
+		assert.Len(t, store.saves, 2)
+		assert.Equal(t, uint64(2), store.saved(t, 1).Version())
+	})
+
+	t.Run("Reloaded auctions keep their configuration", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 100.00, 5.00)
+		alice.ProxyMax = 90.00
+		alice.IncrementDecay = 0.1
+		bob := createBidder("Bob", 60.00, 100.00, 5.00)
+
+		clock := newManualClock()
+		store := &memoryStore{}
+		config := NewAuctionConfig{
+			Bidders:       []*Bidder{alice, bob},
+			AuctionMaxBid: 95.00,
+			ReservePrice:  70.00,
+			MinDuration:   time.Hour,
+			MaxTotalBids:  10,
+		}
+		auction, err := NewAuction(config, WithClock(clock), WithAutoPersist(store))
+		assert.NoError(t, err)
+		assert.NoError(t, auction.StartCountdown(clock.Now().Add(2*time.Hour)))
+		defer auction.StopCountdown()
+		clock.Advance(time.Minute)
+		assert.NoError(t, auction.PlaceBid(bob, 75.00))
+		assert.NoError(t, auction.Flush())
+
+		loaded, err := LoadAuction(store, auction.ID, WithClock(clock))
+		assert.NoError(t, err)
+		assert.Equal(t, 95.00, loaded.AuctionMaxBid)
+		assert.Equal(t, 70.00, loaded.ReservePrice)
+		assert.Equal(t, 10, loaded.MaxTotalBids)
+		assert.True(t, auction.EndTime().Equal(loaded.EndTime()))
+		if assert.Len(t, loaded.History(), len(auction.History())) {
+			for i, event := range auction.History() {
+				assert.Equal(t, event.EventID, loaded.History()[i].EventID)
+			}
+		}
+		assert.False(t, loaded.IsFinal(), "MinDuration counts from the original opening")
+		loadedAlice, _ := loaded.LookupBidder(alice.ID)
+		assert.Equal(t, 90.00, loadedAlice.ProxyMax)
+		assert.Equal(t, alice.AutoIncrement, loadedAlice.AutoIncrement)
+
+		clock.Advance(time.Hour)
+		assert.True(t, loaded.IsFinal())
+	})
+
+	t.Run("Save errors are reported", func(t *testing.T) {
+		store := &memoryStore{err: errors.New("disk full")}
+		auction, err := NewAuction(newTestConfig(), WithAutoPersist(store))
+		assert.NoError(t, err)
+
+		_, err = auction.Close()
+		assert.NoError(t, err)
+		assert.NoError(t, auction.Flush())
+		select {
+		case err := <-auction.PersistErrors():
+			assert.ErrorContains(t, err, "disk full")
+		default:
+			t.Fatal("expected a save error")
+		}
+	})
+
+	t.Run("Missing auction", func(t *testing.T) {
+		_, err := LoadAuction(&memoryStore{}, uuid.New())
+		assert.ErrorContains(t, err, "not found")
+	})
+}
@@ -0,0 +1,128 @@
+package dispatchbidder
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// defaultPrecision is the number of decimal places of currencies missing from
+// currencyPrecision.
+const defaultPrecision = 2
+
+// currencyPrecision holds the number of decimal places of the currencies
+// whose minor unit is not the cent, by ISO 4217 code.
+var currencyPrecision = map[string]int{
+	"BHD": 3,
+	"CLP": 0,
+	"IQD": 3,
+	"ISK": 0,
+	"JOD": 3,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"OMR": 3,
+	"TND": 3,
+	"VND": 0,
+}
+
+// WithCurrencyPrecision sets the number of decimal places bids in the
+// currency may have, overriding the built-in table, under which currencies
+// use two decimal places unless their minor unit says otherwise (JPY and KRW
+// use none, KWD three).
+func WithCurrencyPrecision(currency string, places int) Option {
+	return func(a *Auction) {
+		precision := make(map[string]int, len(a.precision)+1)
+		for c, p := range a.precision {
+			precision[c] = p
+		}
+		precision[currency] = places
+		a.precision = precision
+	}
+}
+
+// Precision returns the number of decimal places bids in the currency may
+// have.
+func (a *Auction) Precision(currency string) int {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.currencyPlaces(currency)
+}
+
+// currencyPlaces returns the number of decimal places of the currency. The
+// caller must hold at least a read lock.
+func (a *Auction) currencyPlaces(currency string) int {
+	if places, ok := a.precision[currency]; ok {
+		return places
+	}
+	if places, ok := currencyPrecision[currency]; ok {
+		return places
+	}
+	return defaultPrecision
+}
+
+// bidCurrency returns the currency the bidder bids in: their Currency, or
+// else the base currency, empty when neither is set. The caller must hold at
+// least a read lock.
+func (a *Auction) bidCurrency(bidder *Bidder) string {
+	if bidder.Currency != "" {
+		return bidder.Currency
+	}
+	return a.baseCurrency
+}
+
+// checkPrecision returns ErrInvalidPrecision if the amount has more decimal
+// places than the currency the bidder bids in: their Currency, or else the
+// base currency. Bids in no currency at all are not checked. The caller must
+// hold at least a read lock.
+func (a *Auction) checkPrecision(bidder *Bidder, amount float64) error {
+	currency := a.bidCurrency(bidder)
+	if currency == "" {
+		return nil
+	}
+
+	places := a.currencyPlaces(currency)
+	scaled := amount * math.Pow10(places)
+	if math.Abs(scaled-math.Round(scaled)) > gridTolerance*max(1, math.Abs(scaled)) {
+		return fmt.Errorf("%w: bid amount %s has more than %d decimal places for %s", ErrInvalidPrecision, strconv.FormatFloat(amount, 'f', -1, 64), places, currency)
+	}
+	return nil
+}
+
+// PlaceBidString places a bid given as a decimal string, like "100.50", as
+// received from forms and APIs. The string is checked against the precision
+// of the bidder's currency digit by digit, so "100.5" is rejected for JPY
+// even where float rounding would hide it; trailing zeros do not count.
+func (a *Auction) PlaceBidString(bidder *Bidder, amount string) error {
+	bidAmount, err := strconv.ParseFloat(strings.TrimSpace(amount), 64)
+	if err != nil || math.IsInf(bidAmount, 0) || math.IsNaN(bidAmount) {
+		return fmt.Errorf("invalid bid amount %q", amount)
+	}
+
+	a.RLock()
+	currency := a.bidCurrency(bidder)
+	places := a.currencyPlaces(currency)
+	a.RUnlock()
+
+	if currency != "" && decimalPlaces(amount) > places {
+		return fmt.Errorf("%w: bid amount %s has more than %d decimal places for %s", ErrInvalidPrecision, strings.TrimSpace(amount), places, currency)
+	}
+
+	return a.PlaceBid(bidder, bidAmount)
+}
+
+// decimalPlaces returns the number of significant digits after the decimal
+// point of a decimal string.
+func decimalPlaces(amount string) int {
+	amount = strings.TrimSpace(amount)
+	if i := strings.IndexAny(amount, "eE"); i >= 0 {
+		amount = amount[:i]
+	}
+	i := strings.IndexByte(amount, '.')
+	if i < 0 {
+		return 0
+	}
+	return len(strings.TrimRight(amount[i+1:], "0"))
+}
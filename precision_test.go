@@ -0,0 +1,78 @@
+package dispatchbidder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCurrencyPrecision tests validating bid amounts against the decimal
+// places of the bidder's currency.
+func TestCurrencyPrecision(t *testing.T) {
+	newAuction := func(t *testing.T, currency string, opts ...Option) (*Auction, *Bidder) {
+		bidder := createBidder("Alice", 50.00, 1000.00, 0)
+		bidder.Currency = currency
+		other := createBidder("Bob", 50.00, 1000.00, 0)
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{bidder, other}}, opts...)
+		assert.NoError(t, err)
+		return auction, bidder
+	}
+
+	tests := []struct {
+		name     string
+		currency string
+		amount   string
+		err      error
+	}{
+		{"JPY rejects decimals", "JPY", "100.5", ErrInvalidPrecision},
+		{"JPY accepts whole amounts", "JPY", "100", nil},
+		{"JPY ignores trailing zeros", "JPY", "100.00", nil},
+		{"USD accepts cents", "USD", "100.50", nil},
+		{"USD rejects fractions of a cent", "USD", "100.505", ErrInvalidPrecision},
+		{"KWD accepts fils", "KWD", "100.505", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auction, bidder := newAuction(t, tt.currency)
+
+			err := auction.PlaceBidString(bidder, tt.amount)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				assert.Empty(t, auction.History())
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, auction.History(), 1)
+			}
+		})
+	}
+
+	t.Run("PlaceBid validates float amounts", func(t *testing.T) {
+		auction, bidder := newAuction(t, "JPY")
+
+		assert.ErrorIs(t, auction.PlaceBid(bidder, 100.5), ErrInvalidPrecision)
+		assert.NoError(t, auction.PlaceBid(bidder, 101))
+	})
+
+	t.Run("Base currency applies to bidders without a currency", func(t *testing.T) {
+		auction, bidder := newAuction(t, "", WithExchangeRates("JPY", FixedRates{}))
+
+		assert.ErrorIs(t, auction.PlaceBid(bidder, 100.5), ErrInvalidPrecision)
+	})
+
+	t.Run("Precision can be overridden", func(t *testing.T) {
+		auction, bidder := newAuction(t, "USD", WithCurrencyPrecision("USD", 0))
+
+		assert.Equal(t, 0, auction.Precision("USD"))
+		assert.Equal(t, 0, auction.Precision("JPY"))
+		assert.Equal(t, 2, auction.Precision("EUR"))
+		assert.ErrorIs(t, auction.PlaceBidString(bidder, "100.50"), ErrInvalidPrecision)
+	})
+
+	t.Run("Malformed amounts are rejected", func(t *testing.T) {
+		auction, bidder := newAuction(t, "USD")
+
+		assert.Error(t, auction.PlaceBidString(bidder, "ten"))
+		assert.Empty(t, auction.History())
+	})
+}
@@ -0,0 +1,67 @@
+package dispatchbidder
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxSimulatedDelay bounds the random reaction delay between simulated bids.
+const maxSimulatedDelay = time.Second
+
+// simulatedClock is a Clock for simulations that moves forward by a random
+// delay every time it is read.
+type simulatedClock struct {
+	now time.Time
+	rng *rand.Rand
+}
+
+// Now returns the current simulated time and advances the clock.
+func (c *simulatedClock) Now() time.Time {
+	now := c.now
+	c.now = c.now.Add(time.Duration(c.rng.Int63n(int64(maxSimulatedDelay))))
+	return now
+}
+
+// WinProbabilities estimates each bidder's chance of winning with a Monte
+// Carlo simulation. Each of the trials runs a clone of the auction to
+// completion with a randomized bidding order and random reaction delays, so
+// ties resolve differently between trials. The same seed always yields the
+// same result. The live auction is not modified.
+func (a *Auction) WinProbabilities(trials int, seed int64) map[uuid.UUID]float64 {
+	a.RLock()
+	base := a.clone()
+	start := a.now()
+	a.RUnlock()
+	base.rateLimited = false
+	base.validators = nil
+
+	probabilities := make(map[uuid.UUID]float64, len(base.Bidders))
+	for _, bidder := range base.Bidders {
+		probabilities[bidder.ID] = 0
+	}
+	if trials <= 0 {
+		return probabilities
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	for i := 0; i < trials; i++ {
+		sim := base.Clone()
+		sim.clock = &simulatedClock{now: start, rng: rng}
+
+		winner, _, err := sim.runToCompletion(context.Background(), func(bidders []*Bidder) {
+			rng.Shuffle(len(bidders), func(i, j int) { bidders[i], bidders[j] = bidders[j], bidders[i] })
+		})
+		if err == nil && winner != nil {
+			probabilities[winner.ID]++
+		}
+	}
+
+	for id := range probabilities {
+		probabilities[id] /= float64(trials)
+	}
+
+	return probabilities
+}
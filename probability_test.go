@@ -0,0 +1,50 @@
+package dispatchbidder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWinProbabilities tests the Monte Carlo win estimation.
+func TestWinProbabilities(t *testing.T) {
+	t.Run("Dominant MaxBid wins every trial", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 80.00, 3.00)
+		bob := createBidder("Bob", 60.00, 82.00, 2.00)
+		carol := createBidder("Carol", 55.00, 500.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob, carol}})
+		assert.NoError(t, err)
+		before := auction.Snapshot()
+
+		probabilities := auction.WinProbabilities(50, 1)
+		assert.InDelta(t, 1.0, probabilities[carol.ID], 0.0001)
+		assert.InDelta(t, 0.0, probabilities[alice.ID], 0.0001)
+		assert.InDelta(t, 0.0, probabilities[bob.ID], 0.0001)
+
+		after := auction.Snapshot()
+		assert.Equal(t, before.Bidders, after.Bidders, "the live auction is not mutated")
+		assert.Equal(t, before.Version, after.Version)
+		assert.Empty(t, auction.History())
+	})
+
+	t.Run("Seeded runs are reproducible", func(t *testing.T) {
+		// Equal ceilings leave the outcome to the randomized tie-breaks.
+		alice := createBidder("Alice", 50.00, 100.00, 5.00)
+		bob := createBidder("Bob", 50.00, 100.00, 5.00)
+		carol := createBidder("Carol", 50.00, 100.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob, carol}})
+		assert.NoError(t, err)
+
+		first := auction.WinProbabilities(200, 42)
+		second := auction.WinProbabilities(200, 42)
+		assert.Equal(t, first, second)
+
+		total := 0.0
+		for _, p := range first {
+			total += p
+		}
+		assert.InDelta(t, 1.0, total, 0.0001)
+	})
+}
@@ -0,0 +1,208 @@
+package dispatchbidder
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ResolveProxies settles proxy bidding in one step, as if every bidder's
+// proxy had raised against the others increment by increment: the bidder
+// with the highest ceiling (ProxyMax or MaxBid, limited by the auction cap)
+// leads at the lowest price that beats the runner-up's ceiling by one
+// increment and meets the ReservePrice, but never above their own ceiling.
+// Every other bidder ends at their ceiling. Equal ceilings are broken as in
+// DetermineWinner, WithTieBreakChain included. The increment is the one of
+// the increment table at the runner-up's ceiling, otherwise MinIncrement,
+// otherwise the leader's AutoIncrement.
+//
+// It returns the leader, or ErrReserveNotMet, leaving the auction unchanged,
+// when no ceiling reaches the reserve, and ErrSealedAuction in sealed-bid
+// modes. The auction stays open.
+func (a *Auction) ResolveProxies() (*Bidder, error) {
+	a.Lock()
+	defer a.Unlock()
+
+	if err := a.checkOpen(); err != nil {
+		return nil, err
+	}
+	if a.Mode.sealed() {
+		return nil, fmt.Errorf("%w: sealed bids have no proxies to resolve", ErrSealedAuction)
+	}
+
+	return a.resolveProxies()
+}
+
+// WithCloseProxyResolution makes every bidder's MaxBid a standing proxy
+// settled when the countdown reaches the end time: right before closing, the
+// proxies are resolved as by ResolveProxies, so the leader wins at the lowest
+// price beating the runner-up's ceiling, a second-price-style outcome, rather
+// than at whatever the bidding left them. Closing with Close does not resolve
+// them, and neither does a countdown whose ceilings miss the ReservePrice.
+// It has no effect in sealed-bid modes.
+func WithCloseProxyResolution() Option {
+	return func(a *Auction) {
+		a.closeProxies = true
+	}
+}
+
+// resolveProxies settles proxy bidding as ResolveProxies does. The caller
+// must hold the lock.
+func (a *Auction) resolveProxies() (*Bidder, error) {
+	candidates := a.proxyCandidates(a.ceiling)
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	leader := candidates[0]
+	if a.ceiling(leader) < a.ReservePrice {
+		return nil, fmt.Errorf("%w: highest ceiling $%.2f is below the reserve price", ErrReserveNotMet, a.ceiling(leader))
+	}
+
+	var runnerUp *Bidder
+	if len(candidates) > 1 {
+		runnerUp = candidates[1]
+	}
+
+	// The leader is applied first, so on equal amounts their earlier
+	// sequence number keeps them ahead.
+	now := a.now()
+	applied := false
+	if price := max(a.proxyPrice(leader, runnerUp, a.ceiling), leader.CurrentBid); price > leader.CurrentBid {
+		a.applyBid(leader, price, leader.rate, now, true, 0)
+		applied = true
+	}
+	for _, bidder := range candidates[1:] {
+		if ceiling := a.ceiling(bidder); ceiling > bidder.CurrentBid {
+			a.applyBid(bidder, ceiling, bidder.rate, now, true, 0)
+			applied = true
+		}
+	}
+	if applied {
+		a.version++
+		a.notify()
+	}
+
+	return leader, nil
+}
+
+// EquilibriumOutcome returns the theoretical outcome of the auction if every
+// bidder bid truthfully up to their MaxBid, limited by the auction cap: the
+// highest MaxBid, compared in the base currency, wins at the runner-up's
+// MaxBid plus one increment, or the ReservePrice if higher, limited to the
+// winner's MaxBid and never below their CurrentBid. It is computed in closed
+// form without changing the auction, to compare against what the bidding or
+// ResolveProxies produced. Unlike ResolveProxies, it ignores ProxyMax, since a
+// truthful bidder goes up to their MaxBid. There is no winner when nobody can
+// bid or the highest MaxBid misses the reserve.
+func (a *Auction) EquilibriumOutcome() (winner *Bidder, price float64) {
+	a.RLock()
+	defer a.RUnlock()
+
+	candidates := a.proxyCandidates(func(b *Bidder) float64 { return b.toBase(a.truthfulLimit(b)) })
+	if len(candidates) == 0 || a.truthfulLimit(candidates[0]) < a.ReservePrice {
+		return nil, 0
+	}
+
+	winner = candidates[0]
+	price = max(a.ReservePrice, winner.StartingBid)
+	if len(candidates) > 1 {
+		runnerUp := candidates[1]
+		rival := winner.fromBase(runnerUp.toBase(a.truthfulLimit(runnerUp)))
+		price = max(price, rival+a.proxyIncrement(winner, rival))
+	}
+	price = min(a.alignToGrid(winner, price), a.truthfulLimit(winner))
+
+	return winner, max(price, winner.CurrentBid)
+}
+
+// truthfulLimit returns the bidder's MaxBid limited by the auction cap.
+func (a *Auction) truthfulLimit(b *Bidder) float64 {
+	if !a.withinCap(b.MaxBid) {
+		return a.AuctionMaxBid
+	}
+	return b.MaxBid
+}
+
+// proxyCandidates returns the bidders who can bid, by decreasing level, with
+// ties broken as in DetermineWinner. The caller must hold at least a read
+// lock.
+func (a *Auction) proxyCandidates(level func(*Bidder) float64) []*Bidder {
+	var candidates []*Bidder
+	for _, bidder := range a.Bidders {
+		if bidder.canBid() {
+			candidates = append(candidates, bidder)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ci, cj := level(candidates[i]), level(candidates[j])
+		return ci > cj || (ci == cj && a.breaksTie(candidates[i], candidates[j]))
+	})
+
+	return candidates
+}
+
+// WinnerPaysMinimal reports whether the proxy math holds for the current
+// leader: their CurrentBid equals the runner-up's CurrentBid plus one
+// increment, or the ReservePrice if higher, limited to their MaxBid, and it
+// never exceeds their MaxBid. It is an invariant check for the state left by
+// ResolveProxies; a manual overbid breaks it. An auction without a leader
+// trivially satisfies it.
+func (a *Auction) WinnerPaysMinimal() bool {
+	a.RLock()
+	defer a.RUnlock()
+
+	ranked := a.rankBidders()
+	if len(ranked) == 0 {
+		return true
+	}
+
+	winner := ranked[0]
+	var runnerUp *Bidder
+	if len(ranked) > 1 {
+		runnerUp = ranked[1]
+	}
+
+	current := func(b *Bidder) float64 { return b.CurrentBid }
+	expected := a.proxyPrice(winner, runnerUp, current)
+
+	return winner.CurrentBid <= winner.MaxBid+gridTolerance &&
+		math.Abs(winner.CurrentBid-expected) < gridTolerance
+}
+
+// proxyPrice returns the lowest price at which the leader beats the
+// runner-up, whose standing is given by level, and meets the reserve,
+// aligned to the grid and limited to the leader's ceiling. The caller must
+// hold at least a read lock.
+func (a *Auction) proxyPrice(leader, runnerUp *Bidder, level func(*Bidder) float64) float64 {
+	price := max(a.ReservePrice, leader.StartingBid)
+	if runnerUp != nil {
+		rival := level(runnerUp)
+		price = max(price, rival+a.proxyIncrement(leader, rival))
+	}
+
+	return min(a.alignToGrid(leader, price), a.ceiling(leader))
+}
+
+// proxyIncrement returns the increment a proxy raises by at the given price.
+// The caller must hold at least a read lock.
+func (a *Auction) proxyIncrement(leader *Bidder, price float64) float64 {
+	switch {
+	case len(a.IncrementTable) > 0:
+		return a.IncrementTable.Increment(price)
+	case a.MinIncrement > 0:
+		return a.MinIncrement
+	default:
+		return leader.increment()
+	}
+}
+
+// ceiling returns the highest amount the bidder's proxy may reach: their
+// ProxyMax (or MaxBid), limited by the auction cap.
+func (a *Auction) ceiling(b *Bidder) float64 {
+	if !a.withinCap(b.proxyLimit()) {
+		return a.AuctionMaxBid
+	}
+	return b.proxyLimit()
+}
@@ -0,0 +1,191 @@
+package dispatchbidder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestResolveProxies tests proxy resolution and the WinnerPaysMinimal
+// invariant across configurations.
+func TestResolveProxies(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         NewAuctionConfig
+		bidders        []*Bidder
+		expectedWinner string
+		expectedPrice  float64
+	}{
+		{
+			name: "Runner-up plus the leader's increment",
+			bidders: []*Bidder{
+				createBidder("Alice", 50.00, 80.00, 3.00),
+				createBidder("Bob", 60.00, 120.00, 2.00),
+			},
+			expectedWinner: "Bob",
+			expectedPrice:  82.00,
+		},
+		{
+			name:   "Reserve above the runner-up",
+			config: NewAuctionConfig{ReservePrice: 100.00},
+			bidders: []*Bidder{
+				createBidder("Alice", 50.00, 80.00, 3.00),
+				createBidder("Bob", 60.00, 120.00, 2.00),
+			},
+			expectedWinner: "Bob",
+			expectedPrice:  100.00,
+		},
+		{
+			name:   "Increment table band",
+			config: NewAuctionConfig{IncrementTable: IncrementTable{{UpTo: 50.00, Increment: 1.00}, {Increment: 5.00}}},
+			bidders: []*Bidder{
+				createBidder("Alice", 10.00, 60.00, 1.00),
+				createBidder("Bob", 10.00, 90.00, 1.00),
+				createBidder("Carol", 10.00, 40.00, 1.00),
+			},
+			expectedWinner: "Bob",
+			expectedPrice:  65.00,
+		},
+		{
+			name: "Close ceilings limit the price to the leader's MaxBid",
+			bidders: []*Bidder{
+				createBidder("Alice", 50.00, 99.00, 5.00),
+				createBidder("Bob", 60.00, 100.00, 5.00),
+			},
+			expectedWinner: "Bob",
+			expectedPrice:  100.00,
+		},
+		{
+			name:   "Auction cap",
+			config: NewAuctionConfig{AuctionMaxBid: 90.00, MinIncrement: 2.00},
+			bidders: []*Bidder{
+				createBidder("Alice", 50.00, 85.00, 3.00),
+				createBidder("Bob", 60.00, 200.00, 2.00),
+			},
+			expectedWinner: "Bob",
+			expectedPrice:  87.00,
+		},
+		{
+			name:   "Grid alignment",
+			config: NewAuctionConfig{BidGridStep: 5.00},
+			bidders: []*Bidder{
+				createBidder("Alice", 50.00, 80.00, 3.00),
+				createBidder("Bob", 50.00, 120.00, 2.00),
+			},
+			expectedWinner: "Bob",
+			expectedPrice:  85.00,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.Bidders = tt.bidders
+			auction, err := NewAuction(tt.config)
+			assert.NoError(t, err)
+			winner, price := auction.EquilibriumOutcome()
+			assert.Equal(t, uint64(0), auction.Version(), "the closed form changes nothing")
+
+			leader, err := auction.ResolveProxies()
+			assert.NoError(t, err)
+			assert.Equal(t, winner, leader, "closed form matches the resolution")
+			assert.InDelta(t, price, leader.CurrentBid, 1e-9)
+			assert.Equal(t, tt.expectedWinner, leader.Name)
+			assert.Equal(t, leader, auction.DetermineWinner())
+			assert.InDelta(t, tt.expectedPrice, leader.CurrentBid, 1e-9)
+			assert.LessOrEqual(t, leader.CurrentBid, leader.MaxBid)
+			assert.True(t, auction.WinnerPaysMinimal())
+		})
+	}
+
+	t.Run("Equilibrium bids up to MaxBid", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 100.00, 5.00)
+		alice.ProxyMax = 70.00
+		bob := createBidder("Bob", 60.00, 90.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+
+		winner, price := auction.EquilibriumOutcome()
+		assert.Equal(t, alice, winner, "ProxyMax does not bound a truthful bidder")
+		assert.Equal(t, 95.00, price)
+	})
+
+	t.Run("Equilibrium compares in the base currency", func(t *testing.T) {
+		carol := createBidder("Carol", 10.00, 60.00, 5.00)
+		carol.Currency = "EUR"
+		dave := createBidder("Dave", 10.00, 100.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{carol, dave}}, WithExchangeRates("USD", FixedRates{"EUR": 2}))
+		assert.NoError(t, err)
+
+		winner, price := auction.EquilibriumOutcome()
+		assert.Equal(t, carol, winner, "60 EUR is worth 120 USD")
+		assert.Equal(t, 55.00, price, "100 USD is 50 EUR, plus the increment")
+	})
+
+	t.Run("Reserve not met", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 80.00, 3.00)
+		bob := createBidder("Bob", 60.00, 90.00, 2.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, ReservePrice: 100.00})
+		assert.NoError(t, err)
+
+		winner, price := auction.EquilibriumOutcome()
+		assert.Nil(t, winner)
+		assert.Zero(t, price)
+
+		_, err = auction.ResolveProxies()
+		assert.ErrorIs(t, err, ErrReserveNotMet)
+		assert.Equal(t, 60.00, bob.CurrentBid)
+		assert.Empty(t, auction.History())
+	})
+
+	t.Run("Manual overbid breaks the invariant", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 80.00, 500.00)
+		bob := createBidder("Bob", 60.00, 120.00, 2.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+
+		_, err = auction.ResolveProxies()
+		assert.NoError(t, err)
+		assert.True(t, auction.WinnerPaysMinimal())
+
+		assert.NoError(t, auction.PlaceBid(bob, 110.00))
+		assert.False(t, auction.WinnerPaysMinimal())
+	})
+}
+
+// TestCloseProxyResolution tests that WithCloseProxyResolution settles the
+// proxies when the countdown closes the auction.
+func TestCloseProxyResolution(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          []Option
+		expectedPrice float64
+	}{
+		{name: "Resolved at close", opts: []Option{WithCloseProxyResolution()}, expectedPrice: 82.00},
+		{name: "Left as bid without the option", expectedPrice: 60.00},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alice := createBidder("Alice", 50.00, 80.00, 3.00)
+			bob := createBidder("Bob", 60.00, 120.00, 2.00)
+			clock := newManualClock()
+
+			auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, append(tt.opts, WithClock(clock))...)
+			assert.NoError(t, err)
+
+			assert.NoError(t, auction.StartCountdown(clock.Now().Add(time.Minute)))
+			clock.Advance(time.Minute)
+			assert.NoError(t, auction.ExtendEndTime(0))
+
+			assert.Eventually(t, func() bool { return auction.State() == StateClosed }, time.Second, time.Millisecond)
+			winner := auction.DetermineWinner()
+			assert.Equal(t, bob, winner)
+			assert.InDelta(t, tt.expectedPrice, winner.CurrentBid, 1e-9)
+		})
+	}
+}
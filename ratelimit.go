@@ -0,0 +1,46 @@
+package dispatchbidder
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"golang.org/x/time/rate"
+)
+
+// WithBidRateLimit limits how often each bidder may call PlaceBid, with a
+// token bucket per bidder refilled at perBidder tokens per second up to
+// burst. A token is consumed once a bid has passed every other check,
+// validators included, so bids rejected otherwise cost none, and bids beyond
+// the limit fail with ErrRateLimited. Auto-bumps never consume tokens.
+// Buckets refill according to the auction clock, so tests can drive them
+// deterministically with WithClock.
+func WithBidRateLimit(perBidder rate.Limit, burst int) Option {
+	return func(a *Auction) {
+		a.bidRate = perBidder
+		a.bidBurst = burst
+		a.rateLimited = true
+	}
+}
+
+// checkRateLimit consumes a token from the bidder's bucket, returning
+// ErrRateLimited if it is empty. The caller must hold the lock.
+func (a *Auction) checkRateLimit(bidder *Bidder) error {
+	if !a.rateLimited {
+		return nil
+	}
+
+	if a.limiters == nil {
+		a.limiters = make(map[uuid.UUID]*rate.Limiter)
+	}
+	limiter, ok := a.limiters[bidder.ID]
+	if !ok {
+		limiter = rate.NewLimiter(a.bidRate, a.bidBurst)
+		a.limiters[bidder.ID] = limiter
+	}
+
+	if !limiter.AllowN(a.now(), 1) {
+		return fmt.Errorf("%w: bidder %s exceeded %v bids per second", ErrRateLimited, bidder.ID, a.bidRate)
+	}
+
+	return nil
+}
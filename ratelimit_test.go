@@ -0,0 +1,60 @@
+package dispatchbidder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBidRateLimit tests the per-bidder token bucket.
+func TestBidRateLimit(t *testing.T) {
+	alice := createBidder("Alice", 10.00, 500.00, 1.00)
+	bob := createBidder("Bob", 10.00, 500.00, 1.00)
+
+	clock := newManualClock()
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, WithClock(clock), WithBidRateLimit(1, 2))
+	assert.NoError(t, err)
+
+	// Bob's auto-bumps do not consume his tokens.
+	assert.NoError(t, auction.PlaceBid(alice, 20.00))
+	assert.NoError(t, auction.PlaceBid(alice, 30.00))
+	assert.ErrorIs(t, auction.PlaceBid(alice, 40.00), ErrRateLimited)
+	assert.Equal(t, 30.00, alice.CurrentBid)
+
+	assert.NoError(t, auction.PlaceBid(bob, 40.00))
+	assert.NoError(t, auction.PlaceBid(bob, 50.00))
+	assert.ErrorIs(t, auction.PlaceBid(bob, 60.00), ErrRateLimited)
+
+	clock.Advance(time.Second)
+	assert.NoError(t, auction.PlaceBid(alice, 60.00))
+	assert.ErrorIs(t, auction.PlaceBid(alice, 70.00), ErrRateLimited)
+
+	clock.Advance(2 * time.Second)
+	assert.NoError(t, auction.PlaceBid(alice, 70.00))
+	assert.NoError(t, auction.PlaceBid(alice, 80.00))
+
+	t.Run("Rejected bids consume no token", func(t *testing.T) {
+		alice := createBidder("Alice", 10.00, 500.00, 1.00)
+		bob := createBidder("Bob", 10.00, 500.00, 1.00)
+		rejectOdd := ValidatorFunc(func(_ context.Context, _ AuctionSnapshot, _ uuid.UUID, amount float64) error {
+			if int(amount)%2 == 1 {
+				return errors.New("odd amount")
+			}
+			return nil
+		})
+
+		clock := newManualClock()
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, WithClock(clock), WithBidRateLimit(1, 1), WithValidators(rejectOdd))
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, auction.PlaceBid(alice, 5.00), ErrBelowStartingBid)
+		assert.ErrorIs(t, auction.PlaceBid(alice, 600.00), ErrAboveMaxBid)
+		assert.ErrorIs(t, auction.PlaceBid(alice, 21.00), ErrBidNotValidated)
+		assert.NoError(t, auction.PlaceBid(alice, 20.00))
+		assert.ErrorIs(t, auction.PlaceBid(alice, 30.00), ErrRateLimited)
+	})
+}
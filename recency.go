@@ -0,0 +1,69 @@
+package dispatchbidder
+
+import "time"
+
+// DecayFunc returns the weight, between 0 and 1, applied to a bid of the
+// given age under recency weighting with the given window.
+type DecayFunc func(age, window time.Duration) float64
+
+// LinearDecay returns a DecayFunc falling linearly from 1 for a bid placed
+// just now to floor for a bid as old as the window or older.
+func LinearDecay(floor float64) DecayFunc {
+	return func(age, window time.Duration) float64 {
+		if window <= 0 || age >= window {
+			return floor
+		}
+		if age <= 0 {
+			return 1
+		}
+		return 1 - (1-floor)*float64(age)/float64(window)
+	}
+}
+
+// defaultRecencyFloor is the weight of stale bids when WithRecencyWeighting
+// is given no DecayFunc.
+const defaultRecencyFloor = 0.9
+
+// WithRecencyWeighting makes DetermineWinner, and everything ranking bidders,
+// compare effective scores instead of raw amounts: each CurrentBid is
+// multiplied by decay(age, window), where age is the time since the bidder's
+// last bid according to the auction clock. A slightly lower but recent bid
+// can then beat a stale high bid. A nil decay uses
+// LinearDecay(defaultRecencyFloor). Equal scores fall back to the usual
+// earliest-bid tie-break.
+//
+// This is a non-standard format: the winner can change as time passes
+// without any bid, and may not hold the highest CurrentBid.
+func WithRecencyWeighting(window time.Duration, decay DecayFunc) Option {
+	return func(a *Auction) {
+		if decay == nil {
+			decay = LinearDecay(defaultRecencyFloor)
+		}
+		a.recencyWindow = window
+		a.recencyDecay = decay
+	}
+}
+
+// outranksFunc returns the comparison used to rank bidders: isWinner, or the
+// recency-weighted comparison at the current clock time. The caller must hold
+// at least a read lock.
+func (a *Auction) outranksFunc() func(current, bidder *Bidder) bool {
+	if a.recencyDecay == nil {
+		return a.isWinner
+	}
+
+	now := a.now()
+	return func(current, bidder *Bidder) bool {
+		if current == nil {
+			return true
+		}
+		currentScore, bidderScore := a.recencyScore(current, now), a.recencyScore(bidder, now)
+		return bidderScore > currentScore ||
+			(bidderScore == currentScore && a.breaksTie(bidder, current))
+	}
+}
+
+// recencyScore returns the bidder's recency-weighted effective score.
+func (a *Auction) recencyScore(b *Bidder, now time.Time) float64 {
+	return b.baseBid() * a.recencyDecay(now.Sub(b.LastBidTime), a.recencyWindow)
+}
@@ -0,0 +1,64 @@
+package dispatchbidder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRecencyWeighting tests that a recent lower bid can beat a stale high one.
+func TestRecencyWeighting(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           []Option
+		expectedWinner string
+		expectedLater  string
+	}{
+		{name: "Raw amounts", expectedWinner: "Alice", expectedLater: "Alice"},
+		{
+			name:           "Recency flips the winner",
+			opts:           []Option{WithRecencyWeighting(time.Minute, nil)},
+			expectedWinner: "Bob",
+			expectedLater:  "Alice",
+		},
+		{
+			name:           "Custom decay",
+			opts:           []Option{WithRecencyWeighting(time.Minute, LinearDecay(0.5))},
+			expectedWinner: "Bob",
+			expectedLater:  "Alice",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Large increments keep auto-bumps out of the way.
+			alice := createBidder("Alice", 10.00, 100.00, 500.00)
+			bob := createBidder("Bob", 10.00, 96.00, 500.00)
+
+			clock := newManualClock()
+			auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, append([]Option{WithClock(clock)}, tt.opts...)...)
+			assert.NoError(t, err)
+
+			assert.NoError(t, auction.PlaceBid(alice, 100.00))
+			clock.Advance(time.Minute)
+			assert.NoError(t, auction.PlaceBid(bob, 96.00))
+
+			assert.Equal(t, tt.expectedWinner, auction.DetermineWinner().Name)
+
+			// Once both bids are stale, they are weighted alike again.
+			clock.Advance(2 * time.Minute)
+			assert.Equal(t, tt.expectedLater, auction.DetermineWinner().Name)
+		})
+	}
+}
+
+// TestLinearDecay tests the linear decay weights.
+func TestLinearDecay(t *testing.T) {
+	decay := LinearDecay(0.5)
+	assert.Equal(t, 1.0, decay(0, time.Minute))
+	assert.InDelta(t, 0.75, decay(30*time.Second, time.Minute), 1e-9)
+	assert.Equal(t, 0.5, decay(time.Minute, time.Minute))
+	assert.Equal(t, 0.5, decay(time.Hour, time.Minute))
+	assert.Equal(t, 0.5, decay(time.Second, 0))
+}
@@ -0,0 +1,124 @@
+package dispatchbidder
+
+import (
+	"fmt"
+	"math"
+)
+
+// ReconcileReport holds the money totals of an auction checked by Reconcile.
+type ReconcileReport struct {
+	// Committed is what the bidders owe as the auction stands: the
+	// winner's price, the units won at each winner's bid in a multi-unit
+	// auction, or every bidder's bid in an all-pay auction.
+	Committed float64
+	// Refunded is the total of the refund ledger, see Refunds.
+	Refunded float64
+	// Expected is what Committed and Refunded should add up to, worked out
+	// from the bids received according to the history instead of the
+	// bidders' current state.
+	Expected float64
+}
+
+// Balanced reports whether the committed and refunded totals add up to the
+// expected total, to the cent.
+func (r ReconcileReport) Balanced() bool {
+	return math.Abs(r.Committed+r.Refunded-r.Expected) < cent/2
+}
+
+// Reconcile computes the money totals of the auction for accounting and
+// returns ErrUnbalanced, along with the report, when the commitments and
+// refunds of its current state do not add up to the bids received according
+// to its history, which happens when the state was modified behind the
+// auction's back. A bidder without bids in the history counts as having
+// bid their CurrentBid.
+func (a *Auction) Reconcile() (ReconcileReport, error) {
+	a.RLock()
+	defer a.RUnlock()
+
+	received := make(map[*Bidder]float64, len(a.Bidders))
+	for _, bidder := range a.Bidders {
+		received[bidder] = bidder.CurrentBid
+	}
+	for _, event := range a.history {
+		if bidder, ok := a.bidderByID(event.BidderID); ok {
+			received[bidder] = event.Amount
+		}
+	}
+
+	report := ReconcileReport{
+		Committed: a.charges(func(b *Bidder) float64 { return b.CurrentBid }),
+		Expected:  a.charges(func(b *Bidder) float64 { return received[b] }),
+	}
+	for _, amount := range a.refunds {
+		report.Refunded += amount
+	}
+	if a.state == StateCancelled && a.Mode == ModeAllPay {
+		for _, bidder := range a.Bidders {
+			report.Expected += received[bidder]
+		}
+	}
+
+	if !report.Balanced() {
+		return report, fmt.Errorf("%w: committed $%.2f plus refunded $%.2f, expected $%.2f", ErrUnbalanced, report.Committed, report.Refunded, report.Expected)
+	}
+	return report, nil
+}
+
+// charges returns the total the bidders owe, valuing each bid with the given
+// function. The caller must hold at least a read lock.
+func (a *Auction) charges(amount func(*Bidder) float64) float64 {
+	total := 0.0
+	for _, c := range a.owed(amount) {
+		total += c.amount
+	}
+	return total
+}
+
+// charge is what one bidder owes.
+type charge struct {
+	bidder *Bidder
+	amount float64
+}
+
+// owed returns what each bidder owes, valuing each bid with the given
+// function: the winner's price, the units won at each winner's bid in a
+// multi-unit auction, or every bidder's bid in an all-pay auction. The
+// caller must hold at least a read lock.
+func (a *Auction) owed(amount func(*Bidder) float64) []charge {
+	if a.state.withoutWinner() {
+		return nil
+	}
+
+	var charges []charge
+	switch {
+	case a.Mode == ModeAllPay:
+		for _, bidder := range a.Bidders {
+			charges = append(charges, charge{bidder, amount(bidder)})
+		}
+	case a.Units > 1:
+		allocation := a.allocate()
+		for _, bidder := range a.Bidders {
+			if units := allocation[bidder.ID]; units > 0 {
+				charges = append(charges, charge{bidder, float64(units) * amount(bidder)})
+			}
+		}
+	default:
+		winner := a.determineWinner()
+		if winner == nil {
+			return nil
+		}
+		if a.Mode != ModeSealedSecondPrice {
+			return []charge{{winner, amount(winner)}}
+		}
+		price := winner.StartingBid
+		for _, bidder := range a.rankBidders() {
+			if bidder != winner {
+				price = max(price, amount(bidder))
+				break
+			}
+		}
+		charges = append(charges, charge{winner, price})
+	}
+
+	return charges
+}
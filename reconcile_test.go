@@ -0,0 +1,65 @@
+package dispatchbidder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestReconcile tests the accounting totals of balanced runs and of a
+// corrupted state.
+func TestReconcile(t *testing.T) {
+	newAuction := func(t *testing.T, na NewAuctionConfig) (*Auction, *Bidder, *Bidder) {
+		alice := createBidder("Alice", 50.00, 100.00, 5.00)
+		alice.DesiredQuantity = 2
+		bob := createBidder("Bob", 60.00, 120.00, 5.00)
+		na.Bidders = []*Bidder{alice, bob}
+		auction, err := NewAuction(na)
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.PlaceBid(alice, 80.00))
+		assert.NoError(t, auction.PlaceBid(bob, 90.00))
+		return auction, alice, bob
+	}
+
+	tests := []struct {
+		name      string
+		config    NewAuctionConfig
+		cancel    bool
+		committed float64
+		refunded  float64
+	}{
+		{"Standard", NewAuctionConfig{}, false, 90.00, 0},
+		{"Multi-unit", NewAuctionConfig{Units: 3}, false, 90.00 + 2*85.00, 0},
+		{"All-pay", NewAuctionConfig{Mode: ModeAllPay}, false, 85.00 + 90.00, 0},
+		{"Cancelled all-pay", NewAuctionConfig{Mode: ModeAllPay}, true, 0, 85.00 + 90.00},
+		{"Cancelled", NewAuctionConfig{}, true, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auction, _, _ := newAuction(t, tt.config)
+			if tt.cancel {
+				assert.NoError(t, auction.Cancel("withdrawn"))
+			}
+
+			report, err := auction.Reconcile()
+			assert.NoError(t, err)
+			assert.True(t, report.Balanced())
+			assert.InDelta(t, tt.committed, report.Committed, 1e-9)
+			assert.InDelta(t, tt.refunded, report.Refunded, 1e-9)
+			assert.InDelta(t, tt.committed+tt.refunded, report.Expected, 1e-9)
+		})
+	}
+
+	t.Run("Corrupted state", func(t *testing.T) {
+		auction, _, bob := newAuction(t, NewAuctionConfig{Mode: ModeAllPay})
+		bob.CurrentBid = 70.00
+
+		report, err := auction.Reconcile()
+		assert.ErrorIs(t, err, ErrUnbalanced)
+		assert.False(t, report.Balanced())
+		assert.InDelta(t, 85.00+70.00, report.Committed, 1e-9)
+		assert.InDelta(t, 85.00+90.00, report.Expected, 1e-9)
+	})
+}
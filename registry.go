@@ -0,0 +1,261 @@
+package dispatchbidder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShutdownPolicy selects what Registry.Shutdown does with open auctions.
+type ShutdownPolicy int
+
+const (
+	// ShutdownCloseOpen closes every open auction on shutdown.
+	ShutdownCloseOpen ShutdownPolicy = iota
+	// ShutdownLeaveOpen leaves open auctions open, only stopping their
+	// countdowns.
+	ShutdownLeaveOpen
+)
+
+// Registry manages a set of auctions by ID.
+type Registry struct {
+	mu       sync.RWMutex
+	auctions map[uuid.UUID]*Auction
+	policy   ShutdownPolicy
+	closed   bool
+	store    RegistryStore          // Nil unless created by NewRegistryWithStore.
+	adding   map[uuid.UUID]struct{} // IDs of auctions being saved by Add.
+
+	auctionOptions func(id uuid.UUID) []Option // Nil unless set with WithAuctionOptions.
+}
+
+// RegistryOption configures optional behavior of a registry.
+type RegistryOption func(*Registry)
+
+// WithShutdownPolicy sets what Shutdown does with open auctions. Defaults to
+// ShutdownCloseOpen.
+func WithShutdownPolicy(policy ShutdownPolicy) RegistryOption {
+	return func(r *Registry) {
+		r.policy = policy
+	}
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry(opts ...RegistryOption) *Registry {
+	r := Registry{auctions: make(map[uuid.UUID]*Auction), adding: make(map[uuid.UUID]struct{})}
+	for _, opt := range opts {
+		opt(&r)
+	}
+
+	return &r
+}
+
+// Create creates a new auction and adds it to the registry.
+func (r *Registry) Create(na NewAuctionConfig, opts ...Option) (*Auction, error) {
+	auction, err := NewAuction(na, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.Add(auction); err != nil {
+		return nil, err
+	}
+
+	return auction, nil
+}
+
+// Add adds an existing auction to the registry. With a registry store, the
+// auction is saved to it first and auto-persisted to it from then on, so it
+// must not have been created with WithAutoPersist.
+func (r *Registry) Add(auction *Auction) error {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return ErrRegistryClosed
+	}
+	_, exists := r.auctions[auction.ID]
+	if _, adding := r.adding[auction.ID]; exists || adding {
+		r.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrDuplicateAuction, auction.ID)
+	}
+	if r.store == nil {
+		r.auctions[auction.ID] = auction
+		r.mu.Unlock()
+		return nil
+	}
+	r.adding[auction.ID] = struct{}{}
+	r.mu.Unlock()
+
+	// Saving is done without the lock, so as not to hold up the registry;
+	// the ID is reserved meanwhile.
+	err := auction.persistTo(auctionStore{r.store})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.adding, auction.ID)
+	if err != nil {
+		return fmt.Errorf("saving auction %s: %w", auction.ID, err)
+	}
+	r.auctions[auction.ID] = auction
+
+	return nil
+}
+
+// Get returns the auction with the given ID.
+func (r *Registry) Get(id uuid.UUID) (*Auction, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	auction, ok := r.auctions[id]
+	return auction, ok
+}
+
+// Delete removes the auction from the registry, and from the registry store,
+// if any, stopping its countdown and its auto-persistence to the store. An
+// auction the store fails to delete stays registered.
+func (r *Registry) Delete(id uuid.UUID) error {
+	r.mu.RLock()
+	auction, ok := r.auctions[id]
+	r.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrAuctionNotFound, id)
+	}
+
+	if r.store != nil {
+		// Pending saves are awaited first, so none recreates the deleted
+		// auction.
+		p := auction.stopPersisting()
+		if err := r.store.DeleteAuction(id); err != nil {
+			auction.resumePersisting(p)
+			return fmt.Errorf("deleting auction %s: %w", id, err)
+		}
+	}
+
+	r.mu.Lock()
+	if r.auctions[id] == auction {
+		delete(r.auctions, id)
+	}
+	r.mu.Unlock()
+
+	auction.StopCountdown()
+
+	return nil
+}
+
+// List returns the registered auctions in no particular order.
+func (r *Registry) List() []*Auction {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	auctions := make([]*Auction, 0, len(r.auctions))
+	for _, auction := range r.auctions {
+		auctions = append(auctions, auction)
+	}
+
+	return auctions
+}
+
+// CloseExpired ends every open auction whose end time has been reached by
+// now, as its countdown would, and returns their IDs sorted. Auctions without
+// an end time, paused auctions, whose end time is pushed back on resume,
+// unless their hard end time has been reached, and auctions no longer open
+// are skipped. Auctions with fewer than MinParticipants bidders are voided
+// rather than closed, and included too. It returns ErrRegistryClosed after
+// Shutdown.
+func (r *Registry) CloseExpired(now time.Time) ([]uuid.UUID, error) {
+	r.mu.RLock()
+	closed := r.closed
+	r.mu.RUnlock()
+	if closed {
+		return nil, ErrRegistryClosed
+	}
+
+	var ids []uuid.UUID
+	for _, auction := range r.List() {
+		if auction.closeExpired(now) {
+			ids = append(ids, auction.ID)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return bytes.Compare(ids[i][:], ids[j][:]) < 0
+	})
+
+	return ids, nil
+}
+
+// RegistryMetrics is an aggregate snapshot of the auctions of a registry.
+type RegistryMetrics struct {
+	Auctions int           // Number of registered auctions.
+	ByState  map[State]int // Number of auctions in each state.
+	Bidders  int           // Bidders across all auctions.
+	Bids     int           // Accepted bids, including auto-increments, across all auctions.
+}
+
+// Metrics returns aggregate numbers over the registered auctions. It holds
+// the registry lock only to list the auctions, then each auction's read lock
+// only while counting it, so it is cheap to call periodically. Auctions are
+// counted one at a time, so the totals are not an atomic snapshot of them
+// all.
+func (r *Registry) Metrics() RegistryMetrics {
+	auctions := r.List()
+
+	metrics := RegistryMetrics{
+		Auctions: len(auctions),
+		ByState:  make(map[State]int),
+	}
+	for _, auction := range auctions {
+		auction.RLock()
+		metrics.ByState[auction.state]++
+		metrics.Bidders += len(auction.Bidders)
+		metrics.Bids += len(auction.history)
+		auction.RUnlock()
+	}
+
+	return metrics
+}
+
+// Shutdown stops every countdown goroutine, closes open auctions according
+// to the shutdown policy, flushes every event sink and saves every auction to
+// the registry store, if any, once more, reporting any failure. It returns
+// once done, or with ctx.Err() if ctx expires first. The registry accepts no
+// new auctions afterwards.
+func (r *Registry) Shutdown(ctx context.Context) error {
+	r.mu.Lock()
+	r.closed = true
+	r.mu.Unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		var errs []error
+		for _, auction := range r.List() {
+			auction.StopCountdown()
+			if r.policy == ShutdownCloseOpen && auction.State() == StateOpen {
+				if _, err := auction.Close(); err != nil && !errors.Is(err, ErrAuctionClosed) {
+					errs = append(errs, fmt.Errorf("closing auction %s: %w", auction.ID, err))
+				}
+			}
+			if err := auction.Flush(); err != nil {
+				errs = append(errs, fmt.Errorf("flushing auction %s: %w", auction.ID, err))
+			}
+			if r.store != nil {
+				if err := auction.saveNow(); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+		done <- errors.Join(errs...)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
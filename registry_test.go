@@ -0,0 +1,219 @@
+package dispatchbidder
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// memorySink is an EventSink buffering events in memory until flushed.
+type memorySink struct {
+	mu       sync.Mutex
+	buffered []BidEvent
+	flushed  []BidEvent
+	block    chan struct{} // When set, Flush blocks until it is closed.
+}
+
+// Write buffers the event.
+func (s *memorySink) Write(event BidEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.buffered = append(s.buffered, event)
+}
+
+// Flush moves the buffered events to the flushed list.
+func (s *memorySink) Flush() error {
+	if s.block != nil {
+		<-s.block
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.flushed = append(s.flushed, s.buffered...)
+	s.buffered = nil
+	return nil
+}
+
+// newTestConfig returns a valid two-bidder auction configuration.
+func newTestConfig() NewAuctionConfig {
+	return NewAuctionConfig{Bidders: []*Bidder{
+		createBidder("Alice", 50.00, 100.00, 5.00),
+		createBidder("Bob", 60.00, 100.00, 5.00),
+	}}
+}
+
+// TestRegistryShutdown tests that Shutdown stops countdowns, closes auctions
+// and flushes sinks.
+func TestRegistryShutdown(t *testing.T) {
+	t.Run("Closes open auctions without leaking goroutines", func(t *testing.T) {
+		baseline := runtime.NumGoroutine()
+
+		registry := NewRegistry()
+		sink := &memorySink{}
+
+		var auctions []*Auction
+		for i := 0; i < 5; i++ {
+			auction, err := registry.Create(newTestConfig(), WithEventSink(sink))
+			assert.NoError(t, err)
+			assert.NoError(t, auction.StartCountdown(time.Now().Add(time.Hour)))
+			auctions = append(auctions, auction)
+		}
+		assert.NoError(t, auctions[0].PlaceBid(auctions[0].Bidders[0], 70.00))
+		assert.Greater(t, runtime.NumGoroutine(), baseline)
+
+		assert.NoError(t, registry.Shutdown(context.Background()))
+
+		for _, auction := range auctions {
+			assert.Equal(t, StateClosed, auction.State())
+		}
+		assert.Len(t, sink.flushed, 2, "the bid and its bump are flushed")
+		assert.Empty(t, sink.buffered)
+		// Poll by hand: assert.Eventually runs its condition on extra goroutines.
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > baseline && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		assert.LessOrEqual(t, runtime.NumGoroutine(), baseline)
+
+		_, err := registry.Create(newTestConfig())
+		assert.ErrorIs(t, err, ErrRegistryClosed)
+	})
+
+	t.Run("Leave-open policy", func(t *testing.T) {
+		registry := NewRegistry(WithShutdownPolicy(ShutdownLeaveOpen))
+		auction, err := registry.Create(newTestConfig())
+		assert.NoError(t, err)
+		assert.NoError(t, auction.StartCountdown(time.Now().Add(time.Hour)))
+
+		assert.NoError(t, registry.Shutdown(context.Background()))
+		assert.Equal(t, StateOpen, auction.State())
+		assert.NoError(t, auction.StartCountdown(time.Now().Add(time.Hour)), "the countdown was stopped")
+		auction.StopCountdown()
+	})
+
+	t.Run("Returns when the context expires", func(t *testing.T) {
+		registry := NewRegistry()
+		sink := &memorySink{block: make(chan struct{})}
+		defer close(sink.block)
+
+		_, err := registry.Create(newTestConfig(), WithEventSink(sink))
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err = registry.Shutdown(ctx)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+}
+
+// TestRegistry tests basic registry operations.
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+
+	auction, err := registry.Create(newTestConfig())
+	assert.NoError(t, err)
+
+	got, ok := registry.Get(auction.ID)
+	assert.True(t, ok)
+	assert.Same(t, auction, got)
+	assert.Len(t, registry.List(), 1)
+
+	assert.ErrorIs(t, registry.Add(auction), ErrDuplicateAuction)
+
+	assert.NoError(t, registry.Delete(auction.ID))
+	_, ok = registry.Get(auction.ID)
+	assert.False(t, ok)
+	assert.ErrorIs(t, registry.Delete(auction.ID), ErrAuctionNotFound)
+}
+
+// TestRegistryMetrics tests the aggregate counts over auctions in various
+// states.
+func TestRegistryMetrics(t *testing.T) {
+	registry := NewRegistry()
+	assert.Equal(t, RegistryMetrics{ByState: map[State]int{}}, registry.Metrics())
+
+	open, err := registry.Create(newTestConfig())
+	assert.NoError(t, err)
+	assert.NoError(t, open.PlaceBid(open.Bidders[0], 70.00))
+	bids := len(open.History())
+
+	closed, err := registry.Create(newTestConfig())
+	assert.NoError(t, err)
+	_, err = closed.Close()
+	assert.NoError(t, err)
+
+	cancelled, err := registry.Create(newTestConfig())
+	assert.NoError(t, err)
+	assert.NoError(t, cancelled.Cancel("duplicate listing"))
+
+	pending, err := registry.Create(newTestConfig(), WithPending())
+	assert.NoError(t, err)
+	assert.NoError(t, pending.AddBidder(createBidder("Carol", 55.00, 90.00, 5.00)))
+
+	assert.Equal(t, RegistryMetrics{
+		Auctions: 4,
+		ByState: map[State]int{
+			StateOpen:      1,
+			StateClosed:    1,
+			StateCancelled: 1,
+			StatePending:   1,
+		},
+		Bidders: 9,
+		Bids:    bids,
+	}, registry.Metrics())
+	assert.Greater(t, bids, 1, "auto-increments count as bids")
+}
+
+// TestRegistryCloseExpired tests that only auctions past their end time are
+// closed.
+func TestRegistryCloseExpired(t *testing.T) {
+	registry := NewRegistry()
+	start := newManualClock().Now()
+
+	create := func(end time.Duration) *Auction {
+		auction, err := registry.Create(newTestConfig(), WithClock(newManualClock()))
+		assert.NoError(t, err)
+		if end > 0 {
+			assert.NoError(t, auction.StartCountdown(start.Add(end)))
+			auction.StopCountdown() // Keeps the end time.
+		}
+		return auction
+	}
+
+	expired := create(time.Hour)
+	due := create(2 * time.Hour)
+	active := create(3 * time.Hour)
+	unscheduled := create(0)
+	paused := create(time.Hour)
+	assert.NoError(t, paused.Pause())
+	closed := create(time.Hour)
+	_, err := closed.Close()
+	assert.NoError(t, err)
+
+	ids, err := registry.CloseExpired(start.Add(2 * time.Hour))
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{expired.ID, due.ID}, ids)
+
+	assert.Equal(t, StateClosed, expired.State())
+	assert.Equal(t, StateClosed, due.State())
+	assert.Equal(t, StateOpen, active.State())
+	assert.Equal(t, StateOpen, unscheduled.State())
+	assert.Equal(t, StateOpen, paused.State())
+
+	ids, err = registry.CloseExpired(start.Add(2 * time.Hour))
+	assert.NoError(t, err)
+	assert.Empty(t, ids, "already closed")
+
+	assert.NoError(t, registry.Shutdown(context.Background()))
+	_, err = registry.CloseExpired(start.Add(4 * time.Hour))
+	assert.ErrorIs(t, err, ErrRegistryClosed)
+}
@@ -0,0 +1,173 @@
+package dispatchbidder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// RegistryStore persists the auctions of a registry across restarts, as
+// their MarshalJSON encoding keyed by auction ID, for the reasons given by
+// Store. Implementations must be safe for concurrent use.
+type RegistryStore interface {
+	// SaveAuction stores the encoding as the latest state of the auction.
+	SaveAuction(id uuid.UUID, data []byte) error
+	// LoadAll returns the latest encoding of every stored auction, by ID.
+	LoadAll() (map[uuid.UUID][]byte, error)
+	// DeleteAuction removes the auction with the given ID. Deleting an
+	// auction that is not stored is not an error.
+	DeleteAuction(id uuid.UUID) error
+}
+
+// auctionStore adapts a RegistryStore to the Store the auctions of the
+// registry are auto-persisted to.
+type auctionStore struct {
+	RegistryStore
+}
+
+// Save saves the auction with SaveAuction.
+func (s auctionStore) Save(id uuid.UUID, data []byte) error {
+	return s.SaveAuction(id, data)
+}
+
+// Load returns the auction's encoding out of LoadAll.
+func (s auctionStore) Load(id uuid.UUID) ([]byte, error) {
+	all, err := s.LoadAll()
+	if err != nil {
+		return nil, err
+	}
+	data, ok := all[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrAuctionNotFound, id)
+	}
+	return data, nil
+}
+
+// WithAuctionOptions sets the options NewRegistryWithStore recovers each
+// stored auction with, given its ID. They restore the options MarshalJSON
+// does not encode, such as WithExchangeRates, WithCurrencyPrecision,
+// WithBidRateLimit, WithRecencyWeighting and WithValidators, which are lost
+// otherwise. Any WithAutoPersist among them is replaced by the registry
+// store.
+func WithAuctionOptions(fn func(id uuid.UUID) []Option) RegistryOption {
+	return func(r *Registry) {
+		r.auctionOptions = fn
+	}
+}
+
+// NewRegistryWithStore creates a registry persisted to the store and
+// hydrated with the auctions the store holds. Added auctions are saved as
+// they are added and then auto-persisted to the store, as by WithAutoPersist,
+// so every change is kept across a restart; the errors of those saves are
+// reported by each auction's PersistErrors. Deleted auctions are removed from
+// the store. Auctions are recovered as LoadAuction decodes them, with the
+// options of WithAuctionOptions, if set; countdowns are not restarted.
+func NewRegistryWithStore(store RegistryStore, opts ...RegistryOption) (*Registry, error) {
+	all, err := store.LoadAll()
+	if err != nil {
+		return nil, fmt.Errorf("loading auctions: %w", err)
+	}
+	ids := make([]uuid.UUID, 0, len(all))
+	for id := range all {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return bytes.Compare(ids[i][:], ids[j][:]) < 0 })
+
+	r := NewRegistry(opts...)
+	for _, id := range ids {
+		var auctionOpts []Option
+		if r.auctionOptions != nil {
+			auctionOpts = r.auctionOptions(id)
+		}
+		auction, err := decodeAuction(id, all[id], append(auctionOpts, WithAutoPersist(auctionStore{store}))...)
+		if err != nil {
+			return nil, err
+		}
+		r.auctions[id] = auction
+	}
+	r.store = store
+
+	return r, nil
+}
+
+// FileRegistryStore is a RegistryStore keeping each auction as a JSON file,
+// encoded by MarshalJSON and named after its ID, in a directory.
+type FileRegistryStore struct {
+	dir string
+}
+
+// NewFileRegistryStore returns a store keeping its files in dir, creating the
+// directory if needed.
+func NewFileRegistryStore(dir string) (*FileRegistryStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &FileRegistryStore{dir: dir}, nil
+}
+
+// SaveAuction writes the encoding to the auction's file. The file is
+// replaced atomically, so a crash mid-save leaves the previous state intact.
+func (s *FileRegistryStore) SaveAuction(id uuid.UUID, data []byte) error {
+	tmp, err := os.CreateTemp(s.dir, ".auction-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path(id))
+}
+
+// LoadAll reads the auction files of the directory. Files not named after
+// an auction ID are skipped.
+func (s *FileRegistryStore) LoadAll() (map[uuid.UUID][]byte, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	all := make(map[uuid.UUID][]byte)
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		id, err := uuid.Parse(strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(s.path(id))
+		if err != nil {
+			return nil, err
+		}
+		all[id] = data
+	}
+
+	return all, nil
+}
+
+// DeleteAuction removes the auction's file.
+func (s *FileRegistryStore) DeleteAuction(id uuid.UUID) error {
+	if err := os.Remove(s.path(id)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path returns the path of the auction's file.
+func (s *FileRegistryStore) path(id uuid.UUID) string {
+	return filepath.Join(s.dir, id.String()+".json")
+}
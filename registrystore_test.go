@@ -0,0 +1,219 @@
+package dispatchbidder
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// failingRegistryStore is a RegistryStore whose saves and deletes fail.
+type failingRegistryStore struct{}
+
+func (failingRegistryStore) SaveAuction(uuid.UUID, []byte) error    { return errors.New("disk full") }
+func (failingRegistryStore) LoadAll() (map[uuid.UUID][]byte, error) { return nil, nil }
+func (failingRegistryStore) DeleteAuction(uuid.UUID) error          { return errors.New("disk full") }
+
+// undeletableRegistryStore is a FileRegistryStore whose deletes fail.
+type undeletableRegistryStore struct {
+	*FileRegistryStore
+}
+
+func (undeletableRegistryStore) DeleteAuction(uuid.UUID) error { return errors.New("read-only") }
+
+// TestRegistryStore tests that a registry recovers its auctions from its
+// store after a restart.
+func TestRegistryStore(t *testing.T) {
+	t.Run("Recovered after a restart", func(t *testing.T) {
+		dir := t.TempDir()
+		store, err := NewFileRegistryStore(dir)
+		assert.NoError(t, err)
+
+		registry, err := NewRegistryWithStore(store)
+		assert.NoError(t, err)
+		kept, err := registry.Create(newTestConfig())
+		assert.NoError(t, err)
+		deleted, err := registry.Create(newTestConfig())
+		assert.NoError(t, err)
+		assert.NoError(t, registry.Delete(deleted.ID))
+
+		bidder := kept.BidderList()[0]
+		assert.NoError(t, kept.PlaceBid(bidder, bidder.CurrentBid+10.00))
+		leader := kept.Leader()
+		assert.NoError(t, registry.Shutdown(context.Background()))
+
+		// A new registry from the same store is a restart.
+		restarted, err := NewRegistryWithStore(store)
+		assert.NoError(t, err)
+		assert.Len(t, restarted.List(), 1)
+		_, ok := restarted.Get(deleted.ID)
+		assert.False(t, ok)
+
+		recovered, ok := restarted.Get(kept.ID)
+		assert.True(t, ok)
+		assert.Equal(t, StateClosed, recovered.State())
+		assert.Len(t, recovered.History(), len(kept.History()))
+		assert.Equal(t, leader.ID, recovered.Leader().ID)
+		assert.Equal(t, leader.CurrentBid, recovered.Leader().CurrentBid)
+	})
+
+	t.Run("Saved on create", func(t *testing.T) {
+		store, err := NewFileRegistryStore(filepath.Join(t.TempDir(), "auctions"))
+		assert.NoError(t, err)
+
+		registry, err := NewRegistryWithStore(store)
+		assert.NoError(t, err)
+		auction, err := registry.Create(newTestConfig())
+		assert.NoError(t, err)
+		defer auction.StopCountdown()
+
+		restarted, err := NewRegistryWithStore(store)
+		assert.NoError(t, err)
+		recovered, ok := restarted.Get(auction.ID)
+		assert.True(t, ok)
+		assert.Equal(t, StateOpen, recovered.State())
+		for i, view := range recovered.Snapshot().Bidders {
+			original := auction.Snapshot().Bidders[i]
+			assert.Equal(t, original.ID, view.ID)
+			assert.Equal(t, original.CurrentBid, view.CurrentBid)
+			assert.True(t, original.LastBidTime.Equal(view.LastBidTime))
+		}
+	})
+
+	t.Run("Every change is saved", func(t *testing.T) {
+		store, err := NewFileRegistryStore(t.TempDir())
+		assert.NoError(t, err)
+
+		registry, err := NewRegistryWithStore(store)
+		assert.NoError(t, err)
+		auction, err := registry.Create(newTestConfig())
+		assert.NoError(t, err)
+		defer auction.StopCountdown()
+
+		bidder := auction.BidderList()[0]
+		assert.NoError(t, auction.PlaceBid(bidder, bidder.CurrentBid+10.00))
+		assert.NoError(t, auction.Flush())
+
+		// No Shutdown: a new registry from the same store is a crash recovery.
+		restarted, err := NewRegistryWithStore(store)
+		assert.NoError(t, err)
+		recovered, ok := restarted.Get(auction.ID)
+		assert.True(t, ok)
+		assert.Equal(t, auction.Version(), recovered.Version())
+		assert.Len(t, recovered.History(), len(auction.History()))
+
+		// Recovered auctions keep being saved.
+		bidder, _ = recovered.LookupBidder(bidder.ID)
+		assert.NoError(t, recovered.PlaceBid(bidder, bidder.CurrentBid+10.00))
+		assert.NoError(t, recovered.Flush())
+		again, err := NewRegistryWithStore(store)
+		assert.NoError(t, err)
+		latest, ok := again.Get(auction.ID)
+		assert.True(t, ok)
+		assert.Equal(t, recovered.Version(), latest.Version())
+	})
+
+	t.Run("Deleted auctions stay deleted", func(t *testing.T) {
+		store, err := NewFileRegistryStore(t.TempDir())
+		assert.NoError(t, err)
+
+		registry, err := NewRegistryWithStore(store)
+		assert.NoError(t, err)
+		auction, err := registry.Create(newTestConfig())
+		assert.NoError(t, err)
+		assert.NoError(t, registry.Delete(auction.ID))
+
+		bidder := auction.BidderList()[0]
+		assert.NoError(t, auction.PlaceBid(bidder, bidder.CurrentBid+10.00))
+		assert.NoError(t, auction.Flush())
+		all, err := store.LoadAll()
+		assert.NoError(t, err)
+		assert.Empty(t, all)
+	})
+
+	t.Run("Failed deletes keep the auction", func(t *testing.T) {
+		files, err := NewFileRegistryStore(t.TempDir())
+		assert.NoError(t, err)
+		store := undeletableRegistryStore{files}
+
+		registry, err := NewRegistryWithStore(store)
+		assert.NoError(t, err)
+		auction, err := registry.Create(newTestConfig())
+		assert.NoError(t, err)
+		defer auction.StopCountdown()
+
+		assert.ErrorContains(t, registry.Delete(auction.ID), "read-only")
+		_, ok := registry.Get(auction.ID)
+		assert.True(t, ok)
+
+		bidder := auction.BidderList()[0]
+		assert.NoError(t, auction.PlaceBid(bidder, bidder.CurrentBid+10.00))
+		assert.NoError(t, auction.Flush())
+		recovered, err := LoadAuction(auctionStore{store}, auction.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, auction.Version(), recovered.Version(), "auto-persistence resumes")
+	})
+
+	t.Run("Already auto-persisted", func(t *testing.T) {
+		store, err := NewFileRegistryStore(t.TempDir())
+		assert.NoError(t, err)
+
+		registry, err := NewRegistryWithStore(store)
+		assert.NoError(t, err)
+		_, err = registry.Create(newTestConfig(), WithAutoPersist(&memoryStore{}))
+		assert.ErrorIs(t, err, ErrAutoPersisted)
+		assert.Empty(t, registry.List())
+	})
+
+	t.Run("Auction options are restored", func(t *testing.T) {
+		store, err := NewFileRegistryStore(t.TempDir())
+		assert.NoError(t, err)
+		options := func(uuid.UUID) []Option {
+			return []Option{WithExchangeRates("USD", FixedRates{"EUR": 2})}
+		}
+
+		registry, err := NewRegistryWithStore(store, WithAuctionOptions(options))
+		assert.NoError(t, err)
+		alice := createBidder("Alice", 10.00, 200.00, 0)
+		alice.Currency = "EUR"
+		bob := createBidder("Bob", 10.00, 200.00, 0)
+		bob.Currency = "USD"
+		auction, err := registry.Create(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, options(uuid.Nil)...)
+		assert.NoError(t, err)
+		defer auction.StopCountdown()
+		assert.NoError(t, auction.PlaceBid(bob, 150.00))
+
+		restarted, err := NewRegistryWithStore(store, WithAuctionOptions(options))
+		assert.NoError(t, err)
+		recovered, ok := restarted.Get(auction.ID)
+		assert.True(t, ok)
+		assert.Equal(t, "USD", recovered.BaseCurrency())
+
+		alice, _ = recovered.LookupBidder(alice.ID)
+		assert.NoError(t, recovered.PlaceBid(alice, 120.00))
+		assert.Equal(t, alice.ID, recovered.DetermineWinner().ID, "120 EUR is 240 USD")
+	})
+
+	t.Run("Corrupt file", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, uuid.NewString()+".json"), []byte("{"), 0o644))
+		store, err := NewFileRegistryStore(dir)
+		assert.NoError(t, err)
+
+		_, err = NewRegistryWithStore(store)
+		assert.Error(t, err)
+	})
+
+	t.Run("Store errors", func(t *testing.T) {
+		registry, err := NewRegistryWithStore(failingRegistryStore{})
+		assert.NoError(t, err)
+
+		_, err = registry.Create(newTestConfig())
+		assert.ErrorContains(t, err, "disk full")
+		assert.Empty(t, registry.List(), "an auction that was not saved is not added")
+	})
+}
@@ -0,0 +1,49 @@
+package dispatchbidder
+
+// WithHiddenReserve sets a reserve price the auction keeps secret: no bidder
+// wins until the top bid, in the base currency, reaches it, but callers only
+// learn whether it is met, through ReserveMet and ReserveStatus. Unlike
+// ReservePrice, which proxy resolution sells at, it is missing from
+// snapshots, results and dumps; HiddenReserve is the admin accessor.
+func WithHiddenReserve(amount float64) Option {
+	return func(a *Auction) {
+		a.hiddenReserve = amount
+	}
+}
+
+// HiddenReserve returns the reserve set with WithHiddenReserve, or zero. It
+// is meant for administrators; bidder-facing code should surface
+// ReserveStatus instead.
+func (a *Auction) HiddenReserve() float64 {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.hiddenReserve
+}
+
+// ReserveMet reports whether the top bid has reached the hidden reserve,
+// which it always has without one.
+func (a *Auction) ReserveMet() bool {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.meetsReserve(a.topBidder())
+}
+
+// ReserveStatus returns "met" or "not met", to show bidders whether the
+// hidden reserve has been reached without revealing it.
+func (a *Auction) ReserveStatus() string {
+	if a.ReserveMet() {
+		return "met"
+	}
+	return "not met"
+}
+
+// meetsReserve reports whether the top bidder's bid reaches the hidden
+// reserve. The caller must hold at least a read lock.
+func (a *Auction) meetsReserve(top *Bidder) bool {
+	if a.hiddenReserve == 0 {
+		return true
+	}
+	return top != nil && top.baseBid() >= a.hiddenReserve-gridTolerance
+}
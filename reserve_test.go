@@ -0,0 +1,51 @@
+package dispatchbidder
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHiddenReserve tests that the reserve status flips once the top bid
+// crosses the hidden reserve, which gates the winner.
+func TestHiddenReserve(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 200.00, 0)
+	bob := createBidder("Bob", 60.00, 200.00, 0)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, WithHiddenReserve(120.00))
+	assert.NoError(t, err)
+
+	assert.False(t, auction.ReserveMet())
+	assert.Equal(t, "not met", auction.ReserveStatus())
+	assert.Nil(t, auction.DetermineWinner())
+	assert.Nil(t, auction.Leader())
+
+	assert.NoError(t, auction.PlaceBid(alice, 119.99))
+	assert.Equal(t, "not met", auction.ReserveStatus())
+	assert.Nil(t, auction.DetermineWinner())
+	assert.Equal(t, uuid.Nil, auction.Snapshot().LeaderID)
+
+	assert.NoError(t, auction.PlaceBid(bob, 120.00))
+	assert.True(t, auction.ReserveMet())
+	assert.Equal(t, "met", auction.ReserveStatus())
+	assert.Equal(t, bob, auction.DetermineWinner())
+	assert.Equal(t, bob, auction.Leader())
+
+	t.Run("Reserve stays hidden", func(t *testing.T) {
+		assert.Equal(t, 120.00, auction.HiddenReserve())
+
+		data, err := json.Marshal(auction.Snapshot())
+		assert.NoError(t, err)
+		assert.NotContains(t, string(data), "Reserve")
+	})
+
+	t.Run("No reserve is always met", func(t *testing.T) {
+		plain, err := NewAuction(newTestConfig())
+		assert.NoError(t, err)
+
+		assert.Equal(t, "met", plain.ReserveStatus())
+		assert.Zero(t, plain.HiddenReserve())
+	})
+}
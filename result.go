@@ -0,0 +1,89 @@
+package dispatchbidder
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuctionResult is the summary of a closed auction.
+type AuctionResult struct {
+	AuctionID     uuid.UUID
+	Winner        *Bidder
+	WinningAmount float64
+	RunnerUp      *Bidder
+	TotalBids     int
+	ClosedAt      time.Time
+	Seed          int64
+
+	// ReserveMet reports whether the winning bid reached the hidden reserve,
+	// as ReserveMet does.
+	ReserveMet bool
+}
+
+// GenerateResult returns the summary of the auction. It returns
+// ErrAuctionNotClosed unless the auction is closed.
+func (a *Auction) GenerateResult() (AuctionResult, error) {
+	a.RLock()
+	defer a.RUnlock()
+
+	if a.state != StateClosed {
+		return AuctionResult{}, fmt.Errorf("%w: auction is %s", ErrAuctionNotClosed, a.state)
+	}
+
+	return a.result(), nil
+}
+
+// result builds the summary of the auction, with the winner DetermineWinner
+// reports: none for a voided auction or an unmet hidden reserve. The caller
+// must hold at least a read lock.
+func (a *Auction) result() AuctionResult {
+	result := AuctionResult{
+		AuctionID:  a.ID,
+		TotalBids:  len(a.history),
+		ClosedAt:   a.closedAt,
+		Seed:       a.seed,
+		ReserveMet: a.meetsReserve(a.topBidder()),
+	}
+
+	winner := a.determineWinner()
+	if winner == nil {
+		return result
+	}
+	result.Winner = winner
+	result.WinningAmount = winner.CurrentBid
+	for _, bidder := range a.rankBidders() {
+		if bidder != winner {
+			result.RunnerUp = bidder
+			break
+		}
+	}
+
+	return result
+}
+
+// Format returns a human-readable summary of the result.
+func (r AuctionResult) Format() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Auction:     %s\n", r.AuctionID)
+	if r.Winner != nil {
+		fmt.Fprintf(&sb, "Winner:      %s (%s)\n", r.Winner.Name, r.Winner.ID)
+		fmt.Fprintf(&sb, "Winning bid: $%.2f\n", r.WinningAmount)
+	} else {
+		fmt.Fprintf(&sb, "Winner:      none\n")
+	}
+	if r.RunnerUp != nil {
+		fmt.Fprintf(&sb, "Runner-up:   %s ($%.2f)\n", r.RunnerUp.Name, r.RunnerUp.CurrentBid)
+	}
+	if !r.ReserveMet {
+		fmt.Fprintf(&sb, "Reserve:     not met\n")
+	}
+	fmt.Fprintf(&sb, "Total bids:  %d\n", r.TotalBids)
+	fmt.Fprintf(&sb, "Closed at:   %s\n", r.ClosedAt.Format(time.RFC3339))
+	fmt.Fprintf(&sb, "Seed:        %d\n", r.Seed)
+
+	return sb.String()
+}
@@ -0,0 +1,107 @@
+package dispatchbidder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGenerateResult tests the result summary of a completed auction.
+func TestGenerateResult(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 80.00, 10.00)
+	bob := createBidder("Bob", 60.00, 100.00, 10.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+	assert.NoError(t, err)
+
+	_, err = auction.GenerateResult()
+	assert.ErrorIs(t, err, ErrAuctionNotClosed)
+
+	assert.NoError(t, auction.PlaceBid(alice, 70.00)) // Bob bumped to 70.
+	assert.NoError(t, auction.PlaceBid(bob, 90.00))   // Alice bumped to 80.
+	_, err = auction.Close()
+	assert.NoError(t, err)
+
+	result, err := auction.GenerateResult()
+	assert.NoError(t, err)
+	assert.Equal(t, auction.ID, result.AuctionID)
+	assert.Equal(t, bob, result.Winner)
+	assert.Equal(t, 90.00, result.WinningAmount)
+	assert.Equal(t, alice, result.RunnerUp)
+	assert.Equal(t, 4, result.TotalBids)
+	assert.False(t, result.ClosedAt.IsZero())
+
+	formatted := result.Format()
+	assert.Contains(t, formatted, auction.ID.String())
+	assert.Contains(t, formatted, "Bob")
+	assert.Contains(t, formatted, "$90.00")
+	assert.Contains(t, formatted, "Alice ($80.00)")
+	assert.Contains(t, formatted, "Total bids:  4")
+}
+
+// TestCloseResult tests that Close returns the same result the getters report
+// afterwards.
+func TestCloseResult(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []Option
+		reserveMet bool
+	}{
+		{name: "Without a hidden reserve", reserveMet: true},
+		{name: "Hidden reserve met", opts: []Option{WithHiddenReserve(90.00)}, reserveMet: true},
+		{name: "Hidden reserve not met", opts: []Option{WithHiddenReserve(150.00)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alice := createBidder("Alice", 50.00, 80.00, 10.00)
+			bob := createBidder("Bob", 60.00, 100.00, 10.00)
+
+			auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, tt.opts...)
+			assert.NoError(t, err)
+			assert.NoError(t, auction.PlaceBid(bob, 90.00))
+
+			result, err := auction.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, auction.DetermineWinner(), result.Winner)
+			if tt.reserveMet {
+				assert.Equal(t, bob, result.Winner)
+				assert.Equal(t, 90.00, result.WinningAmount)
+				assert.Equal(t, alice, result.RunnerUp)
+			} else {
+				assert.Nil(t, result.Winner, "no winner below the hidden reserve")
+				assert.Zero(t, result.WinningAmount)
+				assert.Contains(t, result.Format(), "Winner:      none")
+			}
+			assert.Equal(t, tt.reserveMet, result.ReserveMet)
+			assert.Equal(t, tt.reserveMet, auction.ReserveMet())
+			assert.Equal(t, tt.reserveMet, !strings.Contains(result.Format(), "Reserve:     not met"))
+
+			generated, err := auction.GenerateResult()
+			assert.NoError(t, err)
+			assert.Equal(t, generated, result)
+			assert.Len(t, auction.History(), result.TotalBids)
+
+			_, err = auction.Close()
+			assert.ErrorIs(t, err, ErrAuctionClosed)
+		})
+	}
+
+	t.Run("Voided", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 80.00, 10.00)
+		bob := createBidder("Bob", 60.00, 100.00, 10.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, MinParticipants: 2})
+		assert.NoError(t, err)
+		assert.NoError(t, auction.PlaceBid(bob, 90.00))
+
+		result, err := auction.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, StateVoided, auction.State())
+		assert.Nil(t, auction.DetermineWinner())
+		assert.Nil(t, result.Winner)
+		assert.Zero(t, result.WinningAmount)
+		assert.Nil(t, result.RunnerUp)
+	})
+}
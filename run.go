@@ -0,0 +1,184 @@
+package dispatchbidder
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// maxRounds bounds bidding loops so they cannot run forever.
+const maxRounds = 10000
+
+// RunToCompletion runs ascending bidding rounds until no bidder can raise any
+// further, then returns the winner. Each round, every bidder with headroom
+// bids their CurrentBid plus their AutoIncrement (or the minimum increment, if
+// larger), in registration order; fixed bidders sit the rounds out. It
+// returns ErrTooManyRounds if the auction does not settle within the safety
+// cap. Explain narrates the same run without bidding.
+func (a *Auction) RunToCompletion() (*Bidder, error) {
+	winner, _, err := a.runToCompletion(context.Background(), nil)
+	return winner, err
+}
+
+// RunToCompletionContext is like RunToCompletion, but checks ctx between
+// rounds and returns ctx.Err() once it is done. The bids of the rounds played
+// so far stay in place, so the run can be inspected or resumed.
+func (a *Auction) RunToCompletionContext(ctx context.Context) (*Bidder, error) {
+	winner, _, err := a.runToCompletion(ctx, nil)
+	return winner, err
+}
+
+// runToCompletion runs bidding rounds until settlement or until ctx is done,
+// returning the winner and the number of rounds in which bids were placed.
+// When order is set, it rearranges the bidders before each round.
+func (a *Auction) runToCompletion(ctx context.Context, order func([]*Bidder)) (*Bidder, int, error) {
+	played := 0
+	for round := 0; round < maxRounds; round++ {
+		if err := ctx.Err(); err != nil {
+			return nil, played, err
+		}
+		version := a.Version()
+		more := a.nextRound(order)
+		if a.Version() != version {
+			played++
+		}
+		if !more {
+			return a.DetermineWinner(), played, nil
+		}
+	}
+
+	return nil, played, fmt.Errorf("%w: no settlement after %d rounds", ErrTooManyRounds, maxRounds)
+}
+
+// NextRound plays a single bidding round as RunToCompletion does and reports
+// whether any bid was placed, for callers driving the rounds themselves.
+func (a *Auction) NextRound() bool {
+	return a.nextRound(nil)
+}
+
+// nextRound gives every bidder the chance to raise once and reports whether
+// any bid was placed. The auto-raises of its bids stop short of lifting the
+// total of the CurrentBids by more than MaxRoundRise, and once the total has
+// risen that far, the remaining bidders wait for the next round.
+func (a *Auction) nextRound(order func([]*Bidder)) bool {
+	bidders := a.BidderList()
+	if order != nil {
+		order(bidders)
+	}
+
+	a.RLock()
+	start := a.totalBid()
+	a.RUnlock()
+
+	placed := false
+	for _, bidder := range bidders {
+		a.RLock()
+		open := a.state == StateOpen
+		throttled := a.MaxRoundRise > 0 && a.totalBid()-start >= a.MaxRoundRise-gridTolerance
+		amount, ok := a.nextManualBid(bidder)
+		a.RUnlock()
+
+		if !open || throttled {
+			return placed && open
+		}
+		if ok && a.placeRoundBid(bidder, amount, start+a.MaxRoundRise) == nil {
+			placed = true
+		}
+	}
+
+	return placed
+}
+
+// placeRoundBid places a bid of a bidding round like PlaceBid, skipping the
+// auto-raises that would lift the total of the CurrentBids past limit, when
+// MaxRoundRise is set.
+func (a *Auction) placeRoundBid(bidder *Bidder, amount, limit float64) error {
+	a.Lock()
+	if a.MaxRoundRise > 0 {
+		a.roundLimit = limit
+	}
+	err := a.placeBid(context.Background(), bidder, amount)
+	a.roundLimit = 0
+	onReject := a.onReject
+	fireFirstBid := a.takeFirstBid()
+	fireOutbid := a.takeOutbid()
+	a.Unlock()
+
+	fireReject(onReject, bidder.ID, amount, err)
+	fireFirstBid()
+	fireOutbid()
+	return err
+}
+
+// withinRound reports whether raising the bidder to amount keeps the total of
+// the CurrentBids within the limit of the round being played, if any. The
+// caller must hold at least a read lock.
+func (a *Auction) withinRound(bidder *Bidder, amount float64) bool {
+	return a.roundLimit == 0 || a.totalBid()-bidder.CurrentBid+amount <= a.roundLimit+gridTolerance
+}
+
+// totalBid returns the sum of the bidders' CurrentBids. The caller must hold at
+// least a read lock.
+func (a *Auction) totalBid() float64 {
+	total := 0.0
+	for _, bidder := range a.Bidders {
+		total += bidder.CurrentBid
+	}
+	return total
+}
+
+// nextManualBid returns the next raise the bidder would make in a bidding
+// round, if any. The caller must hold at least a read lock.
+func (a *Auction) nextManualBid(bidder *Bidder) (float64, bool) {
+	if !bidder.hasHeadroom() || bidder.isFixed() || a.stops(bidder) {
+		return 0, false
+	}
+	if leader := a.determineWinner(); leader != nil && leader != bidder && bidder.missesReaction(leader.LastBidTime, a.now()) {
+		return 0, false
+	}
+
+	amount := a.alignToGrid(bidder, bidder.CurrentBid+max(bidder.increment(), a.minIncrement()))
+	if amount > bidder.MaxBid || !a.withinCap(amount) {
+		return 0, false
+	}
+
+	return amount, true
+}
+
+// missesReaction reports whether a response the bidder makes at the given
+// time, to the triggering bid at trigger, lands more than ReactionDeadline
+// after it, given the bidder's ReactionTime.
+func (b *Bidder) missesReaction(trigger, at time.Time) bool {
+	return b.ReactionDeadline > 0 && at.Add(b.ReactionTime).Sub(trigger) > b.ReactionDeadline
+}
+
+// IsSettled reports whether no bidder can place a raise that improves their
+// position: every bidder other than the leader is barred from bidding, has
+// no raise left within their MaxBid and the auction cap, or could not pass
+// the leader even at their MaxBid, whatever their ProxyMax, as in
+// RunToCompletion. A closed or cancelled auction is settled.
+// It is the termination predicate for external round loops and simulates
+// nothing.
+func (a *Auction) IsSettled() bool {
+	a.RLock()
+	defer a.RUnlock()
+
+	if a.state.ended() {
+		return true
+	}
+
+	leader := a.determineWinner()
+	for _, bidder := range a.Bidders {
+		if bidder == leader {
+			continue
+		}
+		if _, ok := a.nextManualBid(bidder); !ok {
+			continue
+		}
+		if leader == nil || bidder.toBase(a.truthfulLimit(bidder)) > leader.baseBid() {
+			return false
+		}
+	}
+
+	return true
+}
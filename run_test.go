@@ -0,0 +1,248 @@
+package dispatchbidder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRunToCompletion tests running the auction until it settles.
+func TestRunToCompletion(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 80.00, 3.00)
+	bob := createBidder("Bob", 60.00, 82.00, 2.00)
+	carol := createBidder("Carol", 55.00, 85.00, 5.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob, carol}})
+	assert.NoError(t, err)
+
+	winner, err := auction.RunToCompletion()
+	assert.NoError(t, err)
+	assert.Equal(t, "Carol", winner.Name)
+	assert.False(t, auction.HasActiveBidders())
+}
+
+// cancelSink cancels a context once it has seen a number of bids.
+type cancelSink struct {
+	after  int
+	seen   int
+	cancel context.CancelFunc
+}
+
+// Write counts the bid and cancels the context once enough were seen.
+func (s *cancelSink) Write(BidEvent) {
+	s.seen++
+	if s.seen == s.after {
+		s.cancel()
+	}
+}
+
+// Flush does nothing.
+func (s *cancelSink) Flush() error { return nil }
+
+// TestRunToCompletionContext tests cancelling a run between rounds.
+func TestRunToCompletionContext(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 500.00, 1.00)
+	bob := createBidder("Bob", 50.00, 600.00, 1.00)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sink := &cancelSink{after: 10, cancel: cancel}
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, WithEventSink(sink))
+	assert.NoError(t, err)
+
+	winner, err := auction.RunToCompletionContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, winner)
+
+	// The partial state is preserved and the run can be resumed.
+	partial := len(auction.History())
+	assert.GreaterOrEqual(t, partial, 10)
+	assert.Less(t, partial, 100)
+	assert.Equal(t, StateOpen, auction.State())
+	assert.True(t, auction.HasActiveBidders())
+
+	winner, err = auction.RunToCompletionContext(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, bob, winner)
+	assert.Greater(t, len(auction.History()), partial)
+}
+
+// TestIsSettled tests the termination predicate of bidding loops.
+func TestIsSettled(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 80.00, 3.00)
+	bob := createBidder("Bob", 60.00, 82.00, 2.00)
+	carol := createBidder("Carol", 55.00, 85.00, 5.00)
+	dave := createBidder("Dave", 40.00, 45.00, 1.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob, carol, dave}})
+	assert.NoError(t, err)
+	assert.False(t, auction.IsSettled(), "trailing bidders can still pass the leader")
+
+	assert.True(t, auction.nextRound(nil))
+	assert.False(t, auction.IsSettled(), "mid-auction")
+
+	_, err = auction.RunToCompletion()
+	assert.NoError(t, err)
+	assert.True(t, auction.IsSettled(), "terminal state")
+
+	_, err = auction.Close()
+	assert.NoError(t, err)
+	assert.True(t, auction.IsSettled())
+
+	// Raises that can never pass the leader do not count.
+	leader := createBidder("Leader", 90.00, 100.00, 5.00)
+	trailer := createBidder("Trailer", 50.00, 80.00, 5.00)
+	auction, err = NewAuction(NewAuctionConfig{Bidders: []*Bidder{leader, trailer}})
+	assert.NoError(t, err)
+	assert.True(t, auction.IsSettled())
+
+	// A proxy ceiling does not stop rounds from bidding up to MaxBid.
+	proxied := createBidder("Proxied", 10.00, 100.00, 5.00)
+	proxied.ProxyMax = 20.00
+	leader = createBidder("Leader", 30.00, 30.00, 0)
+	auction, err = NewAuction(NewAuctionConfig{Bidders: []*Bidder{proxied, leader}})
+	assert.NoError(t, err)
+	assert.False(t, auction.IsSettled())
+	winner, err := auction.RunToCompletion()
+	assert.NoError(t, err)
+	assert.Equal(t, proxied.ID, winner.ID)
+}
+
+// TestMaxRoundRise tests that a round cap throttles escalation, deferring
+// raises to later rounds without changing the outcome.
+func TestMaxRoundRise(t *testing.T) {
+	newAuction := func(t *testing.T, maxRoundRise float64) *Auction {
+		auction, err := NewAuction(NewAuctionConfig{
+			Bidders: []*Bidder{
+				createBidder("Alice", 50.00, 100.00, 5.00),
+				createBidder("Bob", 50.00, 120.00, 5.00),
+				createBidder("Carol", 50.00, 110.00, 5.00),
+			},
+			MaxRoundRise: maxRoundRise,
+		})
+		assert.NoError(t, err)
+		return auction
+	}
+	total := func(a *Auction) float64 {
+		sum := 0.0
+		for _, bidder := range a.BidderList() {
+			sum += bidder.CurrentBid
+		}
+		return sum
+	}
+
+	t.Run("Each round stops once the cap is reached", func(t *testing.T) {
+		auction := newAuction(t, 10.00)
+
+		before := total(auction)
+		assert.True(t, auction.NextRound())
+		rise := total(auction) - before
+		assert.GreaterOrEqual(t, rise, 10.00)
+		assert.Less(t, rise, 20.00, "the raise reaching the cap ends the round")
+
+		unlimited := newAuction(t, 0)
+		before = total(unlimited)
+		assert.True(t, unlimited.NextRound())
+		assert.Greater(t, total(unlimited)-before, rise)
+	})
+
+	t.Run("Auto-raises stop short of the cap", func(t *testing.T) {
+		auction := newAuction(t, 8.00)
+
+		// The first bid's bumps would lift the total by 15; they are skipped,
+		// and the next manual raise reaches the cap.
+		before := total(auction)
+		assert.True(t, auction.NextRound())
+		assert.InDelta(t, 10.00, total(auction)-before, gridTolerance)
+	})
+
+	t.Run("Throttled runs take more rounds to the same winner", func(t *testing.T) {
+		throttled := newAuction(t, 10.00)
+		unlimited := newAuction(t, 0)
+
+		winner, err := throttled.RunToCompletion()
+		assert.NoError(t, err)
+		expected, err := unlimited.RunToCompletion()
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected.Name, winner.Name)
+		assert.Greater(t, rounds(t, newAuction(t, 10.00)), rounds(t, newAuction(t, 0)))
+	})
+
+	t.Run("Negative caps are rejected", func(t *testing.T) {
+		_, err := NewAuction(NewAuctionConfig{Bidders: newTestConfig().Bidders, MaxRoundRise: -1})
+		assert.ErrorContains(t, err, "max round rise must not be negative")
+	})
+}
+
+// rounds plays the auction to completion one round at a time and returns the
+// number of rounds in which bids were placed.
+func rounds(t *testing.T, a *Auction) int {
+	n := 0
+	for a.NextRound() {
+		n++
+		if n > maxRounds {
+			t.Fatal("auction did not settle")
+		}
+	}
+	return n
+}
+
+// TestReactionDeadline tests that bidders past their reaction deadline miss
+// the raise.
+func TestReactionDeadline(t *testing.T) {
+	tests := []struct {
+		name           string
+		deadline       time.Duration
+		reactionTime   time.Duration
+		expectedWinner string
+	}{
+		{name: "Reacts in time", deadline: 3 * time.Second, reactionTime: 2 * time.Second, expectedWinner: "Carol"},
+		{name: "Reacts right at the deadline", deadline: 2 * time.Second, reactionTime: 2 * time.Second, expectedWinner: "Carol"},
+		{name: "Misses the bump and loses", deadline: time.Second, reactionTime: 2 * time.Second, expectedWinner: "Alice"},
+		{name: "Instant reaction", deadline: time.Second, expectedWinner: "Carol"},
+		{name: "No deadline", reactionTime: time.Hour, expectedWinner: "Carol"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newManualClock()
+			alice := createBidder("Alice", 50.00, 100.00, 5.00)
+			carol := createBidder("Carol", 60.00, 200.00, 15.00)
+			carol.ReactionDeadline = tt.deadline
+			carol.ReactionTime = tt.reactionTime
+
+			auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, carol}}, WithClock(clock))
+			assert.NoError(t, err)
+
+			assert.NoError(t, auction.PlaceBid(alice, 70.00))
+			result, err := auction.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedWinner, result.Winner.Name)
+		})
+	}
+
+	t.Run("Rounds", func(t *testing.T) {
+		clock := newManualClock()
+		alice := createBidder("Alice", 50.00, 100.00, 5.00)
+		carol := createBidder("Carol", 40.00, 200.00, 15.00)
+		carol.ReactionDeadline = 5 * time.Second
+		alice.LastBidTime, carol.LastBidTime = clock.Now(), clock.Now()
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, carol}}, WithClock(clock))
+		assert.NoError(t, err)
+		assert.False(t, auction.IsSettled())
+
+		clock.Advance(3 * time.Second)
+		assert.False(t, auction.IsSettled())
+		carol.ReactionTime = 3 * time.Second
+		assert.True(t, auction.IsSettled(), "Carol's response would land too late")
+
+		carol.ReactionTime = 0
+		clock.Advance(7 * time.Second)
+		assert.True(t, auction.IsSettled(), "Carol no longer reacts to Alice's bid")
+	})
+}
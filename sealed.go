@@ -0,0 +1,123 @@
+package dispatchbidder
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// sealed reports whether the mode takes sealed bids.
+func (m Mode) sealed() bool {
+	return m == ModeSealedFirstPrice || m == ModeSealedSecondPrice
+}
+
+// SubmitSealedBid submits the bidder's single hidden bid to a sealed-bid
+// auction. The bid must be within the bidder's StartingBid and MaxBid and
+// the auction cap. It stays hidden, leaving CurrentBid and the history
+// untouched, until the auction closes: the sealed bids are then revealed as
+// manual bids stamped with their submission times, and bidders who submitted
+// none drop out of the running. Until then DetermineWinner and Leader report
+// no winner.
+func (a *Auction) SubmitSealedBid(bidder *Bidder, amount float64) error {
+	a.Lock()
+	defer a.Unlock()
+
+	if err := a.checkOpen(); err != nil {
+		return err
+	}
+	if !a.Mode.sealed() {
+		return ErrNotSealedAuction
+	}
+	if err := a.checkMember(bidder); err != nil {
+		return err
+	}
+	if err := bidder.checkCanBid(); err != nil {
+		return err
+	}
+	if bidder.sealedBid > 0 {
+		return fmt.Errorf("%w: bidder ID %s", ErrSealedBidSubmitted, bidder.ID)
+	}
+	if err := checkAmount(amount); err != nil {
+		return err
+	}
+	if err := a.checkPrecision(bidder, amount); err != nil {
+		return err
+	}
+	if amount < bidder.StartingBid {
+		return fmt.Errorf("%w: bid amount $%.2f is less than starting bid $%.2f", ErrBelowStartingBid, amount, bidder.StartingBid)
+	}
+	if amount > bidder.MaxBid {
+		return fmt.Errorf("%w: bid amount $%.2f is greater than max bid $%.2f", ErrAboveMaxBid, amount, bidder.MaxBid)
+	}
+	if !a.withinCap(amount) {
+		return fmt.Errorf("%w: bid amount $%.2f is greater than auction cap $%.2f", ErrExceedsAuctionCap, amount, a.AuctionMaxBid)
+	}
+	if err := a.validate(context.Background(), bidder, amount); err != nil {
+		return err
+	}
+
+	bidder.sealedBid = amount
+	bidder.sealedAt = a.now()
+	a.version++
+	a.notify()
+
+	return nil
+}
+
+// DetermineWinnerAndPrice returns the winner, like DetermineWinner, and the
+// price they pay: the second-highest bid in a ModeSealedSecondPrice
+// auction, or their StartingBid without a runner-up, and their own bid in
+// every other mode.
+func (a *Auction) DetermineWinnerAndPrice() (*Bidder, float64) {
+	a.RLock()
+	defer a.RUnlock()
+
+	winner := a.determineWinner()
+	if winner == nil {
+		return nil, 0
+	}
+	if a.Mode != ModeSealedSecondPrice {
+		return winner, winner.CurrentBid
+	}
+
+	price := winner.StartingBid
+	for _, bidder := range a.rankBidders() {
+		if bidder != winner {
+			price = max(price, bidder.CurrentBid)
+			break
+		}
+	}
+	return winner, price
+}
+
+// revealSealedBids applies the sealed bids of a sealed-bid auction as manual
+// bids, in submission order, and takes the bidders who submitted none out of
+// the running. The caller must hold the lock.
+func (a *Auction) revealSealedBids() {
+	if !a.Mode.sealed() {
+		return
+	}
+
+	var submitted []*Bidder
+	for _, bidder := range a.Bidders {
+		if bidder.sealedBid > 0 {
+			submitted = append(submitted, bidder)
+		} else {
+			bidder.abstained = true
+		}
+	}
+	sort.SliceStable(submitted, func(i, j int) bool { return submitted[i].sealedAt.Before(submitted[j].sealedAt) })
+
+	for _, bidder := range submitted {
+		a.applyBid(bidder, bidder.sealedBid, bidder.rate, bidder.sealedAt, false, 0)
+		bidder.sealedBid = 0
+	}
+	a.refreshLeader()
+	a.version++
+}
+
+// sealedUnrevealed reports whether the auction takes sealed bids that have
+// not been revealed yet. The caller must hold at least a read lock.
+func (a *Auction) sealedUnrevealed() bool {
+	return a.Mode.sealed() && a.state != StateClosed
+}
@@ -0,0 +1,91 @@
+package dispatchbidder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSealedBids tests first-price and second-price sealed-bid auctions.
+func TestSealedBids(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          Mode
+		expectedPrice float64
+	}{
+		{name: "First price pays own bid", mode: ModeSealedFirstPrice, expectedPrice: 90.00},
+		{name: "Second price pays runner-up bid", mode: ModeSealedSecondPrice, expectedPrice: 75.00},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alice := createBidder("Alice", 50.00, 100.00, 5.00)
+			bob := createBidder("Bob", 50.00, 100.00, 5.00)
+			carol := createBidder("Carol", 50.00, 100.00, 5.00)
+			dave := createBidder("Dave", 50.00, 100.00, 5.00)
+
+			clock := newManualClock()
+			auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob, carol, dave}, Mode: tt.mode}, WithClock(clock))
+			assert.NoError(t, err)
+
+			for _, bid := range []struct {
+				bidder *Bidder
+				amount float64
+			}{{alice, 75.00}, {bob, 90.00}, {carol, 60.00}} {
+				clock.Advance(time.Second)
+				assert.NoError(t, auction.SubmitSealedBid(bid.bidder, bid.amount))
+			}
+
+			assert.Nil(t, auction.DetermineWinner(), "bids stay hidden while open")
+			assert.Nil(t, auction.Leader())
+			assert.Equal(t, 50.00, bob.CurrentBid)
+			assert.Empty(t, auction.History())
+			assert.ErrorIs(t, auction.SubmitSealedBid(bob, 95.00), ErrSealedBidSubmitted)
+			assert.ErrorIs(t, auction.PlaceBid(dave, 99.00), ErrSealedAuction)
+			assert.ErrorIs(t, auction.ForceSettle(), ErrSealedAuction)
+			_, err = auction.ResolveProxies()
+			assert.ErrorIs(t, err, ErrSealedAuction)
+			assert.Equal(t, StateOpen, auction.State())
+
+			_, err = auction.Close()
+			assert.NoError(t, err)
+			winner, price := auction.DetermineWinnerAndPrice()
+			assert.Equal(t, bob, winner)
+			assert.Equal(t, tt.expectedPrice, price)
+			assert.Equal(t, bob, auction.Leader())
+			assert.Len(t, auction.History(), 3)
+
+			ranked := auction.Leaderboard()
+			assert.Len(t, ranked, 3, "Dave submitted no bid")
+		})
+	}
+
+	t.Run("Ties go to the earliest submission", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 100.00, 5.00)
+		bob := createBidder("Bob", 50.00, 100.00, 5.00)
+
+		clock := newManualClock()
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, Mode: ModeSealedSecondPrice}, WithClock(clock))
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.SubmitSealedBid(bob, 80.00))
+		clock.Advance(time.Second)
+		assert.NoError(t, auction.SubmitSealedBid(alice, 80.00))
+		_, err = auction.Close()
+		assert.NoError(t, err)
+
+		winner, price := auction.DetermineWinnerAndPrice()
+		assert.Equal(t, bob, winner)
+		assert.Equal(t, 80.00, price)
+	})
+
+	t.Run("Open auctions take no sealed bids", func(t *testing.T) {
+		auction, err := NewAuction(newTestConfig())
+		assert.NoError(t, err)
+		assert.ErrorIs(t, auction.SubmitSealedBid(auction.BidderList()[0], 70.00), ErrNotSealedAuction)
+
+		winner, price := auction.DetermineWinnerAndPrice()
+		assert.Equal(t, winner.CurrentBid, price)
+	})
+}
@@ -0,0 +1,114 @@
+package dispatchbidder
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// lockedSource is a rand.Source safe for concurrent use, so the auction's
+// random source can be shared by strategies and simulations running on
+// different goroutines.
+type lockedSource struct {
+	mu    sync.Mutex
+	src   rand.Source
+	draws uint64 // Values drawn since the source was seeded.
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer.
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.draws++
+	return s.src.Int63()
+}
+
+// Seed reseeds the underlying source.
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.src.Seed(seed)
+	s.draws = 0
+}
+
+// drawn returns the number of values drawn since the source was seeded, so
+// that a decoded auction resumes the sequence where it left off. A nil
+// source has drawn none.
+func (s *lockedSource) drawn() uint64 {
+	if s == nil {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.draws
+}
+
+// newLockedRand returns a *rand.Rand backed by a lockedSource, advanced past
+// the first draws values of the seed's sequence, and the source.
+func newLockedRand(seed int64, draws uint64) (*rand.Rand, *lockedSource) {
+	src := &lockedSource{src: rand.NewSource(seed)}
+	for ; src.draws < draws; src.draws++ {
+		src.src.Int63()
+	}
+	return rand.New(src), src
+}
+
+// defaultSeed returns the seed used when WithSeed is not given.
+func defaultSeed() int64 {
+	return time.Now().UnixNano()
+}
+
+// WithSeed sets the master seed of the auction. Every randomized component
+// draws from the single random source derived from it, so re-running an
+// auction with the same seed and inputs reproduces the same winner and
+// history, event IDs aside. Without WithSeed a time-based seed is used; it
+// is still recorded and reported by Seed and GenerateResult, so any run can
+// be replayed.
+func WithSeed(seed int64) Option {
+	return func(a *Auction) {
+		a.seed = seed
+	}
+}
+
+// WithShuffledBumps makes the bidders respond to each manual bid in an order
+// drawn from the auction's random source, rather than always in registration
+// order, so no competitor systematically reacts first. The order only shows
+// in the history and in recency-based tie-breaks. With WithSeed, the orders
+// are reproducible.
+func WithShuffledBumps() Option {
+	return func(a *Auction) {
+		a.shuffleBumps = true
+	}
+}
+
+// bumpOrder returns the bidders in the order they respond to a bid. The
+// caller must hold the lock.
+func (a *Auction) bumpOrder() []*Bidder {
+	if !a.shuffleBumps {
+		return a.Bidders
+	}
+	order := append([]*Bidder(nil), a.Bidders...)
+	a.rng.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+	return order
+}
+
+// Seed returns the master seed of the auction.
+func (a *Auction) Seed() int64 {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.seed
+}
+
+// Rand returns the auction's random source, seeded with its master seed. It
+// is safe for concurrent use. Pass it to randomized strategies such as
+// Random to keep a whole run reproducible.
+func (a *Auction) Rand() *rand.Rand {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.rng
+}
@@ -0,0 +1,112 @@
+package dispatchbidder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWithSeed tests that a seeded auction run is reproducible end-to-end.
+func TestWithSeed(t *testing.T) {
+	templates := []Bidder{
+		*createBidder("Alice", 10.00, 300.00, 5.00),
+		*createBidder("Bob", 10.00, 300.00, 7.00),
+		*createBidder("Carol", 10.00, 300.00, 3.00),
+	}
+
+	run := func(t *testing.T, seed int64) (*Bidder, []BidEvent, AuctionResult) {
+		bidders := make([]*Bidder, len(templates))
+		for i := range templates {
+			b := templates[i]
+			bidders[i] = &b
+		}
+
+		clock := newManualClock()
+		auction, err := NewAuction(NewAuctionConfig{Bidders: bidders}, WithSeed(seed), WithClock(clock))
+		assert.NoError(t, err)
+		assert.Equal(t, seed, auction.Seed())
+
+		strategies := make(map[uuid.UUID]Strategy, len(bidders))
+		for _, b := range bidders {
+			random := Random(auction.Rand())
+			strategies[b.ID] = StrategyFunc(func(snapshot AuctionSnapshot, self BidderView) (float64, bool) {
+				clock.Advance(time.Second)
+				return random.NextBid(snapshot, self)
+			})
+		}
+
+		winner, err := auction.AutoPlay(strategies)
+		assert.NoError(t, err)
+		_, err = auction.Close()
+		assert.NoError(t, err)
+
+		result, err := auction.GenerateResult()
+		assert.NoError(t, err)
+
+		return winner, auction.History(), result
+	}
+
+	winner, history, result := run(t, 7)
+	assert.NotNil(t, winner)
+	assert.NotEmpty(t, history)
+	assert.Equal(t, int64(7), result.Seed)
+	assert.Contains(t, result.Format(), "Seed:        7")
+
+	replayWinner, replayHistory, replayResult := run(t, 7)
+	assert.Equal(t, winner.ID, replayWinner.ID)
+	if assert.Len(t, replayHistory, len(history)) {
+		for i := range history {
+			assert.NotEqual(t, history[i].EventID, replayHistory[i].EventID, "event IDs stay unique across runs")
+			replayHistory[i].EventID = history[i].EventID
+		}
+	}
+	assert.Equal(t, history, replayHistory)
+	assert.Equal(t, result.WinningAmount, replayResult.WinningAmount)
+
+	t.Run("Default seed is recorded", func(t *testing.T) {
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{
+			createBidder("Dave", 1.00, 10.00, 1.00),
+			createBidder("Erin", 1.00, 10.00, 1.00),
+		}})
+		assert.NoError(t, err)
+		assert.NotZero(t, auction.Seed())
+		assert.NotNil(t, auction.Rand())
+	})
+}
+
+// TestShuffledBumps tests that WithShuffledBumps varies the bump order
+// reproducibly.
+func TestShuffledBumps(t *testing.T) {
+	bumpOrder := func(t *testing.T, opts ...Option) [][]string {
+		bidders := []*Bidder{
+			createBidder("Alice", 10.00, 300.00, 5.00),
+			createBidder("Bob", 10.00, 300.00, 5.00),
+			createBidder("Carol", 10.00, 300.00, 5.00),
+			createBidder("Dave", 10.00, 300.00, 5.00),
+		}
+		names := make(map[uuid.UUID]string)
+		for i, b := range bidders {
+			b.ID = uuid.UUID{15: byte(i + 1)} // Registration order, as the auction sorts by ID.
+			names[b.ID] = b.Name
+		}
+		auction, err := NewAuction(NewAuctionConfig{Bidders: bidders}, opts...)
+		assert.NoError(t, err)
+
+		var orders [][]string
+		for _, amount := range []float64{50.00, 80.00} {
+			before := len(auction.History())
+			assert.NoError(t, auction.PlaceBid(bidders[0], amount))
+			var order []string
+			for _, event := range auction.History()[before+1:] {
+				order = append(order, names[event.BidderID])
+			}
+			orders = append(orders, order)
+		}
+		return orders
+	}
+
+	assert.Equal(t, [][]string{{"Bob", "Carol", "Dave"}, {"Bob", "Carol", "Dave"}}, bumpOrder(t, WithSeed(1)))
+	assert.Equal(t, [][]string{{"Bob", "Dave", "Carol"}, {"Carol", "Dave", "Bob"}}, bumpOrder(t, WithSeed(1), WithShuffledBumps()))
+}
@@ -0,0 +1,38 @@
+package dispatchbidder
+
+import "errors"
+
+// EventSink receives every accepted bid of an auction. Write is called under
+// the auction lock, so implementations should buffer events and do slow work,
+// such as network or disk I/O, in Flush.
+type EventSink interface {
+	Write(event BidEvent)
+	Flush() error
+}
+
+// WithEventSink adds a sink receiving every accepted bid of the auction.
+func WithEventSink(sink EventSink) Option {
+	return func(a *Auction) {
+		a.sinks = append(a.sinks, sink)
+	}
+}
+
+// Flush flushes every event sink of the auction, returning their joined
+// errors, and waits for pending saves of WithAutoPersist.
+func (a *Auction) Flush() error {
+	a.RLock()
+	sinks := a.sinks
+	persister := a.persister
+	a.RUnlock()
+
+	if persister != nil {
+		persister.wait()
+	}
+
+	var errs []error
+	for _, sink := range sinks {
+		errs = append(errs, sink.Flush())
+	}
+
+	return errors.Join(errs...)
+}
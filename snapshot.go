@@ -0,0 +1,150 @@
+package dispatchbidder
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BidderView is a read-only copy of a bidder's state.
+type BidderView struct {
+	ID            uuid.UUID
+	Name          string
+	OwnerID       string
+	Metadata      map[string]string
+	StartingBid   float64
+	MaxBid        float64
+	CurrentBid    float64
+	AutoIncrement float64
+	LastBidTime   time.Time
+
+	// Currency is the bidder's currency and BaseBid their CurrentBid
+	// converted to the auction's base currency.
+	Currency string
+	BaseBid  float64
+
+	// ManualBid is the amount the bidder last bid themselves, or their
+	// StartingBid if they never did, as opposed to CurrentBid which includes
+	// auto-increments applied on their behalf.
+	ManualBid float64
+
+	// AutoRaises is the number of auto-raises applied on the bidder's behalf.
+	AutoRaises int
+
+	// Sequence orders the bidder's registration or last bid among all those
+	// of the auction. Among equal bids placed at the same time, the lowest
+	// Sequence wins.
+	Sequence uint64
+
+	// Status is the bidder's derived status when the view was taken.
+	Status BidderStatus
+	// DisqualifyReason is the reason given when the bidder was disqualified.
+	DisqualifyReason string
+}
+
+// AuctionSnapshot is a consistent, read-only copy of the auction state.
+type AuctionSnapshot struct {
+	ID       uuid.UUID
+	State    State
+	Version  uint64
+	Bidders  []BidderView
+	LeaderID uuid.UUID // uuid.Nil when there is no leader.
+	TakenAt  time.Time
+}
+
+// Snapshot returns a consistent copy of the auction state.
+func (a *Auction) Snapshot() AuctionSnapshot {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.snapshot()
+}
+
+// snapshot returns a copy of the auction state. The caller must hold at least
+// a read lock.
+func (a *Auction) snapshot() AuctionSnapshot {
+	s := AuctionSnapshot{
+		ID:      a.ID,
+		State:   a.state,
+		Version: a.version,
+		Bidders: make([]BidderView, len(a.Bidders)),
+		TakenAt: a.now(),
+	}
+	leader := a.determineWinner()
+	for i, bidder := range a.Bidders {
+		s.Bidders[i] = bidder.view()
+		s.Bidders[i].Status = a.status(bidder, leader)
+	}
+	if leader != nil {
+		s.LeaderID = leader.ID
+	}
+
+	return s
+}
+
+// Leader returns the view of the leading bidder, if any.
+func (s AuctionSnapshot) Leader() (BidderView, bool) {
+	return s.Bidder(s.LeaderID)
+}
+
+// Bidder returns the view of the bidder with the given ID, if present.
+func (s AuctionSnapshot) Bidder(id uuid.UUID) (BidderView, bool) {
+	if id == uuid.Nil {
+		return BidderView{}, false
+	}
+	for _, b := range s.Bidders {
+		if b.ID == id {
+			return b, true
+		}
+	}
+	return BidderView{}, false
+}
+
+// view returns a read-only copy of the bidder.
+func (b *Bidder) view() BidderView {
+	return BidderView{
+		ID:               b.ID,
+		Name:             b.Name,
+		OwnerID:          b.OwnerID,
+		Metadata:         copyMetadata(b.Metadata),
+		StartingBid:      b.StartingBid,
+		MaxBid:           b.MaxBid,
+		CurrentBid:       b.CurrentBid,
+		AutoIncrement:    b.AutoIncrement,
+		LastBidTime:      b.LastBidTime,
+		Currency:         b.Currency,
+		BaseBid:          b.baseBid(),
+		ManualBid:        b.lastManualBid(),
+		AutoRaises:       b.bumps,
+		Sequence:         b.seq,
+		DisqualifyReason: b.disqualifyReason,
+	}
+}
+
+// lastManualBid returns the amount of the bidder's last manual bid, or their
+// StartingBid if they never bid themselves.
+func (b *Bidder) lastManualBid() float64 {
+	if b.manualBid == 0 {
+		return b.StartingBid
+	}
+	return b.manualBid
+}
+
+// Leaderboard returns views of the bidders still in the running, ranked from
+// the leader down using the same rules as DetermineWinner. Retracted and
+// disqualified bidders are left out.
+func (a *Auction) Leaderboard() []BidderView {
+	a.RLock()
+	defer a.RUnlock()
+
+	leader := a.determineWinner()
+	ranked := a.rankBidders()
+
+	board := make([]BidderView, len(ranked))
+	for i, bidder := range ranked {
+		board[i] = bidder.view()
+		board[i].Status = a.status(bidder, leader)
+	}
+
+	return board
+}
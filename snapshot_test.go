@@ -0,0 +1,69 @@
+package dispatchbidder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSnapshot tests that snapshots are consistent copies.
+func TestSnapshot(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 100.00, 5.00)
+	bob := createBidder("Bob", 60.00, 100.00, 5.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+	assert.NoError(t, err)
+	assert.NoError(t, auction.PlaceBid(alice, 70.00))
+
+	snapshot := auction.Snapshot()
+	assert.Equal(t, auction.ID, snapshot.ID)
+	assert.Equal(t, StateOpen, snapshot.State)
+	assert.Equal(t, uint64(1), snapshot.Version)
+	assert.Len(t, snapshot.Bidders, 2)
+
+	leader, ok := snapshot.Leader()
+	assert.True(t, ok)
+	assert.Equal(t, alice.ID, leader.ID)
+	assert.Equal(t, 70.00, leader.CurrentBid)
+
+	snapshot.Bidders[0].CurrentBid = 1.00
+	assert.Equal(t, 70.00, alice.CurrentBid)
+}
+
+// TestManualBid tests that manual bids are tracked apart from auto-increments.
+func TestManualBid(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 100.00, 3.00)
+	bob := createBidder("Bob", 60.00, 100.00, 2.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+	assert.NoError(t, err)
+
+	view := func(b *Bidder) BidderView {
+		v, ok := auction.Snapshot().Bidder(b.ID)
+		assert.True(t, ok)
+		return v
+	}
+
+	assert.Equal(t, 50.00, view(alice).ManualBid, "the starting bid until a manual bid")
+
+	assert.NoError(t, auction.PlaceBid(alice, 70.00))
+	assert.Equal(t, 70.00, view(alice).ManualBid)
+	assert.Equal(t, 60.00, view(bob).ManualBid)
+	assert.Equal(t, 62.00, view(bob).CurrentBid, "Bob was proxy-raised")
+
+	assert.NoError(t, auction.PlaceBid(bob, 80.00))
+	assert.NoError(t, auction.PlaceBid(bob, 90.00))
+	assert.Equal(t, 90.00, view(bob).ManualBid)
+	assert.Equal(t, 70.00, view(alice).ManualBid)
+	assert.Equal(t, 76.00, view(alice).CurrentBid)
+
+	board := auction.Leaderboard()
+	if assert.Len(t, board, 2) {
+		assert.Equal(t, "Bob", board[0].Name)
+		assert.Equal(t, 90.00, board[0].ManualBid)
+		assert.Equal(t, 70.00, board[1].ManualBid)
+	}
+
+	assert.NoError(t, auction.UndoLastBid(bob.ID))
+	assert.Equal(t, 80.00, view(bob).ManualBid, "undo restores the previous manual bid")
+}
@@ -0,0 +1,230 @@
+package dispatchbidder
+
+import (
+	"fmt"
+	"time"
+)
+
+// State represents the lifecycle state of an auction.
+type State int
+
+const (
+	// StateOpen is the state of an auction accepting bids.
+	StateOpen State = iota
+	// StateClosed is the state of an auction that no longer accepts bids.
+	StateClosed
+	// StateCancelled is the state of an auction that was withdrawn. It has no
+	// winner and accepts no bids.
+	StateCancelled
+	// StatePending is the state of an auction created with WithPending that
+	// has not been opened yet.
+	StatePending
+	// StateVoided is the state of an auction that closed without the
+	// required MinParticipants. It has no winner and accepts no bids.
+	StateVoided
+)
+
+// String returns the human-readable name of the state.
+func (s State) String() string {
+	switch s {
+	case StateOpen:
+		return "Open"
+	case StateClosed:
+		return "Closed"
+	case StateCancelled:
+		return "Cancelled"
+	case StatePending:
+		return "Pending"
+	case StateVoided:
+		return "Voided"
+	default:
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+}
+
+// ended reports whether the state is final: the auction accepts no bids and
+// no changes to its bidders.
+func (s State) ended() bool {
+	return s == StateClosed || s == StateCancelled || s == StateVoided
+}
+
+// withoutWinner reports whether the state rules out any winner.
+func (s State) withoutWinner() bool {
+	return s == StateCancelled || s == StateVoided
+}
+
+// State returns the current lifecycle state of the auction.
+func (a *Auction) State() State {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.state
+}
+
+// WithPending creates the auction in StatePending. It accepts no bids until
+// Open is called.
+func WithPending() Option {
+	return func(a *Auction) {
+		a.state = StatePending
+	}
+}
+
+// Open starts a pending auction, recording the open time used by MinDuration.
+func (a *Auction) Open() error {
+	a.Lock()
+	defer a.Unlock()
+
+	if a.state != StatePending {
+		return fmt.Errorf("%w: cannot open a %s auction", ErrInvalidTransition, a.state)
+	}
+	a.state = StateOpen
+	a.openedAt = a.now()
+	a.notify()
+
+	return nil
+}
+
+// IsFinal reports whether the result of DetermineWinner is final rather than
+// provisional: the auction must have been open for at least MinDuration, as
+// measured by the auction clock. A cancelled or voided auction is never
+// final.
+func (a *Auction) IsFinal() bool {
+	a.RLock()
+	defer a.RUnlock()
+
+	if a.state == StatePending || a.state.withoutWinner() {
+		return false
+	}
+	return a.now().Sub(a.openedAt) >= a.MinDuration
+}
+
+// Close closes the auction so no further bids are accepted and returns its
+// result, read under the same lock so it cannot race with later bids or
+// lifecycle changes. When fewer than MinParticipants bidders have bid, the
+// auction is voided instead, see VoidReason, and the result has no winner.
+func (a *Auction) Close() (AuctionResult, error) {
+	a.Lock()
+	defer a.Unlock()
+
+	if err := a.checkOpen(); err != nil {
+		return AuctionResult{}, err
+	}
+	a.finish(a.now())
+
+	return a.result(), nil
+}
+
+// Reopen reopens a closed auction for bidding, for instance after the winner
+// defaulted or the close was a mistake: the bids stand, but the close time
+// and any winner settled by the close or by AwardNext are cleared, so the
+// winner is determined afresh. Any AuctionResult already issued no longer
+// holds. No countdown is restarted. It returns ErrInvalidTransition unless
+// the auction is closed.
+func (a *Auction) Reopen() error {
+	a.Lock()
+	defer a.Unlock()
+
+	if a.state != StateClosed {
+		return fmt.Errorf("%w: cannot reopen a %s auction", ErrInvalidTransition, a.state)
+	}
+	a.reopen()
+	a.notify()
+
+	return nil
+}
+
+// ReopenFromCheckpoint reopens a closed auction like Reopen, but first rolls
+// it back to a checkpoint taken while it was open, as RestoreCheckpoint does,
+// discarding the bids placed since. Any AuctionResult already issued no
+// longer holds.
+func (a *Auction) ReopenFromCheckpoint(id string) error {
+	a.Lock()
+	defer a.Unlock()
+
+	if a.state != StateClosed {
+		return fmt.Errorf("%w: cannot reopen a %s auction", ErrInvalidTransition, a.state)
+	}
+	checkpoint, ok := a.checkpoints[id]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrCheckpointNotFound, id)
+	}
+	if checkpoint.state != StateOpen {
+		return fmt.Errorf("%w: checkpoint %s is of a %s auction", ErrInvalidTransition, id, checkpoint.state)
+	}
+	a.restoreCheckpoint(checkpoint)
+
+	return nil
+}
+
+// reopen returns a closed auction to StateOpen, clearing its close. The
+// caller must hold the lock.
+func (a *Auction) reopen() {
+	a.state = StateOpen
+	a.closedAt = time.Time{}
+	a.winner = nil
+	a.awards = nil
+	a.commitments = nil
+	a.refreshLeader()
+}
+
+// Cancel withdraws the auction, invalidating all provisional state: the
+// auction has no winner and rejects all further bids with ErrAuctionCancelled.
+// It returns ErrInvalidTransition for a closed or voided auction, which
+// Reopen must reopen first, and ErrAuctionCancelled if already cancelled.
+func (a *Auction) Cancel(reason string) error {
+	a.Lock()
+	defer a.Unlock()
+
+	if a.state == StateCancelled {
+		return fmt.Errorf("%w: %s", ErrAuctionCancelled, a.cancelReason)
+	}
+	if a.state.ended() {
+		return fmt.Errorf("%w: cannot cancel a %s auction", ErrInvalidTransition, a.state)
+	}
+	a.recordRefunds()
+	a.state = StateCancelled
+	a.cancelReason = reason
+	a.winner = nil
+	a.commitments = nil
+	a.paused = false
+	a.releaseCountdown()
+	a.notify()
+
+	return nil
+}
+
+// CancelReason returns the reason given when the auction was cancelled.
+func (a *Auction) CancelReason() string {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.cancelReason
+}
+
+// close transitions the auction to StateClosed at the given time and releases
+// its countdown, notifying subscribers. The caller must hold the lock.
+func (a *Auction) close(at time.Time) {
+	a.state = StateClosed
+	a.closedAt = at
+	a.unpause(at)
+	a.recordCommitments()
+	a.releaseCountdown()
+	a.notify()
+}
+
+// checkOpen returns an error unless the auction accepts bids. The caller must
+// hold at least a read lock.
+func (a *Auction) checkOpen() error {
+	switch a.state {
+	case StateOpen:
+		return nil
+	case StateCancelled:
+		return fmt.Errorf("%w: %s", ErrAuctionCancelled, a.cancelReason)
+	case StatePending:
+		return fmt.Errorf("%w: auction is %s", ErrAuctionNotOpen, a.state)
+	case StateVoided:
+		return fmt.Errorf("%w: %s", ErrAuctionVoided, a.voidReason)
+	default:
+		return fmt.Errorf("%w: auction is %s", ErrAuctionClosed, a.state)
+	}
+}
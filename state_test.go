@@ -0,0 +1,169 @@
+package dispatchbidder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestClose tests closing an auction.
+func TestClose(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 100.00, 5.00)
+	bob := createBidder("Bob", 60.00, 100.00, 5.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+	assert.NoError(t, err)
+	assert.Equal(t, StateOpen, auction.State())
+
+	_, err = auction.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, StateClosed, auction.State())
+
+	_, err = auction.Close()
+	assert.ErrorIs(t, err, ErrAuctionClosed)
+	assert.ErrorIs(t, auction.PlaceBid(alice, 70.00), ErrAuctionClosed)
+}
+
+// TestCancel tests cancelling an auction mid-bidding.
+func TestCancel(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 100.00, 5.00)
+	bob := createBidder("Bob", 60.00, 100.00, 5.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+	assert.NoError(t, err)
+
+	assert.NoError(t, auction.PlaceBid(alice, 70.00))
+	assert.NotNil(t, auction.DetermineWinner())
+
+	assert.NoError(t, auction.Cancel("item withdrawn"))
+	assert.Equal(t, StateCancelled, auction.State())
+	assert.NotEqual(t, StateClosed, auction.State())
+	assert.Equal(t, "item withdrawn", auction.CancelReason())
+	assert.Nil(t, auction.DetermineWinner())
+
+	err = auction.PlaceBid(bob, 80.00)
+	assert.ErrorIs(t, err, ErrAuctionCancelled)
+	assert.NotErrorIs(t, err, ErrAuctionClosed)
+
+	_, err = auction.Close()
+	assert.ErrorIs(t, err, ErrAuctionCancelled)
+	assert.ErrorIs(t, auction.Cancel("again"), ErrAuctionCancelled)
+
+	_, err = auction.GenerateResult()
+	assert.ErrorIs(t, err, ErrAuctionNotClosed)
+
+	t.Run("Ended auctions", func(t *testing.T) {
+		closed, err := NewAuction(newTestConfig())
+		assert.NoError(t, err)
+		_, err = closed.Close()
+		assert.NoError(t, err)
+		assert.ErrorIs(t, closed.Cancel("too late"), ErrInvalidTransition)
+		assert.Equal(t, StateClosed, closed.State())
+		assert.Empty(t, closed.CancelReason())
+
+		config := newTestConfig()
+		config.MinParticipants = 2
+		voided, err := NewAuction(config)
+		assert.NoError(t, err)
+		_, err = voided.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, StateVoided, voided.State())
+		assert.ErrorIs(t, voided.Cancel("too late"), ErrInvalidTransition)
+		assert.Equal(t, StateVoided, voided.State())
+
+		assert.NoError(t, closed.Reopen())
+		assert.NoError(t, closed.Cancel("withdrawn after all"))
+		assert.Equal(t, StateCancelled, closed.State())
+	})
+}
+
+// TestMinDuration tests that the winner is provisional until MinDuration has
+// elapsed since the auction opened.
+func TestMinDuration(t *testing.T) {
+	clock := newManualClock()
+	alice := createBidder("Alice", 50.00, 100.00, 5.00)
+	bob := createBidder("Bob", 60.00, 100.00, 5.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, MinDuration: time.Minute}, WithClock(clock), WithPending())
+	assert.NoError(t, err)
+	assert.Equal(t, StatePending, auction.State())
+	assert.ErrorIs(t, auction.PlaceBid(alice, 70.00), ErrAuctionNotOpen)
+	assert.False(t, auction.IsFinal())
+
+	clock.Advance(time.Hour) // Time spent pending does not count.
+	assert.NoError(t, auction.Open())
+	assert.ErrorIs(t, auction.Open(), ErrInvalidTransition)
+	assert.NoError(t, auction.PlaceBid(alice, 70.00))
+
+	clock.Advance(59 * time.Second)
+	assert.Equal(t, alice, auction.DetermineWinner(), "the provisional winner is still reported")
+	assert.False(t, auction.IsFinal())
+
+	clock.Advance(time.Second)
+	assert.True(t, auction.IsFinal())
+}
+
+// TestReopen tests reopening a closed auction for further bids.
+func TestReopen(t *testing.T) {
+	t.Run("New bid after reopening", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 120.00, 0)
+		bob := createBidder("Bob", 60.00, 100.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+		assert.ErrorIs(t, auction.Reopen(), ErrInvalidTransition)
+
+		first, err := auction.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, bob, first.Winner)
+
+		assert.NoError(t, auction.Reopen())
+		assert.Equal(t, StateOpen, auction.State())
+		assert.ErrorIs(t, auction.Reopen(), ErrInvalidTransition)
+		_, err = auction.GenerateResult()
+		assert.ErrorIs(t, err, ErrAuctionNotClosed)
+
+		assert.NoError(t, auction.PlaceBid(alice, 110.00))
+		second, err := auction.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, alice, second.Winner)
+		assert.Equal(t, 110.00, second.WinningAmount)
+		assert.False(t, second.ClosedAt.Before(first.ClosedAt))
+	})
+
+	t.Run("From a checkpoint", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 120.00, 5.00)
+		bob := createBidder("Bob", 60.00, 100.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+		open := auction.Checkpoint()
+
+		assert.NoError(t, auction.PlaceBid(bob, 100.00))
+		_, err = auction.Close()
+		assert.NoError(t, err)
+		closed := auction.Checkpoint()
+
+		assert.ErrorIs(t, auction.ReopenFromCheckpoint(closed), ErrInvalidTransition)
+		assert.ErrorIs(t, auction.ReopenFromCheckpoint("missing"), ErrCheckpointNotFound)
+		assert.NoError(t, auction.ReopenFromCheckpoint(open))
+		assert.Equal(t, StateOpen, auction.State())
+		assert.Empty(t, auction.History())
+		assert.Equal(t, 60.00, bob.CurrentBid)
+
+		assert.NoError(t, auction.PlaceBid(alice, 90.00))
+		result, err := auction.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, alice, result.Winner)
+	})
+
+	t.Run("Other states", func(t *testing.T) {
+		auction, err := NewAuction(newTestConfig())
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.Cancel("item withdrawn"))
+		assert.ErrorIs(t, auction.Reopen(), ErrInvalidTransition)
+		assert.Equal(t, StateCancelled, auction.State())
+	})
+}
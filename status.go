@@ -0,0 +1,202 @@
+package dispatchbidder
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// BidderStatus is the derived status of a bidder within an auction.
+type BidderStatus int
+
+const (
+	// StatusActive is a bidder who can still raise their bid.
+	StatusActive BidderStatus = iota
+	// StatusLeading is the bidder currently winning the auction.
+	StatusLeading
+	// StatusMaxed is a bidder who reached their MaxBid.
+	StatusMaxed
+	// StatusFrozen is a bidder temporarily barred from bidding.
+	StatusFrozen
+	// StatusRetracted is a bidder who left the auction.
+	StatusRetracted
+	// StatusDisqualified is a bidder permanently excluded from the results.
+	StatusDisqualified
+	// StatusWithdrawn is a WithdrawAboveMax bidder priced out of the auction.
+	StatusWithdrawn
+)
+
+// String returns the human-readable name of the status.
+func (s BidderStatus) String() string {
+	switch s {
+	case StatusActive:
+		return "Active"
+	case StatusLeading:
+		return "Leading"
+	case StatusMaxed:
+		return "Maxed"
+	case StatusFrozen:
+		return "Frozen"
+	case StatusRetracted:
+		return "Retracted"
+	case StatusDisqualified:
+		return "Disqualified"
+	case StatusWithdrawn:
+		return "Withdrawn"
+	default:
+		return fmt.Sprintf("BidderStatus(%d)", int(s))
+	}
+}
+
+// FreezeBidder bars the bidder from bidding and from receiving auto-increments
+// until UnfreezeBidder is called. The bidder keeps their standing bid.
+func (a *Auction) FreezeBidder(id uuid.UUID) error {
+	return a.updateBidder(id, func(b *Bidder) { b.frozen = true })
+}
+
+// UnfreezeBidder lifts a freeze placed by FreezeBidder.
+func (a *Auction) UnfreezeBidder(id uuid.UUID) error {
+	return a.updateBidder(id, func(b *Bidder) { b.frozen = false })
+}
+
+// RetractBidder takes the bidder out of the auction for good: they can no
+// longer bid, receive no auto-increments and cannot win.
+func (a *Auction) RetractBidder(id uuid.UUID) error {
+	return a.updateBidder(id, func(b *Bidder) { b.retracted = true })
+}
+
+// Disqualify permanently excludes the bidder from the results: they cannot
+// bid, receive no auto-increments and cannot win, but stay in the bidder
+// list, the history and snapshots for audit, along with the reason.
+func (a *Auction) Disqualify(id uuid.UUID, reason string) error {
+	a.Lock()
+	defer a.Unlock()
+
+	bidder, ok := a.bidderByID(id)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrBidderNotFound, id)
+	}
+	if bidder.disqualified {
+		return fmt.Errorf("%w: bidder ID %s: %s", ErrBidderDisqualified, id, bidder.disqualifyReason)
+	}
+
+	bidder.disqualified = true
+	bidder.disqualifyReason = reason
+	if a.winner == bidder {
+		a.winner = nil
+	}
+	a.refreshLeader()
+	a.version++
+	a.notify()
+
+	return nil
+}
+
+// FilterBidders returns a snapshot of the bidders with the given status. The
+// returned bidders are copies and modifying them does not affect the auction.
+func (a *Auction) FilterBidders(status BidderStatus) []*Bidder {
+	a.RLock()
+	defer a.RUnlock()
+
+	leader := a.determineWinner()
+
+	var filtered []*Bidder
+	for _, bidder := range a.Bidders {
+		if a.status(bidder, leader) == status {
+			filtered = append(filtered, bidder.copy())
+		}
+	}
+
+	return filtered
+}
+
+// status derives the bidder's status given the current leader. The caller must
+// hold at least a read lock.
+func (a *Auction) status(bidder, leader *Bidder) BidderStatus {
+	switch {
+	case bidder.disqualified:
+		return StatusDisqualified
+	case bidder.retracted:
+		return StatusRetracted
+	case bidder.withdrawn:
+		return StatusWithdrawn
+	case bidder.frozen:
+		return StatusFrozen
+	case bidder == leader:
+		return StatusLeading
+	case bidder.CurrentBid >= bidder.MaxBid:
+		return StatusMaxed
+	default:
+		return StatusActive
+	}
+}
+
+// updateBidder applies fn to the bidder under the lock.
+func (a *Auction) updateBidder(id uuid.UUID, fn func(*Bidder)) error {
+	a.Lock()
+	defer a.Unlock()
+
+	bidder, ok := a.bidderByID(id)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrBidderNotFound, id)
+	}
+	fn(bidder)
+	a.refreshLeader()
+	a.version++
+	a.notify()
+
+	return nil
+}
+
+// inRunning reports whether the bidder can still win the auction.
+func (b *Bidder) inRunning() bool {
+	return !b.retracted && !b.withdrawn && !b.disqualified && !b.abstained
+}
+
+// canBid reports whether the bidder may bid or receive auto-increments.
+func (b *Bidder) canBid() bool {
+	return b.checkCanBid() == nil
+}
+
+// checkCanBid returns an error if the bidder is barred from bidding.
+func (b *Bidder) checkCanBid() error {
+	switch {
+	case b.disqualified:
+		return fmt.Errorf("%w: bidder ID %s: %s", ErrBidderDisqualified, b.ID, b.disqualifyReason)
+	case b.retracted:
+		return fmt.Errorf("%w: bidder ID %s", ErrBidderRetracted, b.ID)
+	case b.withdrawn:
+		return fmt.Errorf("%w: bidder ID %s", ErrBidderWithdrawn, b.ID)
+	case b.frozen:
+		return fmt.Errorf("%w: bidder ID %s", ErrBidderFrozen, b.ID)
+	}
+	return nil
+}
+
+// hasHeadroom reports whether the bidder may bid and is below their MaxBid.
+func (b *Bidder) hasHeadroom() bool {
+	return b.canBid() && b.CurrentBid < b.MaxBid
+}
+
+// withdrawOutpriced withdraws every WithdrawAboveMax bidder whose MaxBid the
+// highest bid exceeds, both compared in the base currency. The caller must
+// hold the lock.
+func (a *Auction) withdrawOutpriced() {
+	highest := 0.0
+	for _, bidder := range a.Bidders {
+		if bidder.inRunning() {
+			highest = max(highest, bidder.baseBid())
+		}
+	}
+
+	withdrawn := false
+	for _, bidder := range a.Bidders {
+		if bidder.WithdrawAboveMax && bidder.inRunning() && highest > bidder.toBase(bidder.MaxBid)+gridTolerance {
+			bidder.withdrawn = true
+			withdrawn = true
+		}
+	}
+	if withdrawn {
+		a.refreshLeader()
+	}
+}
@@ -0,0 +1,153 @@
+package dispatchbidder
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFilterBidders tests each derived bidder status.
+func TestFilterBidders(t *testing.T) {
+	leading := createBidder("Leading", 90.00, 100.00, 5.00)
+	active := createBidder("Active", 50.00, 100.00, 5.00)
+	maxed := createBidder("Maxed", 60.00, 60.00, 5.00)
+	frozen := createBidder("Frozen", 55.00, 100.00, 5.00)
+	retracted := createBidder("Retracted", 95.00, 100.00, 5.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{leading, active, maxed, frozen, retracted}})
+	assert.NoError(t, err)
+
+	assert.NoError(t, auction.FreezeBidder(frozen.ID))
+	assert.NoError(t, auction.RetractBidder(retracted.ID))
+
+	tests := []struct {
+		status   BidderStatus
+		expected *Bidder
+	}{
+		{StatusLeading, leading},
+		{StatusActive, active},
+		{StatusMaxed, maxed},
+		{StatusFrozen, frozen},
+		{StatusRetracted, retracted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status.String(), func(t *testing.T) {
+			filtered := auction.FilterBidders(tt.status)
+			if assert.Len(t, filtered, 1) {
+				assert.Equal(t, tt.expected.ID, filtered[0].ID)
+				assert.NotSame(t, tt.expected, filtered[0], "the result is a snapshot copy")
+			}
+		})
+	}
+
+	t.Run("Frozen and retracted bidders are skipped", func(t *testing.T) {
+		assert.ErrorIs(t, auction.PlaceBid(frozen, 70.00), ErrBidderFrozen)
+		assert.ErrorIs(t, auction.PlaceBid(retracted, 100.00), ErrBidderRetracted)
+
+		assert.NoError(t, auction.PlaceBid(active, 99.00))
+		assert.Equal(t, 55.00, frozen.CurrentBid)
+		assert.Equal(t, 95.00, retracted.CurrentBid)
+		assert.Equal(t, 95.00, leading.CurrentBid)
+		assert.Equal(t, active, auction.DetermineWinner())
+
+		assert.NoError(t, auction.UnfreezeBidder(frozen.ID))
+		assert.NoError(t, auction.PlaceBid(frozen, 70.00))
+	})
+
+	t.Run("Unknown bidder", func(t *testing.T) {
+		assert.ErrorIs(t, auction.FreezeBidder(uuid.New()), ErrBidderNotFound)
+	})
+}
+
+// TestDisqualify tests that disqualified bidders are excluded from the results
+// but stay visible for audit.
+func TestDisqualify(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 80.00, 3.00)
+	bob := createBidder("Bob", 60.00, 120.00, 2.00)
+	carol := createBidder("Carol", 55.00, 100.00, 5.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob, carol}})
+	assert.NoError(t, err)
+
+	assert.NoError(t, auction.PlaceBid(bob, 90.00))
+	assert.Equal(t, bob, auction.DetermineWinner())
+
+	assert.NoError(t, auction.Disqualify(bob.ID, "shill bidding"))
+	assert.ErrorIs(t, auction.Disqualify(bob.ID, "again"), ErrBidderDisqualified)
+	assert.ErrorIs(t, auction.Disqualify(uuid.New(), "unknown"), ErrBidderNotFound)
+
+	winner := auction.DetermineWinner()
+	if assert.NotNil(t, winner) {
+		assert.NotEqual(t, bob.ID, winner.ID)
+	}
+
+	board := auction.Leaderboard()
+	assert.Len(t, board, 2)
+	for _, view := range board {
+		assert.NotEqual(t, bob.ID, view.ID)
+	}
+	assert.Equal(t, winner.ID, board[0].ID)
+	assert.Equal(t, StatusLeading, board[0].Status)
+
+	err = auction.PlaceBid(bob, 110.00)
+	assert.ErrorIs(t, err, ErrBidderDisqualified)
+	assert.ErrorContains(t, err, "shill bidding")
+
+	// Bob receives no more bumps.
+	before := bob.CurrentBid
+	assert.NoError(t, auction.PlaceBid(carol, 100.00))
+	assert.Equal(t, before, bob.CurrentBid)
+
+	// Bob stays in the bidder list, snapshots and history.
+	assert.Len(t, auction.Bidders, 3)
+	view, ok := auction.Snapshot().Bidder(bob.ID)
+	if assert.True(t, ok) {
+		assert.Equal(t, StatusDisqualified, view.Status)
+		assert.Equal(t, "shill bidding", view.DisqualifyReason)
+	}
+	found := false
+	for _, event := range auction.History() {
+		found = found || event.BidderID == bob.ID
+	}
+	assert.True(t, found)
+
+	disqualified := auction.FilterBidders(StatusDisqualified)
+	if assert.Len(t, disqualified, 1) {
+		assert.Equal(t, bob.ID, disqualified[0].ID)
+	}
+	assert.Equal(t, "Disqualified", StatusDisqualified.String())
+}
+
+// TestWithdrawAboveMax tests that bidders priced out of the auction drop out
+// for good, unlike merely maxed bidders.
+func TestWithdrawAboveMax(t *testing.T) {
+	alice := createBidder("Alice", 95.00, 100.00, 5.00)
+	alice.WithdrawAboveMax = true
+	bob := createBidder("Bob", 50.00, 150.00, 0)
+	bob.WithdrawAboveMax = true
+	carol := createBidder("Carol", 50.00, 300.00, 0)
+	maxed := createBidder("Maxed", 100.00, 100.00, 5.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob, carol, maxed}})
+	assert.NoError(t, err)
+
+	assert.NoError(t, auction.PlaceBid(bob, 100.00))
+	assert.Empty(t, auction.FilterBidders(StatusWithdrawn), "a bid equal to MaxBid does not price out")
+	assert.Equal(t, 100.00, alice.CurrentBid)
+
+	assert.NoError(t, auction.PlaceBid(bob, 120.00))
+	assert.Len(t, auction.FilterBidders(StatusWithdrawn), 1)
+	assert.Equal(t, alice.ID, auction.FilterBidders(StatusWithdrawn)[0].ID)
+	assert.Equal(t, maxed.ID, auction.FilterBidders(StatusMaxed)[0].ID, "bidders without the flag are merely maxed")
+	assert.ErrorIs(t, auction.PlaceBid(alice, 100.00), ErrBidderWithdrawn)
+
+	assert.NoError(t, auction.PlaceBid(carol, 200.00))
+	assert.Len(t, auction.FilterBidders(StatusWithdrawn), 2)
+
+	assert.NoError(t, auction.RetractBidder(carol.ID))
+	assert.NoError(t, auction.RetractBidder(maxed.ID))
+	assert.Nil(t, auction.DetermineWinner(), "withdrawn bidders cannot win even when everyone else left")
+	assert.Nil(t, auction.Leader())
+}
@@ -0,0 +1,34 @@
+package dispatchbidder
+
+// StopCondition decides, from views of a bidder and of the auction, whether
+// the bidder stops raising on their own behalf. See Bidder.StopCondition.
+type StopCondition func(self BidderView, snapshot AuctionSnapshot) bool
+
+// StopAfterAutoRaises returns a stop condition ending a bidder's
+// auto-responses once n auto-raises have been applied on their behalf.
+func StopAfterAutoRaises(n int) StopCondition {
+	return func(self BidderView, _ AuctionSnapshot) bool {
+		return self.AutoRaises >= n
+	}
+}
+
+// StopAbovePrice returns a stop condition ending a bidder's auto-responses
+// once the leading bid exceeds price, even while it is under their MaxBid.
+func StopAbovePrice(price float64) StopCondition {
+	return func(_ BidderView, snapshot AuctionSnapshot) bool {
+		leader, ok := snapshot.Leader()
+		return ok && leader.CurrentBid > price
+	}
+}
+
+// stops reports whether the bidder's stop condition holds. The caller must
+// hold at least a read lock.
+func (a *Auction) stops(b *Bidder) bool {
+	if b.StopCondition == nil {
+		return false
+	}
+
+	snapshot := a.snapshot()
+	self, _ := snapshot.Bidder(b.ID)
+	return b.StopCondition(self, snapshot)
+}
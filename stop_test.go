@@ -0,0 +1,47 @@
+package dispatchbidder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStopCondition tests would-be winners bowing out early on their stop
+// conditions.
+func TestStopCondition(t *testing.T) {
+	t.Run("Stop above price", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 200.00, 5.00)
+		alice.StopCondition = StopAbovePrice(100.00)
+		bob := createBidder("Bob", 60.00, 150.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+
+		winner, err := auction.RunToCompletion()
+		assert.NoError(t, err)
+		assert.Equal(t, bob, winner, "Alice could outbid Bob but stops above $100")
+		assert.LessOrEqual(t, alice.CurrentBid, 105.00)
+
+		assert.NoError(t, auction.PlaceBid(alice, 155.00), "manual bids are still accepted")
+		assert.Equal(t, alice, auction.DetermineWinner())
+	})
+
+	t.Run("Stop after auto-raises", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 200.00, 5.00)
+		alice.StopCondition = StopAfterAutoRaises(2)
+		bob := createBidder("Bob", 50.00, 200.00, 0)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+
+		for _, amount := range []float64{60.00, 70.00, 80.00} {
+			assert.NoError(t, auction.PlaceBid(bob, amount))
+		}
+		assert.Equal(t, 60.00, alice.CurrentBid, "only the first two raises are applied")
+		assert.Equal(t, bob, auction.DetermineWinner())
+
+		view, ok := auction.Snapshot().Bidder(alice.ID)
+		assert.True(t, ok)
+		assert.Equal(t, 2, view.AutoRaises)
+	})
+}
@@ -0,0 +1,112 @@
+package dispatchbidder
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/google/uuid"
+)
+
+// Strategy decides a bidder's next action given the current auction state.
+// NextBid returns the amount to bid and whether to bid at all.
+type Strategy interface {
+	NextBid(snapshot AuctionSnapshot, self BidderView) (amount float64, bid bool)
+}
+
+// StrategyFunc adapts an ordinary function to the Strategy interface.
+type StrategyFunc func(snapshot AuctionSnapshot, self BidderView) (float64, bool)
+
+// NextBid calls f(snapshot, self).
+func (f StrategyFunc) NextBid(snapshot AuctionSnapshot, self BidderView) (float64, bool) {
+	return f(snapshot, self)
+}
+
+// Conservative returns a strategy that only bids when trailing, raising to
+// one AutoIncrement above the leader, capped at MaxBid.
+func Conservative() Strategy {
+	return StrategyFunc(func(snapshot AuctionSnapshot, self BidderView) (float64, bool) {
+		leader, ok := snapshot.Leader()
+		if !ok || leader.ID == self.ID {
+			return 0, false
+		}
+
+		amount := min(leader.CurrentBid+self.AutoIncrement, self.MaxBid)
+		return amount, amount > self.CurrentBid
+	})
+}
+
+// Aggressive returns a strategy that, whenever trailing, jumps straight to
+// MaxBid.
+func Aggressive() Strategy {
+	return StrategyFunc(func(snapshot AuctionSnapshot, self BidderView) (float64, bool) {
+		if snapshot.LeaderID == self.ID {
+			return 0, false
+		}
+		return self.MaxBid, self.MaxBid > self.CurrentBid
+	})
+}
+
+// Random returns a strategy that, whenever trailing, raises to a random
+// whole-cent amount between one AutoIncrement above the leader (or the
+// StartingBid when nobody leads) and MaxBid. Pass the auction's Rand to make
+// runs reproducible with WithSeed.
+func Random(rng *rand.Rand) Strategy {
+	return StrategyFunc(func(snapshot AuctionSnapshot, self BidderView) (float64, bool) {
+		leader, ok := snapshot.Leader()
+		if ok && leader.ID == self.ID {
+			return 0, false
+		}
+
+		low := self.StartingBid
+		if ok {
+			low = max(low, leader.CurrentBid+self.AutoIncrement)
+		}
+		if low > self.MaxBid {
+			return 0, false
+		}
+
+		amount := low + rng.Float64()*(self.MaxBid-low)
+		return math.Min(math.Round(amount/cent)*cent, self.MaxBid), true
+	})
+}
+
+// AutoPlay drives the auction with the given strategies, keyed by bidder ID.
+// Each round, every bidder with a strategy is asked for its next bid in
+// registration order; rejected bids are ignored. Play stops when a round
+// places no bid or the auction stops accepting bids, returning the winner.
+func (a *Auction) AutoPlay(strategies map[uuid.UUID]Strategy) (*Bidder, error) {
+	for round := 0; round < maxRounds; round++ {
+		placed := false
+
+		for _, bidder := range a.BidderList() {
+			strategy, ok := strategies[bidder.ID]
+			if !ok {
+				continue
+			}
+
+			snapshot := a.Snapshot()
+			if snapshot.State != StateOpen {
+				return a.DetermineWinner(), nil
+			}
+			self, _ := snapshot.Bidder(bidder.ID)
+
+			amount, bid := strategy.NextBid(snapshot, self)
+			if !bid {
+				continue
+			}
+			if err := a.PlaceBid(bidder, amount); err == nil {
+				placed = true
+			} else if errors.Is(err, ErrAuctionClosed) || errors.Is(err, ErrAuctionCancelled) {
+				return a.DetermineWinner(), nil
+			}
+		}
+
+		if !placed {
+			return a.DetermineWinner(), nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: no settlement after %d rounds", ErrTooManyRounds, maxRounds)
+}
@@ -0,0 +1,56 @@
+package dispatchbidder
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAutoPlay tests that different strategy assignments change the winner.
+func TestAutoPlay(t *testing.T) {
+	tests := []struct {
+		name         string
+		alice        Strategy
+		bob          Strategy
+		expectedName string
+	}{
+		{name: "Conservative Alice is out-bumped by Bob", alice: Conservative(), expectedName: "Bob"},
+		{name: "Aggressive Alice outruns Bob's bumps", alice: Aggressive(), expectedName: "Alice"},
+		{name: "Conservative Bob answers aggressive Alice", alice: Aggressive(), bob: Conservative(), expectedName: "Bob"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alice := createBidder("Alice", 50.00, 95.00, 5.00)
+			bob := createBidder("Bob", 60.00, 150.00, 10.00)
+
+			auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+			assert.NoError(t, err)
+
+			strategies := map[uuid.UUID]Strategy{alice.ID: tt.alice}
+			if tt.bob != nil {
+				strategies[bob.ID] = tt.bob
+			}
+
+			winner, err := auction.AutoPlay(strategies)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedName, winner.Name)
+			assert.Equal(t, auction.DetermineWinner(), winner)
+		})
+	}
+
+	t.Run("Runaway strategy hits the round cap", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 1e9, 0.01)
+		bob := createBidder("Bob", 60.00, 1e9, 0.01)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+
+		raise := StrategyFunc(func(_ AuctionSnapshot, self BidderView) (float64, bool) {
+			return self.CurrentBid + 0.01, true
+		})
+		_, err = auction.AutoPlay(map[uuid.UUID]Strategy{alice.ID: raise})
+		assert.ErrorIs(t, err, ErrTooManyRounds)
+	})
+}
@@ -0,0 +1,193 @@
+package dispatchbidder
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// winnerChangeBuffer is the number of winner changes a SubscribeWinner
+// channel holds for a slow reader before the oldest ones are dropped.
+const winnerChangeBuffer = 16
+
+// WinnerChange reports that the provisional winner changed.
+type WinnerChange struct {
+	OldID  uuid.UUID // uuid.Nil when there was no winner.
+	NewID  uuid.UUID // uuid.Nil when there is no winner anymore.
+	Amount float64   // The new winner's CurrentBid, or zero.
+	At     time.Time
+}
+
+// winnerWatcher delivers winner changes to one SubscribeWinner caller.
+type winnerWatcher struct {
+	out  chan WinnerChange
+	last uuid.UUID // Winner as last reported.
+}
+
+// subscriber delivers snapshots of an auction to one Subscribe caller.
+type subscriber struct {
+	notify chan struct{} // Signalled, without blocking, on every change.
+	out    chan AuctionSnapshot
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// Subscribe returns a channel receiving a snapshot of the auction right away
+// and then after every change: accepted bids, bidder updates and lifecycle
+// transitions. Changes made in quick succession may be coalesced into one
+// snapshot, so a slow reader always catches up with the latest state rather
+// than falling behind. The returned cancel function stops the subscription
+// and closes the channel; it must be called to release resources and is safe
+// to call more than once.
+func (a *Auction) Subscribe() (<-chan AuctionSnapshot, func()) {
+	s := &subscriber{
+		notify: make(chan struct{}, 1),
+		out:    make(chan AuctionSnapshot),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+
+	a.Lock()
+	initial := a.snapshot()
+	a.subscribers = append(a.subscribers, s)
+	a.Unlock()
+
+	go a.runSubscriber(s, initial)
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			a.Lock()
+			for i, other := range a.subscribers {
+				if other == s {
+					a.subscribers = append(a.subscribers[:i], a.subscribers[i+1:]...)
+					break
+				}
+			}
+			a.Unlock()
+
+			close(s.stop)
+			<-s.done
+		})
+	}
+
+	return s.out, cancel
+}
+
+// SubscribeWinner returns a channel receiving a WinnerChange every time the
+// provisional winner, as returned by DetermineWinner, changes, including to
+// no winner, for live "current high bidder" displays. Sends never block the
+// auction: a reader falling more than a few changes behind misses the oldest
+// ones, so the latest change is always delivered. The returned cancel
+// function stops the subscription and closes the channel; it must be called
+// to release resources and is safe to call more than once.
+func (a *Auction) SubscribeWinner() (<-chan WinnerChange, func()) {
+	w := &winnerWatcher{out: make(chan WinnerChange, winnerChangeBuffer)}
+
+	a.Lock()
+	if winner := a.determineWinner(); winner != nil {
+		w.last = winner.ID
+	}
+	a.watchers = append(a.watchers, w)
+	a.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			a.Lock()
+			defer a.Unlock()
+
+			for i, other := range a.watchers {
+				if other == w {
+					a.watchers = append(a.watchers[:i], a.watchers[i+1:]...)
+					break
+				}
+			}
+			close(w.out)
+		})
+	}
+
+	return w.out, cancel
+}
+
+// notifyWinner sends a WinnerChange to every winner watcher who last saw a
+// different winner. It never blocks: a full channel drops its oldest change
+// instead. The caller must hold the lock.
+func (a *Auction) notifyWinner() {
+	if len(a.watchers) == 0 {
+		return
+	}
+
+	change := WinnerChange{At: a.now()}
+	if winner := a.determineWinner(); winner != nil {
+		change.NewID = winner.ID
+		change.Amount = winner.CurrentBid
+	}
+	for _, w := range a.watchers {
+		if w.last == change.NewID {
+			continue
+		}
+		change.OldID = w.last
+		w.last = change.NewID
+		w.send(change)
+	}
+}
+
+// send delivers the change, dropping the oldest buffered one while the
+// channel is full.
+func (w *winnerWatcher) send(change WinnerChange) {
+	for {
+		select {
+		case w.out <- change:
+			return
+		default:
+		}
+		select {
+		case <-w.out:
+		default:
+		}
+	}
+}
+
+// runSubscriber delivers the initial snapshot, then takes a fresh snapshot on
+// every notification and delivers it, skipping snapshots identical in version
+// and state to the last one.
+func (a *Auction) runSubscriber(s *subscriber, initial AuctionSnapshot) {
+	defer close(s.done)
+	defer close(s.out)
+
+	snapshot := initial
+	for {
+		select {
+		case <-s.stop:
+			return
+		case s.out <- snapshot:
+		}
+
+		last := snapshot
+		for snapshot.Version == last.Version && snapshot.State == last.State {
+			select {
+			case <-s.stop:
+				return
+			case <-s.notify:
+			}
+			snapshot = a.Snapshot()
+		}
+	}
+}
+
+// notify signals every subscriber that the auction changed, reports winner
+// changes and queues the change for auto-persistence. It never blocks. The
+// caller must hold the lock.
+func (a *Auction) notify() {
+	a.persist()
+	a.notifyWinner()
+
+	for _, s := range a.subscribers {
+		select {
+		case s.notify <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package dispatchbidder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// receiveSnapshot waits for the next snapshot from a subscription.
+func receiveSnapshot(t *testing.T, updates <-chan AuctionSnapshot) AuctionSnapshot {
+	t.Helper()
+
+	select {
+	case snapshot, ok := <-updates:
+		assert.True(t, ok, "subscription closed unexpectedly")
+		return snapshot
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for a snapshot")
+		return AuctionSnapshot{}
+	}
+}
+
+// TestSubscribe tests that subscribers receive snapshots on every change.
+func TestSubscribe(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 80.00, 3.00)
+	bob := createBidder("Bob", 60.00, 82.00, 2.00)
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+	assert.NoError(t, err)
+
+	updates, cancel := auction.Subscribe()
+	defer cancel()
+
+	initial := receiveSnapshot(t, updates)
+	assert.Equal(t, uint64(0), initial.Version)
+	assert.Equal(t, StateOpen, initial.State)
+
+	assert.NoError(t, auction.PlaceBid(alice, 55.00))
+	afterBid := receiveSnapshot(t, updates)
+	assert.Equal(t, uint64(1), afterBid.Version)
+	assert.Equal(t, auction.Snapshot().LeaderID, afterBid.LeaderID)
+
+	_, err = auction.Close()
+	assert.NoError(t, err)
+	closed := receiveSnapshot(t, updates)
+	assert.Equal(t, StateClosed, closed.State)
+
+	cancel()
+	_, ok := <-updates
+	assert.False(t, ok, "cancel closes the channel")
+	cancel()
+
+	auction.RLock()
+	assert.Empty(t, auction.subscribers)
+	auction.RUnlock()
+}
+
+// TestSubscribeWinner tests that winner subscribers receive every leadership
+// change, including to no winner.
+func TestSubscribeWinner(t *testing.T) {
+	alice := createBidder("Alice", 50.00, 80.00, 0)
+	bob := createBidder("Bob", 60.00, 100.00, 0)
+	clock := newManualClock()
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, WithClock(clock))
+	assert.NoError(t, err)
+
+	changes, cancel := auction.SubscribeWinner()
+	defer cancel()
+
+	assert.NoError(t, auction.PlaceBid(alice, 70.00))
+	assert.NoError(t, auction.PlaceBid(alice, 75.00)) // Alice keeps the lead.
+	assert.NoError(t, auction.PlaceBid(bob, 90.00))
+	assert.NoError(t, auction.RetractBidder(bob.ID))
+	assert.NoError(t, auction.Cancel("item withdrawn"))
+
+	expected := []WinnerChange{
+		{OldID: bob.ID, NewID: alice.ID, Amount: 70.00, At: clock.Now()},
+		{OldID: alice.ID, NewID: bob.ID, Amount: 90.00, At: clock.Now()},
+		{OldID: bob.ID, NewID: alice.ID, Amount: 75.00, At: clock.Now()},
+		{OldID: alice.ID, At: clock.Now()},
+	}
+	for _, want := range expected {
+		select {
+		case change := <-changes:
+			assert.Equal(t, want, change)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for a winner change")
+		}
+	}
+	select {
+	case change := <-changes:
+		t.Fatalf("unexpected change %+v", change)
+	default:
+	}
+
+	cancel()
+	_, ok := <-changes
+	assert.False(t, ok, "cancel closes the channel")
+	cancel()
+
+	auction.RLock()
+	assert.Empty(t, auction.watchers)
+	auction.RUnlock()
+
+	t.Run("Slow readers get the latest change", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 500.00, 0)
+		bob := createBidder("Bob", 60.00, 500.00, 0)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+		changes, cancel := auction.SubscribeWinner()
+		defer cancel()
+
+		for i := 1; i <= winnerChangeBuffer+5; i++ {
+			assert.NoError(t, auction.PlaceBid(alice, 60.00+float64(2*i)))
+			assert.NoError(t, auction.PlaceBid(bob, 61.00+float64(2*i)))
+		}
+
+		var last WinnerChange
+		for len(changes) > 0 {
+			last = <-changes
+		}
+		assert.Equal(t, bob.ID, last.NewID)
+		assert.Equal(t, bob.CurrentBid, last.Amount)
+	})
+}
@@ -0,0 +1,178 @@
+package dispatchbidder
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SuspicionType identifies the kind of suspicious bidding pattern.
+type SuspicionType int
+
+const (
+	// RapidFire flags a bidder placing many manual bids within a short window.
+	RapidFire SuspicionType = iota
+	// ShillPattern flags a bidder who only ever bids just above one specific
+	// rival.
+	ShillPattern
+)
+
+// String returns the human-readable name of the suspicion type.
+func (t SuspicionType) String() string {
+	switch t {
+	case RapidFire:
+		return "RapidFire"
+	case ShillPattern:
+		return "ShillPattern"
+	default:
+		return fmt.Sprintf("SuspicionType(%d)", int(t))
+	}
+}
+
+// SuspicionFlag reports a suspicious pattern found in the bid history.
+type SuspicionFlag struct {
+	Type     SuspicionType
+	BidderID uuid.UUID
+	RivalID  uuid.UUID // Set for ShillPattern flags.
+	Detail   string
+}
+
+// suspicionConfig holds the thresholds used by AnalyzeSuspicion.
+type suspicionConfig struct {
+	rapidFireBids   int
+	rapidFireWindow time.Duration
+	shillMinBids    int
+	shillMargin     float64
+}
+
+// SuspicionOption configures the thresholds used by AnalyzeSuspicion.
+type SuspicionOption func(*suspicionConfig)
+
+// WithRapidFireThreshold flags bidders placing at least bids manual bids
+// within window. Defaults to 5 bids within 10 seconds.
+func WithRapidFireThreshold(bids int, window time.Duration) SuspicionOption {
+	return func(c *suspicionConfig) {
+		c.rapidFireBids = bids
+		c.rapidFireWindow = window
+	}
+}
+
+// WithShillThreshold flags bidders whose manual bids, at least minBids of
+// them, all land within margin above the same rival's bid. Defaults to 3 bids
+// within $1.00.
+func WithShillThreshold(minBids int, margin float64) SuspicionOption {
+	return func(c *suspicionConfig) {
+		c.shillMinBids = minBids
+		c.shillMargin = margin
+	}
+}
+
+// AnalyzeSuspicion inspects the bid history for suspicious patterns and
+// returns one flag per offending bidder and pattern, in registration order.
+func (a *Auction) AnalyzeSuspicion(opts ...SuspicionOption) []SuspicionFlag {
+	cfg := suspicionConfig{
+		rapidFireBids:   5,
+		rapidFireWindow: 10 * time.Second,
+		shillMinBids:    3,
+		shillMargin:     1.00,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	a.RLock()
+	defer a.RUnlock()
+
+	var flags []SuspicionFlag
+	for _, bidder := range a.Bidders {
+		if flag, ok := a.detectRapidFire(bidder.ID, cfg); ok {
+			flags = append(flags, flag)
+		}
+	}
+	for _, bidder := range a.Bidders {
+		if flag, ok := a.detectShill(bidder.ID, cfg); ok {
+			flags = append(flags, flag)
+		}
+	}
+
+	return flags
+}
+
+// detectRapidFire looks for too many manual bids by the bidder within the
+// configured window. The caller must hold at least a read lock.
+func (a *Auction) detectRapidFire(id uuid.UUID, cfg suspicionConfig) (SuspicionFlag, bool) {
+	if cfg.rapidFireBids <= 0 {
+		return SuspicionFlag{}, false
+	}
+
+	var times []time.Time
+	for _, event := range a.history {
+		if event.BidderID == id && !event.Auto {
+			times = append(times, event.Time)
+		}
+	}
+
+	for i := 0; i+cfg.rapidFireBids <= len(times); i++ {
+		first, last := times[i], times[i+cfg.rapidFireBids-1]
+		if last.Sub(first) <= cfg.rapidFireWindow {
+			return SuspicionFlag{
+				Type:     RapidFire,
+				BidderID: id,
+				Detail:   fmt.Sprintf("%d manual bids within %s", cfg.rapidFireBids, last.Sub(first)),
+			}, true
+		}
+	}
+
+	return SuspicionFlag{}, false
+}
+
+// detectShill replays the history looking for a bidder whose every manual bid
+// lands just above the same rival's standing bid. The caller must hold at
+// least a read lock.
+func (a *Auction) detectShill(id uuid.UUID, cfg suspicionConfig) (SuspicionFlag, bool) {
+	if cfg.shillMinBids <= 0 {
+		return SuspicionFlag{}, false
+	}
+
+	standing := make(map[uuid.UUID]float64, len(a.Bidders))
+	for _, bidder := range a.Bidders {
+		standing[bidder.ID] = bidder.StartingBid
+	}
+
+	var rival uuid.UUID
+	bids := 0
+	for _, event := range a.history {
+		if event.BidderID == id && !event.Auto {
+			// Find the top competing bid at the time of this bid.
+			top, topBid := uuid.Nil, 0.0
+			for _, bidder := range a.Bidders {
+				if bidder.ID != id && (top == uuid.Nil || standing[bidder.ID] > topBid) {
+					top, topBid = bidder.ID, standing[bidder.ID]
+				}
+			}
+
+			diff := event.Amount - topBid
+			if top == uuid.Nil || diff <= 0 || diff > cfg.shillMargin {
+				return SuspicionFlag{}, false
+			}
+			if rival != uuid.Nil && rival != top {
+				return SuspicionFlag{}, false
+			}
+			rival = top
+			bids++
+		}
+		standing[event.BidderID] = event.Amount
+	}
+
+	if bids < cfg.shillMinBids {
+		return SuspicionFlag{}, false
+	}
+
+	return SuspicionFlag{
+		Type:     ShillPattern,
+		BidderID: id,
+		RivalID:  rival,
+		Detail:   fmt.Sprintf("%d manual bids within $%.2f above the same rival", bids, cfg.shillMargin),
+	}, true
+}
@@ -0,0 +1,111 @@
+package dispatchbidder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAnalyzeSuspicion tests suspicion flags against scripted histories.
+func TestAnalyzeSuspicion(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	newAuction := func(t *testing.T) (*Auction, *Bidder, *Bidder, *Bidder) {
+		alice := createBidder("Alice", 50.00, 500.00, 5.00)
+		bob := createBidder("Bob", 50.00, 500.00, 5.00)
+		carol := createBidder("Carol", 50.00, 500.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob, carol}})
+		assert.NoError(t, err)
+
+		return auction, alice, bob, carol
+	}
+
+	t.Run("RapidFire triggers on bursts", func(t *testing.T) {
+		auction, alice, _, _ := newAuction(t)
+		for i := 0; i < 5; i++ {
+			auction.history = append(auction.history, BidEvent{BidderID: alice.ID, Amount: 60.00 + float64(i), Time: start.Add(time.Duration(i) * time.Second)})
+		}
+
+		flags := auction.AnalyzeSuspicion()
+		if assert.Len(t, flags, 1) {
+			assert.Equal(t, RapidFire, flags[0].Type)
+			assert.Equal(t, alice.ID, flags[0].BidderID)
+		}
+	})
+
+	t.Run("RapidFire does not trigger on spaced bids", func(t *testing.T) {
+		auction, alice, _, _ := newAuction(t)
+		for i := 0; i < 5; i++ {
+			auction.history = append(auction.history, BidEvent{BidderID: alice.ID, Amount: 60.00 + float64(i), Time: start.Add(time.Duration(i) * time.Minute)})
+		}
+
+		assert.Empty(t, auction.AnalyzeSuspicion())
+	})
+
+	t.Run("RapidFire ignores auto-increments and honors thresholds", func(t *testing.T) {
+		auction, alice, _, _ := newAuction(t)
+		for i := 0; i < 5; i++ {
+			auction.history = append(auction.history, BidEvent{BidderID: alice.ID, Amount: 60.00 + float64(i), Time: start, Auto: true})
+		}
+		assert.Empty(t, auction.AnalyzeSuspicion())
+
+		for i := 0; i < 3; i++ {
+			auction.history = append(auction.history, BidEvent{BidderID: alice.ID, Amount: 70.00 + float64(i), Time: start.Add(time.Duration(i) * time.Minute)})
+		}
+		assert.Empty(t, auction.AnalyzeSuspicion())
+		assert.Len(t, auction.AnalyzeSuspicion(WithRapidFireThreshold(3, 5*time.Minute)), 1)
+	})
+
+	t.Run("ShillPattern triggers on bids just above one rival", func(t *testing.T) {
+		auction, alice, bob, _ := newAuction(t)
+		minute := func(i int) time.Time { return start.Add(time.Duration(i) * time.Minute) }
+		auction.history = []BidEvent{
+			{BidderID: alice.ID, Amount: 100.00, Time: minute(0)},
+			{BidderID: bob.ID, Amount: 100.50, Time: minute(1)},
+			{BidderID: alice.ID, Amount: 150.00, Time: minute(2)},
+			{BidderID: bob.ID, Amount: 150.25, Time: minute(3)},
+			{BidderID: alice.ID, Amount: 200.00, Time: minute(4)},
+			{BidderID: bob.ID, Amount: 201.00, Time: minute(5)},
+		}
+
+		flags := auction.AnalyzeSuspicion()
+		if assert.Len(t, flags, 1) {
+			assert.Equal(t, ShillPattern, flags[0].Type)
+			assert.Equal(t, bob.ID, flags[0].BidderID)
+			assert.Equal(t, alice.ID, flags[0].RivalID)
+		}
+	})
+
+	t.Run("ShillPattern does not trigger when the rival changes", func(t *testing.T) {
+		auction, alice, bob, carol := newAuction(t)
+		minute := func(i int) time.Time { return start.Add(time.Duration(i) * time.Minute) }
+		auction.history = []BidEvent{
+			{BidderID: alice.ID, Amount: 100.00, Time: minute(0)},
+			{BidderID: bob.ID, Amount: 100.50, Time: minute(1)},
+			{BidderID: carol.ID, Amount: 150.00, Time: minute(2)},
+			{BidderID: bob.ID, Amount: 150.25, Time: minute(3)},
+			{BidderID: alice.ID, Amount: 200.00, Time: minute(4)},
+			{BidderID: bob.ID, Amount: 201.00, Time: minute(5)},
+		}
+
+		assert.Empty(t, auction.AnalyzeSuspicion())
+	})
+
+	t.Run("ShillPattern does not trigger on large jumps", func(t *testing.T) {
+		auction, alice, bob, _ := newAuction(t)
+		minute := func(i int) time.Time { return start.Add(time.Duration(i) * time.Minute) }
+		auction.history = []BidEvent{
+			{BidderID: alice.ID, Amount: 100.00, Time: minute(0)},
+			{BidderID: bob.ID, Amount: 110.00, Time: minute(1)},
+			{BidderID: alice.ID, Amount: 150.00, Time: minute(2)},
+			{BidderID: bob.ID, Amount: 160.00, Time: minute(3)},
+			{BidderID: alice.ID, Amount: 200.00, Time: minute(4)},
+			{BidderID: bob.ID, Amount: 210.00, Time: minute(5)},
+		}
+
+		assert.Empty(t, auction.AnalyzeSuspicion())
+		assert.Len(t, auction.AnalyzeSuspicion(WithShillThreshold(3, 10.00)), 1)
+	})
+}
@@ -0,0 +1,70 @@
+package dispatchbidder
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// SetNextTarget makes the bidder's next auto-raise jump to exactly the given
+// amount instead of adding their AutoIncrement, for bidders who plan in
+// absolute amounts. The target is consumed by the bidder's next accepted bid,
+// automatic or manual; if it has been overtaken by then, the AutoIncrement
+// applies as usual. The target must be above the bidder's CurrentBid and
+// a legal bid for them. A zero amount clears the target.
+func (a *Auction) SetNextTarget(bidderID uuid.UUID, amount float64) error {
+	a.Lock()
+	defer a.Unlock()
+
+	bidder, ok := a.bidderByID(bidderID)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrBidderNotFound, bidderID)
+	}
+	if amount == 0 {
+		bidder.nextTarget = 0
+		return nil
+	}
+
+	if err := a.checkOpen(); err != nil {
+		return err
+	}
+	if err := bidder.checkCanBid(); err != nil {
+		return err
+	}
+	if !a.onGrid(bidder, amount) {
+		if !a.autoAlign {
+			return fmt.Errorf("%w: target $%.2f is not on the $%.2f grid", ErrOffGrid, amount, a.BidGridStep)
+		}
+		amount = a.alignToGrid(bidder, amount)
+	}
+	if amount > bidder.MaxBid {
+		return fmt.Errorf("%w: target $%.2f is greater than max bid $%.2f", ErrAboveMaxBid, amount, bidder.MaxBid)
+	}
+	if amount <= bidder.CurrentBid {
+		return fmt.Errorf("%w: target $%.2f is less than or equal to current bid $%.2f", ErrNotAboveCurrentBid, amount, bidder.CurrentBid)
+	}
+	if !a.withinCap(amount) {
+		return fmt.Errorf("%w: target $%.2f is greater than auction cap $%.2f", ErrExceedsAuctionCap, amount, a.AuctionMaxBid)
+	}
+
+	bidder.nextTarget = amount
+	a.version++
+	a.notify()
+
+	return nil
+}
+
+// autoRaises reports whether the bidder responds to being outbid.
+func (b *Bidder) autoRaises() bool {
+	return !b.isFixed() || b.nextTarget > b.CurrentBid
+}
+
+// bumpAmount returns the amount of the bidder's next auto-raise: their next
+// bid target when still ahead of their bid, otherwise their CurrentBid plus
+// their increment.
+func (b *Bidder) bumpAmount() float64 {
+	if b.nextTarget > b.CurrentBid {
+		return b.nextTarget
+	}
+	return b.CurrentBid + b.increment()
+}
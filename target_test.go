@@ -0,0 +1,88 @@
+package dispatchbidder
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSetNextTarget tests that a targeted bidder jumps to their target on the
+// next bump.
+func TestSetNextTarget(t *testing.T) {
+	t.Run("Jumps to the target once", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 200.00, 3.00)
+		bob := createBidder("Bob", 60.00, 200.00, 2.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.SetNextTarget(bob.ID, 100.00))
+		assert.NoError(t, auction.PlaceBid(alice, 70.00))
+		assert.Equal(t, 100.00, bob.CurrentBid, "Bob jumps to his target")
+
+		assert.NoError(t, auction.PlaceBid(alice, 110.00))
+		assert.Equal(t, 102.00, bob.CurrentBid, "the target is consumed")
+	})
+
+	t.Run("Fixed bidder with a target", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 200.00, 3.00)
+		fixed := createBidder("Fixed", 60.00, 200.00, 0)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, fixed}})
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.SetNextTarget(fixed.ID, 90.00))
+		assert.NoError(t, auction.PlaceBid(alice, 70.00))
+		assert.Equal(t, 90.00, fixed.CurrentBid)
+
+		assert.NoError(t, auction.PlaceBid(alice, 95.00))
+		assert.Equal(t, 90.00, fixed.CurrentBid)
+	})
+
+	t.Run("A manual bid consumes the target", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 200.00, 3.00)
+		bob := createBidder("Bob", 60.00, 200.00, 2.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.SetNextTarget(bob.ID, 100.00))
+		assert.NoError(t, auction.PlaceBid(bob, 120.00))
+
+		assert.NoError(t, auction.PlaceBid(alice, 130.00))
+		assert.Equal(t, 122.00, bob.CurrentBid)
+	})
+
+	t.Run("Undo restores the target", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 200.00, 3.00)
+		bob := createBidder("Bob", 60.00, 200.00, 2.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, WithUndoRevertsBumps())
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.SetNextTarget(bob.ID, 100.00))
+		assert.NoError(t, auction.PlaceBid(alice, 70.00))
+		assert.NoError(t, auction.UndoLastBid(alice.ID))
+		assert.Equal(t, 60.00, bob.CurrentBid)
+		assert.Equal(t, 100.00, bob.nextTarget)
+	})
+
+	t.Run("Invalid targets", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 200.00, 3.00)
+		bob := createBidder("Bob", 60.00, 200.00, 2.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, AuctionMaxBid: 150.00})
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, auction.SetNextTarget(uuid.New(), 100.00), ErrBidderNotFound)
+		assert.ErrorIs(t, auction.SetNextTarget(bob.ID, 60.00), ErrNotAboveCurrentBid)
+		assert.ErrorIs(t, auction.SetNextTarget(bob.ID, 250.00), ErrAboveMaxBid)
+		assert.ErrorIs(t, auction.SetNextTarget(bob.ID, 160.00), ErrExceedsAuctionCap)
+
+		assert.NoError(t, auction.SetNextTarget(bob.ID, 100.00))
+		assert.NoError(t, auction.SetNextTarget(bob.ID, 0))
+		assert.NoError(t, auction.PlaceBid(alice, 70.00))
+		assert.Equal(t, 62.00, bob.CurrentBid)
+	})
+}
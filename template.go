@@ -0,0 +1,31 @@
+package dispatchbidder
+
+// AuctionTemplate captures the configuration and options of similar auctions
+// so fresh ones can be built repeatedly without restating them. A template
+// is immutable and safe for concurrent use.
+type AuctionTemplate struct {
+	config NewAuctionConfig
+	opts   []Option
+}
+
+// NewAuctionTemplate returns a template building auctions with the given
+// configuration and options. Any Bidders in the configuration are ignored;
+// pass them to Build instead. Options are applied to every built auction, so
+// values they carry, such as a clock or an event sink, are shared.
+func NewAuctionTemplate(na NewAuctionConfig, opts ...Option) AuctionTemplate {
+	na.Bidders = nil
+	na.IncrementTable = append(IncrementTable(nil), na.IncrementTable...)
+
+	return AuctionTemplate{
+		config: na,
+		opts:   append([]Option(nil), opts...),
+	}
+}
+
+// Build creates a new auction from the template with the given bidders.
+func (t AuctionTemplate) Build(bidders ...*Bidder) (*Auction, error) {
+	na := t.config
+	na.Bidders = bidders
+
+	return NewAuction(na, t.opts...)
+}
@@ -0,0 +1,59 @@
+package dispatchbidder
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAuctionTemplate tests building independent auctions from one template.
+func TestAuctionTemplate(t *testing.T) {
+	table := IncrementTable{{UpTo: 100.00, Increment: 1.00}, {Increment: 5.00}}
+	template := NewAuctionTemplate(NewAuctionConfig{
+		AuctionMaxBid:  500.00,
+		BidGridStep:    0.50,
+		IncrementTable: table,
+		Bidders:        []*Bidder{createBidder("Ignored", 1.00, 2.00, 1.00)},
+	}, WithTieEpsilon(time.Millisecond), WithAutoAlign())
+
+	// The template keeps its own copy of the table.
+	table[0].Increment = 99.00
+
+	first, err := template.Build(createBidder("Alice", 50.00, 80.00, 3.00), createBidder("Bob", 60.00, 82.00, 2.00))
+	assert.NoError(t, err)
+	second, err := template.Build(createBidder("Carol", 55.00, 120.00, 5.00), createBidder("Dave", 40.00, 70.00, 5.00))
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, first.ID, second.ID)
+	for _, a := range []*Auction{first, second} {
+		assert.Equal(t, 500.00, a.AuctionMaxBid)
+		assert.Equal(t, 0.50, a.BidGridStep)
+		assert.Equal(t, IncrementTable{{UpTo: 100.00, Increment: 1.00}, {Increment: 5.00}}, a.IncrementTable)
+		assert.Equal(t, time.Millisecond, a.tieEpsilon)
+		assert.True(t, a.autoAlign)
+		assert.Len(t, a.Bidders, 2)
+	}
+
+	_, err = first.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, StateClosed, first.State())
+	assert.Equal(t, StateOpen, second.State(), "built auctions have independent state")
+
+	_, err = template.Build(createBidder("Solo", 50.00, 80.00, 3.00))
+	assert.Error(t, err)
+
+	t.Run("Concurrent builds", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := template.Build(createBidder("Alice", 50.00, 80.00, 3.00), createBidder("Bob", 60.00, 82.00, 2.00))
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+	})
+}
@@ -0,0 +1,65 @@
+package dispatchbidder
+
+// TieBreakFunc orders two bidders with equal bids: it returns a negative
+// number when x ranks ahead of y, a positive one when y ranks ahead of x, and
+// zero when the rule does not tell them apart. It must not modify the
+// bidders.
+type TieBreakFunc func(x, y *Bidder) int
+
+// WithTieBreakChain replaces the earliest-bid tie-break with an ordered chain
+// of rules: bidders with equal bids, or equal scores under recency
+// weighting, are ordered by the first rule that tells them apart. When every
+// rule ties, the earliest-bid tie-break still decides, so rankings stay
+// deterministic.
+func WithTieBreakChain(rules ...TieBreakFunc) Option {
+	return func(a *Auction) {
+		a.tieBreaks = append([]TieBreakFunc(nil), rules...)
+	}
+}
+
+// TieBreakByMaxBid ranks the bidder with the higher MaxBid first.
+func TieBreakByMaxBid(x, y *Bidder) int {
+	return compareFloats(y.MaxBid, x.MaxBid)
+}
+
+// TieBreakByTime ranks the bidder whose last bid is earlier first.
+func TieBreakByTime(x, y *Bidder) int {
+	return x.LastBidTime.Compare(y.LastBidTime)
+}
+
+// TieBreakBySequence ranks the bidder registered or bidding first, as given
+// by BidderView.Sequence, first.
+func TieBreakBySequence(x, y *Bidder) int {
+	switch {
+	case x.seq < y.seq:
+		return -1
+	case x.seq > y.seq:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// breaksTie reports whether the bidder ranks ahead of the other, whose bid is
+// equal to theirs.
+func (a *Auction) breaksTie(bidder, other *Bidder) bool {
+	for _, rule := range a.tieBreaks {
+		if c := rule(bidder, other); c != 0 {
+			return c < 0
+		}
+	}
+	return a.bidEarlier(bidder, other)
+}
+
+// compareFloats returns -1, 0 or 1 as x is less than, equal to or greater
+// than y.
+func compareFloats(x, y float64) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,76 @@
+package dispatchbidder
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTieBreakChain tests that tie-break rules apply in order, falling
+// through on ties.
+func TestTieBreakChain(t *testing.T) {
+	bySeniority := func(x, y *Bidder) int {
+		return strings.Compare(x.Metadata["member_since"], y.Metadata["member_since"])
+	}
+	byAutoIncrement := func(x, y *Bidder) int {
+		return compareFloats(y.AutoIncrement, x.AutoIncrement)
+	}
+
+	newBidders := func() (*Bidder, *Bidder) {
+		at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+		alice := createBidder("Alice", 80.00, 100.00, 1.00)
+		alice.Metadata = map[string]string{"member_since": "2019"}
+		alice.LastBidTime = at
+		bob := createBidder("Bob", 80.00, 100.00, 2.00)
+		bob.Metadata = map[string]string{"member_since": "2019"}
+		bob.LastBidTime = at.Add(time.Second)
+		return alice, bob
+	}
+
+	t.Run("Third rule decides", func(t *testing.T) {
+		alice, bob := newBidders()
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}},
+			WithTieBreakChain(bySeniority, TieBreakByMaxBid, byAutoIncrement, TieBreakByTime))
+		assert.NoError(t, err)
+
+		assert.Equal(t, bob, auction.DetermineWinner(), "Bob's larger AutoIncrement decides over Alice's earlier bid")
+		assert.Equal(t, bob, auction.Leader())
+		board := auction.Leaderboard()
+		assert.Equal(t, []string{"Bob", "Alice"}, []string{board[0].Name, board[1].Name})
+	})
+
+	t.Run("Exhausted chain falls back to the earliest bid", func(t *testing.T) {
+		alice, bob := newBidders()
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}},
+			WithTieBreakChain(bySeniority, TieBreakByMaxBid))
+		assert.NoError(t, err)
+
+		assert.Equal(t, alice, auction.DetermineWinner())
+	})
+
+	t.Run("Proxy resolution", func(t *testing.T) {
+		alice, bob := newBidders()
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}},
+			WithTieBreakChain(byAutoIncrement))
+		assert.NoError(t, err)
+
+		winner, _ := auction.EquilibriumOutcome()
+		assert.Equal(t, bob, winner, "equal ceilings are broken by the chain")
+		leader, err := auction.ResolveProxies()
+		assert.NoError(t, err)
+		assert.Equal(t, bob, leader)
+		assert.Equal(t, bob, auction.DetermineWinner())
+	})
+
+	t.Run("Built-in rules", func(t *testing.T) {
+		alice, bob := newBidders()
+		bob.MaxBid = 120.00
+		assert.Equal(t, 1, TieBreakByMaxBid(alice, bob))
+		assert.Equal(t, -1, TieBreakByTime(alice, bob))
+		alice.seq, bob.seq = 2, 1
+		assert.Equal(t, 1, TieBreakBySequence(alice, bob))
+		assert.Equal(t, 0, TieBreakBySequence(alice, alice))
+	})
+}
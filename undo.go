@@ -0,0 +1,72 @@
+package dispatchbidder
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// WithUndoRevertsBumps makes UndoLastBid also revert the auto-increments the
+// undone bid caused, for every bumped bidder that has not bid since.
+func WithUndoRevertsBumps() Option {
+	return func(a *Auction) {
+		a.undoRevertsBumps = true
+	}
+}
+
+// UndoLastBid pops the bidder's most recent manual bid from the history and
+// restores their prior CurrentBid and LastBidTime. Any auto-increments the
+// bidder received after that bid are popped as well, since they built on it.
+// It can be called repeatedly until the bidder is back at their starting
+// state, after which it returns ErrNothingToUndo.
+func (a *Auction) UndoLastBid(bidderID uuid.UUID) error {
+	a.Lock()
+	defer a.Unlock()
+
+	if err := a.checkOpen(); err != nil {
+		return err
+	}
+
+	bidder, ok := a.bidderByID(bidderID)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrBidderNotFound, bidderID)
+	}
+
+	last := -1
+	for i := len(a.history) - 1; i >= 0; i-- {
+		if a.history[i].BidderID == bidderID && !a.history[i].Auto {
+			last = i
+			break
+		}
+	}
+	if last < 0 {
+		return fmt.Errorf("%w: bidder ID %s has no manual bid", ErrNothingToUndo, bidderID)
+	}
+
+	undone := a.history[last]
+	undone.restore(bidder)
+
+	// -----------------------------------------------------------------------
+	// Drop the undone bid, the bidder's later events and, when configured,
+	// the bumps it caused on bidders whose state still reflects the bump.
+
+	kept := a.history[:last]
+	for _, event := range a.history[last+1:] {
+		if event.BidderID == bidderID {
+			continue
+		}
+		if a.undoRevertsBumps && event.Auto && event.CausedBy == undone.Seq {
+			if other, ok := a.bidderByID(event.BidderID); ok && other.seq == event.Seq {
+				event.restore(other)
+				continue
+			}
+		}
+		kept = append(kept, event)
+	}
+	a.history = kept
+	a.refreshLeader()
+	a.version++
+	a.notify()
+
+	return nil
+}
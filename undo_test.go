@@ -0,0 +1,81 @@
+package dispatchbidder
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestUndoLastBid tests multi-step undo down to the starting bid.
+func TestUndoLastBid(t *testing.T) {
+	t.Run("Multi-step undo down to StartingBid", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 200.00, 5.00)
+		bob := createBidder("Bob", 60.00, 200.00, 5.00)
+		start := alice.LastBidTime
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}}, WithClock(newManualClock()))
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.PlaceBid(alice, 70.00))
+		firstBidTime := alice.LastBidTime
+		assert.NoError(t, auction.PlaceBid(alice, 80.00))
+		assert.NoError(t, auction.PlaceBid(alice, 90.00))
+
+		assert.NoError(t, auction.UndoLastBid(alice.ID))
+		assert.Equal(t, 80.00, alice.CurrentBid)
+
+		assert.NoError(t, auction.UndoLastBid(alice.ID))
+		assert.Equal(t, 70.00, alice.CurrentBid)
+		assert.Equal(t, firstBidTime, alice.LastBidTime)
+
+		assert.NoError(t, auction.UndoLastBid(alice.ID))
+		assert.Equal(t, 50.00, alice.CurrentBid)
+		assert.Equal(t, start, alice.LastBidTime)
+
+		assert.ErrorIs(t, auction.UndoLastBid(alice.ID), ErrNothingToUndo)
+		assert.Equal(t, 50.00, alice.CurrentBid)
+
+		// Bumps are kept by default.
+		assert.Equal(t, 75.00, bob.CurrentBid)
+	})
+
+	t.Run("Bumps caused by the bid are reverted when configured", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 200.00, 5.00)
+		bob := createBidder("Bob", 60.00, 200.00, 5.00)
+		carol := createBidder("Carol", 55.00, 200.00, 5.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob, carol}}, WithUndoRevertsBumps())
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.PlaceBid(alice, 70.00)) // Bob 65, Carol 60.
+		assert.NoError(t, auction.PlaceBid(carol, 80.00)) // Alice 75, Bob 70.
+		assert.NoError(t, auction.PlaceBid(alice, 90.00)) // Bob 75, Carol 85.
+
+		assert.NoError(t, auction.UndoLastBid(alice.ID))
+		assert.Equal(t, 75.00, alice.CurrentBid, "back to the bump received before the undone bid")
+		assert.Equal(t, 70.00, bob.CurrentBid)
+		assert.Equal(t, 80.00, carol.CurrentBid)
+
+		// Alice's first bid: her later bump is dropped along with it, while
+		// Bob's and Carol's bumps from it are superseded and stay.
+		assert.NoError(t, auction.UndoLastBid(alice.ID))
+		assert.Equal(t, 50.00, alice.CurrentBid)
+		assert.Equal(t, 70.00, bob.CurrentBid)
+		assert.Equal(t, 80.00, carol.CurrentBid)
+
+		for _, event := range auction.History() {
+			assert.NotEqual(t, alice.ID, event.BidderID)
+		}
+	})
+
+	t.Run("Unknown bidder", func(t *testing.T) {
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{
+			createBidder("Alice", 50.00, 200.00, 5.00),
+			createBidder("Bob", 60.00, 200.00, 5.00),
+		}})
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, auction.UndoLastBid(uuid.New()), ErrBidderNotFound)
+	})
+}
@@ -0,0 +1,42 @@
+package dispatchbidder
+
+import "github.com/google/uuid"
+
+// DetermineWinners allocates the auction's Units greedily by price: bidders
+// still in the running are served in the order of DetermineWinner, highest
+// bid first with ties going to the earliest bid, each getting their
+// DesiredQuantity or whatever is left. The marginal winner may thus get fewer
+// units than desired. It returns the number of units allocated to each
+// winning bidder; a cancelled auction allocates nothing.
+func (a *Auction) DetermineWinners() map[uuid.UUID]int {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.allocate()
+}
+
+// allocate allocates the units as DetermineWinners does. The caller must hold
+// at least a read lock.
+func (a *Auction) allocate() map[uuid.UUID]int {
+	allocation := make(map[uuid.UUID]int)
+	if a.state.withoutWinner() {
+		return allocation
+	}
+
+	remaining := max(a.Units, 1)
+	for _, bidder := range a.rankBidders() {
+		if remaining == 0 {
+			break
+		}
+		units := min(bidder.desiredQuantity(), remaining)
+		allocation[bidder.ID] = units
+		remaining -= units
+	}
+
+	return allocation
+}
+
+// desiredQuantity returns the number of units the bidder wants.
+func (b *Bidder) desiredQuantity() int {
+	return max(b.DesiredQuantity, 1)
+}
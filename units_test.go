@@ -0,0 +1,50 @@
+package dispatchbidder
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDetermineWinners tests allocating the units of a multi-unit auction.
+func TestDetermineWinners(t *testing.T) {
+	t.Run("Demand exceeds supply", func(t *testing.T) {
+		alice := createBidder("Alice", 90.00, 100.00, 0)
+		alice.DesiredQuantity = 3
+		bob := createBidder("Bob", 80.00, 100.00, 0)
+		bob.DesiredQuantity = 4
+		carol := createBidder("Carol", 70.00, 100.00, 0)
+		carol.DesiredQuantity = 2
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob, carol}, Units: 5})
+		assert.NoError(t, err)
+
+		assert.Equal(t, map[uuid.UUID]int{alice.ID: 3, bob.ID: 2}, auction.DetermineWinners(), "Bob is the marginal winner")
+
+		assert.NoError(t, auction.PlaceBid(carol, 95.00))
+		assert.Equal(t, map[uuid.UUID]int{carol.ID: 2, alice.ID: 3}, auction.DetermineWinners())
+	})
+
+	t.Run("Single unit by default", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 80.00, 3.00)
+		bob := createBidder("Bob", 60.00, 82.00, 2.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+		assert.Equal(t, map[uuid.UUID]int{bob.ID: 1}, auction.DetermineWinners())
+
+		assert.NoError(t, auction.Cancel("withdrawn"))
+		assert.Empty(t, auction.DetermineWinners())
+	})
+
+	t.Run("Quantities are validated", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 80.00, 3.00)
+		alice.DesiredQuantity = -1
+		bob := createBidder("Bob", 60.00, 82.00, 2.00)
+
+		err := ValidateAll(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, Units: -2})
+		assert.ErrorContains(t, err, "units must not be negative, got -2")
+		assert.ErrorContains(t, err, "desired quantity must not be negative, got -1")
+	})
+}
@@ -0,0 +1,52 @@
+package dispatchbidder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Validator is a domain rule, such as a KYC or jurisdiction check, that a
+// manual bid must pass to be accepted. ValidateBid receives a snapshot of the
+// auction before the bid, the bidder and the amount, and returns an error to
+// reject the bid. It runs under the auction lock, so it must not call into
+// the auction.
+type Validator interface {
+	ValidateBid(ctx context.Context, snapshot AuctionSnapshot, bidderID uuid.UUID, amount float64) error
+}
+
+// ValidatorFunc adapts a function to the Validator interface.
+type ValidatorFunc func(ctx context.Context, snapshot AuctionSnapshot, bidderID uuid.UUID, amount float64) error
+
+// ValidateBid calls f.
+func (f ValidatorFunc) ValidateBid(ctx context.Context, snapshot AuctionSnapshot, bidderID uuid.UUID, amount float64) error {
+	return f(ctx, snapshot, bidderID, amount)
+}
+
+// WithValidators appends validators to the pipeline PlaceBid and
+// SubmitSealedBid run, in order, once a bid has passed the built-in checks
+// and before any state changes.
+// The first validator to return an error rejects the bid with
+// ErrBidNotValidated, wrapping that error; the later ones are not called.
+// Auto-raises are not validated.
+func WithValidators(validators ...Validator) Option {
+	return func(a *Auction) {
+		a.validators = append(a.validators, validators...)
+	}
+}
+
+// validate runs the validators on a manual bid. The caller must hold the
+// lock.
+func (a *Auction) validate(ctx context.Context, bidder *Bidder, amount float64) error {
+	if len(a.validators) == 0 {
+		return nil
+	}
+	snapshot := a.snapshot()
+	for i, v := range a.validators {
+		if err := v.ValidateBid(ctx, snapshot, bidder.ID, amount); err != nil {
+			return fmt.Errorf("%w: validator %d: %w", ErrBidNotValidated, i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package dispatchbidder
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestValidators tests that validators run in order and that the first
+// rejection stops the bid.
+func TestValidators(t *testing.T) {
+	errNoKYC := errors.New("bidder has not passed KYC")
+	alice := createBidder("Alice", 50.00, 100.00, 5.00)
+	bob := createBidder("Bob", 60.00, 100.00, 5.00)
+
+	var calls []string
+	record := func(name string, reject uuid.UUID) Validator {
+		return ValidatorFunc(func(_ context.Context, snapshot AuctionSnapshot, bidderID uuid.UUID, amount float64) error {
+			calls = append(calls, name)
+			if bidderID == reject {
+				return errNoKYC
+			}
+			return nil
+		})
+	}
+
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}},
+		WithValidators(record("first", uuid.Nil)),
+		WithValidators(record("kyc", bob.ID), record("last", uuid.Nil)),
+	)
+	assert.NoError(t, err)
+
+	assert.NoError(t, auction.PlaceBid(alice, 70.00))
+	assert.Equal(t, []string{"first", "kyc", "last"}, calls)
+
+	calls = nil
+	err = auction.PlaceBid(bob, 90.00)
+	assert.ErrorIs(t, err, ErrBidNotValidated)
+	assert.ErrorIs(t, err, errNoKYC)
+	assert.Equal(t, []string{"first", "kyc"}, calls, "validators after the rejection are not called")
+	assert.Equal(t, 65.00, bob.CurrentBid, "a rejected bid changes nothing")
+	assert.Len(t, auction.History(), 2)
+
+	calls = nil
+	assert.ErrorIs(t, auction.PlaceBid(alice, 40.00), ErrBelowStartingBid)
+	assert.Empty(t, calls, "built-in checks run first")
+
+	t.Run("Snapshot and context", func(t *testing.T) {
+		type key struct{}
+		var seen AuctionSnapshot
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{createBidder("Alice", 50.00, 100.00, 5.00), createBidder("Bob", 60.00, 100.00, 5.00)}},
+			WithValidators(ValidatorFunc(func(ctx context.Context, snapshot AuctionSnapshot, _ uuid.UUID, amount float64) error {
+				seen = snapshot
+				if ctx.Value(key{}) == nil {
+					return errors.New("missing jurisdiction")
+				}
+				return nil
+			})),
+		)
+		assert.NoError(t, err)
+		alice := auction.BidderList()[0]
+
+		assert.ErrorIs(t, auction.PlaceBid(alice, 70.00), ErrBidNotValidated)
+		assert.NoError(t, auction.PlaceBidContext(context.WithValue(context.Background(), key{}, "EU"), alice, 70.00))
+		assert.Equal(t, uint64(0), seen.Version, "the snapshot predates the bid")
+	})
+
+	t.Run("Sealed bids", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 100.00, 5.00)
+		bob := createBidder("Bob", 60.00, 100.00, 5.00)
+		calls = nil
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}, Mode: ModeSealedFirstPrice},
+			WithValidators(record("kyc", bob.ID)),
+		)
+		assert.NoError(t, err)
+
+		assert.NoError(t, auction.SubmitSealedBid(alice, 70.00))
+		err = auction.SubmitSealedBid(bob, 90.00)
+		assert.ErrorIs(t, err, ErrBidNotValidated)
+		assert.ErrorIs(t, err, errNoKYC)
+		assert.Equal(t, []string{"kyc", "kyc"}, calls)
+
+		result, err := auction.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, alice.ID, result.Winner.ID, "the rejected sealed bid is not counted")
+	})
+}
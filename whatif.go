@@ -0,0 +1,181 @@
+package dispatchbidder
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CheapestWinningBid returns the lowest amount the bidder could end up
+// winning with, simulated on a clone free of rate limits and validators:
+// whoever trails bids the minimum to lead, as given by MinBidToLead, with
+// auto-increments applying as usual, until no rival can afford to take the
+// lead back. It returns false when the bidder cannot win, because a rival's
+// MaxBid dominates theirs or because the bidder cannot bid. The live auction
+// is not touched.
+func (a *Auction) CheapestWinningBid(id uuid.UUID) (float64, bool) {
+	sim := a.simulation()
+
+	bidder, ok := sim.bidderByID(id)
+	if !ok || !bidder.inRunning() {
+		return 0, false
+	}
+
+	for round := 0; round < maxRounds; round++ {
+		if sim.State() != StateOpen {
+			break
+		}
+
+		if sim.Leader() != bidder {
+			amount, err := sim.MinBidToLead(id)
+			if err != nil || sim.PlaceBid(bidder, amount) != nil {
+				return 0, false
+			}
+			continue
+		}
+
+		responded := false
+		for _, rival := range sim.BidderList() {
+			if rival == bidder || sim.Leader() == rival {
+				continue
+			}
+			amount, err := sim.MinBidToLead(rival.ID)
+			if err == nil && amount <= rival.MaxBid && sim.PlaceBid(rival, amount) == nil {
+				responded = true
+			}
+		}
+		if !responded {
+			break
+		}
+	}
+
+	if sim.DetermineWinner() != bidder {
+		return 0, false
+	}
+	return bidder.CurrentBid, true
+}
+
+// WouldHaveWonWith returns the lowest amount the bidder could have bid
+// instead of their last manual bid to win the auction. It replays the
+// history from that moment on a clone: the other bidders' later manual bids
+// are placed as recorded, when still legal, with auto-increments applying as
+// usual. It returns false if the bidder never bid, already wins, or no amount
+// within their MaxBid would have won. The live auction is not touched.
+func (a *Auction) WouldHaveWonWith(id uuid.UUID) (float64, bool) {
+	sim := a.simulation()
+
+	bidder, ok := sim.bidderByID(id)
+	if !ok || sim.DetermineWinner() == bidder {
+		return 0, false
+	}
+	last := -1
+	for i, event := range sim.history {
+		if event.BidderID == id && !event.Auto {
+			last = i
+		}
+	}
+	if last < 0 {
+		return 0, false
+	}
+
+	// Candidate amounts are the grid points, or cents, above the lost bid.
+	step := sim.BidGridStep
+	if step == 0 {
+		step = cent
+	}
+	grid := func(k int) float64 { return bidder.StartingBid + float64(k)*step }
+	index := func(amount float64) int { return int(math.Floor((amount-bidder.StartingBid)/step + gridTolerance)) }
+
+	top := bidder.MaxBid
+	if !sim.withinCap(top) {
+		top = sim.AuctionMaxBid
+	}
+	lo, hi := index(sim.history[last].Amount), index(top)
+	if hi <= lo || !sim.wins(last, id, grid(hi)) {
+		return 0, false
+	}
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		if sim.wins(last, id, grid(mid)) {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+
+	return math.Round(grid(hi)/cent) * cent, true
+}
+
+// wins reports whether the bidder would win, on a clone, had the nth event of
+// the history, their manual bid, been the given amount instead. The later
+// manual bids of the other bidders are replayed at their recorded times.
+func (a *Auction) wins(n int, id uuid.UUID, amount float64) bool {
+	sim := a.simulation()
+	clock := &replayClock{}
+	sim.clock = clock
+
+	at := sim.history[n].Time
+	later := append([]BidEvent(nil), sim.history[n+1:]...)
+	sim.rewind(n)
+
+	bidder, _ := sim.bidderByID(id)
+	clock.at = at
+	if sim.PlaceBid(bidder, amount) != nil {
+		return false
+	}
+	for _, event := range later {
+		if event.Auto || event.BidderID == id {
+			continue
+		}
+		if other, ok := sim.bidderByID(event.BidderID); ok {
+			clock.at = event.Time
+			_ = sim.PlaceBid(other, event.Amount)
+		}
+	}
+
+	return sim.DetermineWinner() == bidder
+}
+
+// simulation returns a clone of the auction for what-if analysis, with rate
+// limiting and the validators turned off: simulated bids are judged by the
+// auction rules alone and do not drain the clone's buckets.
+func (a *Auction) simulation() *Auction {
+	sim := a.Clone()
+	sim.rateLimited = false
+	sim.validators = nil
+	return sim
+}
+
+// rewind reverts the auction to its state before the nth event of the
+// history, restoring the bidders from the later events in reverse order, and
+// reopens it. The WithdrawAboveMax withdrawals are worked out again from the
+// restored bids. The caller must hold the lock.
+func (a *Auction) rewind(n int) {
+	for i := len(a.history) - 1; i >= n; i-- {
+		if bidder, ok := a.bidderByID(a.history[i].BidderID); ok {
+			a.history[i].restore(bidder)
+		}
+	}
+	for _, bidder := range a.Bidders {
+		bidder.withdrawn = false
+	}
+	a.withdrawOutpriced()
+	a.history = a.history[:n]
+	a.state = StateOpen
+	a.winner = nil
+	a.closedAt = time.Time{}
+	a.paused = false
+	a.refreshLeader()
+}
+
+// replayClock is a Clock returning a settable time, for replaying bids at
+// their recorded times.
+type replayClock struct {
+	at time.Time
+}
+
+// Now returns the time set on the clock.
+func (c *replayClock) Now() time.Time {
+	return c.at
+}
@@ -0,0 +1,128 @@
+package dispatchbidder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCheapestWinningBid tests simulating the cheapest path to win.
+func TestCheapestWinningBid(t *testing.T) {
+	tests := []struct {
+		name           string
+		rivalMax       float64
+		expectedAmount float64
+		expectedOK     bool
+	}{
+		{name: "Winning is possible", rivalMax: 80.00, expectedAmount: 81.00, expectedOK: true},
+		{name: "Higher-max rival makes winning impossible", rivalMax: 150.00},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alice := createBidder("Alice", 50.00, 100.00, 5.00)
+			bob := createBidder("Bob", 60.00, tt.rivalMax, 2.00)
+			carol := createBidder("Carol", 40.00, 70.00, 0)
+
+			auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob, carol}, MinIncrement: 1.00})
+			assert.NoError(t, err)
+			before := auction.Snapshot()
+
+			amount, ok := auction.CheapestWinningBid(alice.ID)
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expectedAmount, amount)
+
+			after := auction.Snapshot()
+			assert.Equal(t, before.Bidders, after.Bidders, "the live auction is not mutated")
+			assert.Empty(t, auction.History())
+		})
+	}
+
+	t.Run("Leader nobody can answer wins at their bid", func(t *testing.T) {
+		alice := createBidder("Alice", 90.00, 100.00, 5.00)
+		bob := createBidder("Bob", 60.00, 80.00, 2.00)
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob}})
+		assert.NoError(t, err)
+
+		amount, ok := auction.CheapestWinningBid(alice.ID)
+		assert.True(t, ok)
+		assert.Equal(t, 90.00, amount)
+	})
+
+	t.Run("Rate limits and validators do not apply", func(t *testing.T) {
+		alice := createBidder("Alice", 50.00, 100.00, 5.00)
+		bob := createBidder("Bob", 60.00, 80.00, 2.00)
+		carol := createBidder("Carol", 40.00, 70.00, 0)
+		rejectAll := ValidatorFunc(func(context.Context, AuctionSnapshot, uuid.UUID, float64) error {
+			return errors.New("closed for review")
+		})
+
+		auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob, carol}, MinIncrement: 1.00},
+			WithClock(newManualClock()), WithBidRateLimit(1, 1), WithValidators(rejectAll))
+		assert.NoError(t, err)
+
+		amount, ok := auction.CheapestWinningBid(alice.ID)
+		assert.True(t, ok)
+		assert.Equal(t, 81.00, amount)
+
+		probabilities := auction.WinProbabilities(10, 1)
+		assert.Equal(t, 1.0, probabilities[alice.ID])
+		assert.NotEmpty(t, auction.Explain())
+		assert.ErrorIs(t, auction.PlaceBid(alice, 70.00), ErrBidNotValidated, "the live auction keeps them")
+	})
+}
+
+// TestWouldHaveWonWith tests finding the winning move a loser missed.
+func TestWouldHaveWonWith(t *testing.T) {
+	// Large increments keep auto-bumps out of the recorded run.
+	alice := createBidder("Alice", 50.00, 100.00, 500.00)
+	bob := createBidder("Bob", 50.00, 120.00, 500.00)
+	carol := createBidder("Carol", 50.00, 95.00, 500.00)
+	dave := createBidder("Dave", 50.00, 88.00, 500.00)
+
+	clock := newManualClock()
+	auction, err := NewAuction(NewAuctionConfig{Bidders: []*Bidder{alice, bob, carol, dave}}, WithClock(clock))
+	assert.NoError(t, err)
+
+	for _, bid := range []struct {
+		bidder *Bidder
+		amount float64
+	}{{dave, 60.00}, {alice, 70.00}, {bob, 80.00}, {carol, 85.00}, {bob, 90.00}} {
+		clock.Advance(time.Second)
+		assert.NoError(t, auction.PlaceBid(bid.bidder, bid.amount))
+	}
+	_, err = auction.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, bob, auction.DetermineWinner())
+	before := auction.Snapshot()
+
+	tests := []struct {
+		name           string
+		bidder         *Bidder
+		expectedAmount float64
+		expectedOK     bool
+	}{
+		{name: "Close loser ties Bob's later bid and wins as the earlier bidder", bidder: carol, expectedAmount: 90.00, expectedOK: true},
+		{name: "Early loser", bidder: alice, expectedAmount: 90.00, expectedOK: true},
+		{name: "Rival's bid is above the loser's MaxBid", bidder: dave},
+		{name: "Winner", bidder: bob},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, ok := auction.WouldHaveWonWith(tt.bidder.ID)
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expectedAmount, amount)
+		})
+	}
+
+	after := auction.Snapshot()
+	assert.Equal(t, before.Bidders, after.Bidders, "the live auction is not mutated")
+	assert.Equal(t, StateClosed, after.State)
+	assert.Len(t, auction.History(), 5)
+}
@@ -0,0 +1,76 @@
+// Package wsstream streams live auction snapshots to websocket clients, for
+// bid boards that want push updates. It keeps the websocket dependency out of
+// the core dispatchbidder package.
+package wsstream
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	dispatchbidder "github.com/leandrorichard/dispatch-bidder"
+)
+
+// writeTimeout bounds how long a single message write may block.
+const writeTimeout = 10 * time.Second
+
+// Upgrader is used to upgrade HTTP requests to websocket connections. Replace
+// it to customize buffer sizes or the origin check.
+var Upgrader = websocket.Upgrader{}
+
+// Serve upgrades the request to a websocket and streams the auction as JSON
+// AuctionSnapshots: the current snapshot first, then one after every change.
+// It returns when the client disconnects or after sending the snapshot in
+// which the auction is closed or cancelled, closing the connection. On an
+// upgrade failure, the error response has already been written.
+func Serve(a *dispatchbidder.Auction, w http.ResponseWriter, r *http.Request) error {
+	conn, err := Upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	updates, cancel := a.Subscribe()
+	defer cancel()
+
+	// The client only ever sends control frames; reading them is how a
+	// disconnect is noticed.
+	gone := make(chan struct{})
+	go func() {
+		defer close(gone)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-gone:
+			return nil
+		case snapshot := <-updates:
+			conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := conn.WriteJSON(snapshot); err != nil {
+				return err
+			}
+			if isFinished(snapshot.State) {
+				msg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "auction "+snapshot.State.String())
+				return conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(writeTimeout))
+			}
+		}
+	}
+}
+
+// Handler returns an http.Handler serving the auction stream with Serve.
+func Handler(a *dispatchbidder.Auction) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Serve(a, w, r)
+	})
+}
+
+// isFinished reports whether the auction no longer changes in this state.
+func isFinished(state dispatchbidder.State) bool {
+	return state == dispatchbidder.StateClosed || state == dispatchbidder.StateCancelled || state == dispatchbidder.StateVoided
+}
@@ -0,0 +1,87 @@
+package wsstream
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+
+	dispatchbidder "github.com/leandrorichard/dispatch-bidder"
+)
+
+// newAuction creates a two-bidder auction for the stream tests.
+func newAuction(t *testing.T) (*dispatchbidder.Auction, *dispatchbidder.Bidder) {
+	alice := &dispatchbidder.Bidder{ID: uuid.New(), Name: "Alice", StartingBid: 50.00, MaxBid: 80.00, CurrentBid: 50.00, AutoIncrement: 3.00}
+	bob := &dispatchbidder.Bidder{ID: uuid.New(), Name: "Bob", StartingBid: 60.00, MaxBid: 82.00, CurrentBid: 60.00, AutoIncrement: 2.00}
+
+	auction, err := dispatchbidder.NewAuction(dispatchbidder.NewAuctionConfig{Bidders: []*dispatchbidder.Bidder{alice, bob}})
+	assert.NoError(t, err)
+
+	return auction, alice
+}
+
+// dial connects a test websocket client to the server.
+func dial(t *testing.T, server *httptest.Server) *websocket.Conn {
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	assert.NoError(t, err)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	return conn
+}
+
+// TestServe tests streaming snapshots to a websocket client.
+func TestServe(t *testing.T) {
+	t.Run("Initial snapshot, updates and close", func(t *testing.T) {
+		auction, alice := newAuction(t)
+		server := httptest.NewServer(Handler(auction))
+		defer server.Close()
+
+		conn := dial(t, server)
+		defer conn.Close()
+
+		var snapshot dispatchbidder.AuctionSnapshot
+		assert.NoError(t, conn.ReadJSON(&snapshot))
+		assert.Equal(t, auction.ID, snapshot.ID)
+		assert.Equal(t, uint64(0), snapshot.Version)
+
+		assert.NoError(t, auction.PlaceBid(alice, 65.00))
+		assert.NoError(t, conn.ReadJSON(&snapshot))
+		assert.Equal(t, uint64(1), snapshot.Version)
+		assert.Equal(t, auction.Snapshot().LeaderID, snapshot.LeaderID)
+
+		_, err := auction.Close()
+		assert.NoError(t, err)
+		assert.NoError(t, conn.ReadJSON(&snapshot))
+		assert.Equal(t, dispatchbidder.StateClosed, snapshot.State)
+
+		_, _, err = conn.ReadMessage()
+		assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), "got %v", err)
+	})
+
+	t.Run("Client disconnect ends the stream", func(t *testing.T) {
+		auction, _ := newAuction(t)
+
+		done := make(chan error, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			done <- Serve(auction, w, r)
+		}))
+		defer server.Close()
+
+		conn := dial(t, server)
+		var snapshot dispatchbidder.AuctionSnapshot
+		assert.NoError(t, conn.ReadJSON(&snapshot))
+		conn.Close()
+
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Serve did not return after the client disconnected")
+		}
+	})
+}